@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// maxValueWidth 差异中显示的值的最大宽度，只影响显示，比较总是使用完整的值
+const maxValueWidth = 60
+
+// runDump 把当前环境变量按KEY=VALUE每行一个写到标准输出，供diff比较
+func runDump() error {
+	env := os.Environ()
+	sort.Strings(env)
+	for _, e := range env {
+		if _, err := fmt.Println(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runDiff 比较两个环境变量快照，终端相关的变量单独列在前面
+func runDiff(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: envtest diff FILE1 FILE2")
+	}
+	old, err := readEnvFile(args[0])
+	if err != nil {
+		return err
+	}
+	new, err := readEnvFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	changes := terminal.DiffEnv(old, new)
+	counts := map[string]int{}
+	var important, other []terminal.EnvChange
+	for _, c := range changes {
+		counts[c.Kind]++
+		if terminal.IsTerminalEnvVar(c.Key) {
+			important = append(important, c)
+		} else {
+			other = append(other, c)
+		}
+	}
+
+	if len(changes) == 0 {
+		fmt.Printf("%s No differences\n", utils.CurrentSymbols().Colored().Success)
+		return nil
+	}
+	if len(important) > 0 {
+		fmt.Println("=== Terminal-relevant changes ===")
+		for _, c := range important {
+			fmt.Printf("%s %s\n", utils.CurrentSymbols().Colored().Warning, formatChange(c))
+		}
+		fmt.Println()
+	}
+	if len(other) > 0 {
+		fmt.Println("=== Other changes ===")
+		for _, c := range other {
+			fmt.Printf("  %s\n", formatChange(c))
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%d added, %d removed, %d changed\n",
+		counts[terminal.EnvAdded], counts[terminal.EnvRemoved], counts[terminal.EnvChanged])
+	return nil
+}
+
+// readEnvFile 读取并解析环境变量快照
+func readEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取环境变量文件失败: %w", err)
+	}
+	return terminal.ParseEnvFile(data), nil
+}
+
+// formatChange 格式化一处差异：+新增、-删除、~修改
+func formatChange(c terminal.EnvChange) string {
+	switch c.Kind {
+	case terminal.EnvAdded:
+		return fmt.Sprintf("+ %s=%s", c.Key, displayValue(c.New))
+	case terminal.EnvRemoved:
+		return fmt.Sprintf("- %s=%s", c.Key, displayValue(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Key, displayValue(c.Old), displayValue(c.New))
+	}
+}
+
+// displayValue 加引号显示值，使首尾空白和控制字符可见，过长时截断
+func displayValue(value string) string {
+	return utils.FitToWidth(fmt.Sprintf("%q", value), maxValueWidth)
+}
@@ -4,9 +4,33 @@ import (
 	"fmt"
 	"os"
 	"sort"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		var err error
+		switch os.Args[1] {
+		case "dump":
+			err = runDump()
+		case "diff":
+			err = runDiff(os.Args[2:])
+		default:
+			err = fmt.Errorf("unknown command %q (usage: envtest [dump | diff FILE1 FILE2])", os.Args[1])
+		}
+		if err != nil {
+			utils.PrintError(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	printReport()
+}
+
+// printReport 打印当前的环境变量和终端相关检查
+func printReport() {
 	fmt.Println("=== Environment Variables ===")
 
 	// Get all environment variables
@@ -43,14 +67,14 @@ func main() {
 
 	// Check if TERM is set
 	if term := os.Getenv("TERM"); term == "" {
-		fmt.Println("\n⚠️  WARNING: TERM environment variable is not set!")
+		fmt.Printf("\n%s  WARNING: TERM environment variable is not set!\n", utils.CurrentSymbols().Colored().Warning)
 		fmt.Println("This can cause terminal detection problems.")
 	} else {
-		fmt.Printf("\n✓ TERM is set to: %s\n", term)
+		fmt.Printf("\n%s TERM is set to: %s\n", utils.CurrentSymbols().Colored().Success, term)
 	}
 
 	// Check if we're in omnish
 	if os.Getenv("OMNISH_SESSION_ID") != "" {
-		fmt.Println("\n✓ Running inside omnish")
+		fmt.Printf("\n%s Running inside omnish\n", utils.CurrentSymbols().Colored().Success)
 	}
-}
\ No newline at end of file
+}
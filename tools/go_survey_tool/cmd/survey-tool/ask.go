@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// questionFlag 把同一类型的命令行参数解析为问题，追加到共享的列表中以保留参数的顺序
+type questionFlag struct {
+	typ       string
+	questions *[]survey.Question
+}
+
+func (f questionFlag) String() string { return "" }
+
+func (f questionFlag) Set(spec string) error {
+	q, err := survey.ParseQuestionSpec(f.typ, spec)
+	if err != nil {
+		return err
+	}
+	for _, existing := range *f.questions {
+		if existing.Name == q.Name {
+			return fmt.Errorf("duplicate question name %q", q.Name)
+		}
+	}
+	*f.questions = append(*f.questions, q)
+	return nil
+}
+
+// runAsk 按参数的顺序提问，把答案以key=value每行一个输出到标准输出，
+// -env时改为输出export语句，可以直接eval到当前shell中
+// 提示显示在标准错误上；参数语法见survey.ParseQuestionSpec
+func runAsk(args []string) error {
+	var questions []survey.Question
+	fs := flag.NewFlagSet("ask", flag.ContinueOnError)
+	fs.Var(questionFlag{survey.TypeInput, &questions}, "input", "ask for text: `NAME[=DEFAULT]`")
+	fs.Var(questionFlag{survey.TypePassword, &questions}, "password", "ask for a hidden value: `NAME`")
+	fs.Var(questionFlag{survey.TypeConfirm, &questions}, "confirm", "ask yes or no: `NAME[=yes|no]`")
+	fs.Var(questionFlag{survey.TypeSelect, &questions}, "select", "choose one: `NAME:OPTION,OPTION`")
+	fs.Var(questionFlag{survey.TypeMultiSelect, &questions}, "multiselect", "choose several: `NAME:OPTION,OPTION`")
+	env := fs.Bool("env", false, "print export NAME='VALUE' lines for eval in a shell")
+	boolSpec := fs.String("bool", "true/false", "print yes/no answers as `FORMAT` (true/false, yes/no or 1/0)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	bools, err := utils.ParseBoolFormat(*boolSpec)
+	if err != nil {
+		return err
+	}
+	if len(questions) == 0 || fs.NArg() > 0 {
+		return errors.New("usage: survey-tool ask [-env] [-bool FORMAT] [--input NAME[=DEFAULT]] [--select NAME:A,B] [--confirm NAME] ...")
+	}
+	if *env {
+		if err := checkEnvNames(questions); err != nil {
+			return err
+		}
+	}
+
+	opts, closeTranscript, err := transcriptOptions()
+	if err != nil {
+		return err
+	}
+	defer closeTranscript()
+	runner := survey.NewRunner(append(opts, survey.WithStdio(os.Stdin, os.Stderr, os.Stderr))...)
+	answers, err := runner.AskAll(questions)
+	if err != nil {
+		return err
+	}
+	for _, q := range questions {
+		value := bools.Apply(answers[q.Name])
+		if *env {
+			fmt.Println(utils.ExportLine(q.Name, value))
+			continue
+		}
+		fmt.Printf("%s=%s\n", q.Name, utils.FormatValue(value))
+	}
+	return nil
+}
+
+// checkEnvNames 确认各问题名转换成环境变量名后不重复，例如db-host和db_host都会成为DB_HOST
+func checkEnvNames(questions []survey.Question) error {
+	seen := make(map[string]string, len(questions))
+	for _, q := range questions {
+		name := utils.EnvName(q.Name)
+		if other, ok := seen[name]; ok {
+			return fmt.Errorf("questions %q and %q both export %s", other, q.Name, name)
+		}
+		seen[name] = q.Name
+	}
+	return nil
+}
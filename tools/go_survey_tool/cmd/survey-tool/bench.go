@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+// benchReport bench-input的测量结果，时间以毫秒为单位
+type benchReport struct {
+	Samples       []float64 `json:"samples_ms"`
+	Min           float64   `json:"min_ms"`
+	Avg           float64   `json:"avg_ms"`
+	Max           float64   `json:"max_ms"`
+	EscapeTimeout float64   `json:"recommended_escape_timeout_ms"`
+}
+
+// runBenchInput 测量终端输入的往返时间，输出最小、平均和最长时间以及推荐的转义序列等待时间
+// 测量通过in所在的终端进行，结束后恢复终端状态
+func runBenchInput(args []string, in *os.File, out io.Writer) error {
+	fs := flag.NewFlagSet("bench-input", flag.ContinueOnError)
+	count := fs.Int("count", 5, "number of round trips to measure")
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New("usage: survey-tool bench-input [-count N] [-json]")
+	}
+	if *count <= 0 {
+		return fmt.Errorf("invalid -count %d, want at least 1", *count)
+	}
+
+	samples, err := terminal.MeasureLatency(int(in.Fd()), *count, probeTimeout)
+	switch {
+	case errors.Is(err, terminal.ErrNotTerminal):
+		return fmt.Errorf("bench-input needs a terminal on stdin: %w", err)
+	case errors.Is(err, terminal.ErrTerminalUnresponsive):
+		return fmt.Errorf("%w: no reply within %v", err, probeTimeout)
+	case err != nil:
+		return fmt.Errorf("测量失败: %w", err)
+	}
+
+	report := newBenchReport(samples)
+	if *asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	return report.writeText(out)
+}
+
+// newBenchReport 统计各次往返时间
+func newBenchReport(samples []time.Duration) benchReport {
+	report := benchReport{Samples: make([]float64, len(samples))}
+	if len(samples) == 0 {
+		return report
+	}
+	minD, maxD, total := samples[0], samples[0], time.Duration(0)
+	for i, d := range samples {
+		report.Samples[i] = milliseconds(d)
+		minD, maxD = min(minD, d), max(maxD, d)
+		total += d
+	}
+	report.Min = milliseconds(minD)
+	report.Avg = milliseconds(total / time.Duration(len(samples)))
+	report.Max = milliseconds(maxD)
+	report.EscapeTimeout = milliseconds(recommendedEscapeTimeout(maxD))
+	return report
+}
+
+// 推荐的转义序列等待时间的下限和取整单位
+const (
+	minEscapeTimeout  = 25 * time.Millisecond
+	escapeTimeoutStep = 5 * time.Millisecond
+)
+
+// recommendedEscapeTimeout 按最长的往返时间推荐单独的ESC之后等待序列其余字节的时间：
+// 取最长往返时间的两倍，向上取整到5ms，至少25ms
+func recommendedEscapeTimeout(longest time.Duration) time.Duration {
+	timeout := 2 * longest
+	if rem := timeout % escapeTimeoutStep; rem != 0 {
+		timeout += escapeTimeoutStep - rem
+	}
+	return max(timeout, minEscapeTimeout)
+}
+
+// milliseconds 把时间转换为毫秒，保留到微秒
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// writeText 以便于阅读的格式输出结果
+func (r benchReport) writeText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "Input round trip over %d samples:\n  min  %.2fms\n  avg  %.2fms\n  max  %.2fms\nRecommended escape timeout: %gms\n",
+		len(r.Samples), r.Min, r.Avg, r.Max, r.EscapeTimeout)
+	return err
+}
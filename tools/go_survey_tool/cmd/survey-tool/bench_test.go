@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewBenchReport(t *testing.T) {
+	report := newBenchReport([]time.Duration{1500 * time.Microsecond, 500 * time.Microsecond, 40 * time.Millisecond})
+	want := benchReport{
+		Samples:       []float64{1.5, 0.5, 40},
+		Min:           0.5,
+		Avg:           14,
+		Max:           40,
+		EscapeTimeout: 80,
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("newBenchReport() = %+v, want %+v", report, want)
+	}
+
+	var out bytes.Buffer
+	if err := report.writeText(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"over 3 samples", "min  0.50ms", "avg  14.00ms", "max  40.00ms", "Recommended escape timeout: 80ms"} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("text report missing %q:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestRecommendedEscapeTimeout(t *testing.T) {
+	tests := []struct {
+		longest, want time.Duration
+	}{
+		{200 * time.Microsecond, 25 * time.Millisecond},
+		{12 * time.Millisecond, 25 * time.Millisecond},
+		{31 * time.Millisecond, 65 * time.Millisecond},
+		{100 * time.Millisecond, 200 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := recommendedEscapeTimeout(tt.longest); got != tt.want {
+			t.Errorf("recommendedEscapeTimeout(%v) = %v, want %v", tt.longest, got, tt.want)
+		}
+	}
+}
+
+func TestRunBenchInputNotTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	var out bytes.Buffer
+	if err := runBenchInput(nil, r, &out); err == nil || !strings.Contains(err.Error(), "needs a terminal") {
+		t.Errorf("runBenchInput() on a pipe error = %v, want a terminal error", err)
+	}
+	if err := runBenchInput([]string{"-count", "0"}, r, &out); err == nil {
+		t.Error("runBenchInput() with -count 0 should fail")
+	}
+	if out.Len() != 0 {
+		t.Errorf("failed runs should print nothing, got %q", out.String())
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+// confirm子命令的退出状态，与dialog工具一致，便于在shell的if中直接使用
+const (
+	confirmYes   = 0 // 回答yes
+	confirmNo    = 1 // 回答no
+	confirmError = 2 // 参数错误、输入结束或被中断
+)
+
+// exitStatus 要求main以指定状态退出且不再打印错误
+type exitStatus int
+
+func (s exitStatus) Error() string {
+	return fmt.Sprintf("exit status %d", int(s))
+}
+
+// runConfirm 询问一个是否问题，回答yes时返回nil，no时返回exitStatus(confirmNo)。
+// 其他错误先输出到errOut，再返回exitStatus(confirmError)，不会与no混淆。
+// 提示显示在errOut上；不带-quiet时把yes或no输出到out
+func runConfirm(args []string, in io.Reader, out, errOut io.Writer) error {
+	fs := flag.NewFlagSet("confirm", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	def := fs.String("default", "no", "answer used when enter is pressed (yes|no)")
+	quiet := fs.Bool("quiet", false, "print nothing to stdout, only set the exit status")
+	if err := fs.Parse(args); err != nil {
+		return exitStatus(confirmError)
+	}
+
+	answer, err := askConfirm(in, errOut, strings.Join(fs.Args(), " "), *def)
+	if err != nil {
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		return exitStatus(confirmError)
+	}
+	if !*quiet {
+		word := "no"
+		if answer {
+			word = "yes"
+		}
+		fmt.Fprintln(out, word)
+	}
+	if !answer {
+		return exitStatus(confirmNo)
+	}
+	return nil
+}
+
+// askConfirm 校验参数后用in和errOut询问message
+func askConfirm(in io.Reader, errOut io.Writer, message, def string) (bool, error) {
+	if strings.TrimSpace(message) == "" {
+		return false, fmt.Errorf("no message given")
+	}
+	var value bool
+	switch strings.ToLower(def) {
+	case "yes", "y":
+		value = true
+	case "no", "n":
+	default:
+		return false, fmt.Errorf("invalid -default %q, want yes or no", def)
+	}
+	runner := survey.NewRunner(survey.WithStdio(in, errOut, errOut))
+	return runner.AskConfirm(message, value)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRunConfirm(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		input  string
+		status int
+		stdout string
+	}{
+		{"Yes", []string{"Proceed?"}, "y\n", confirmYes, "yes\n"},
+		{"No", []string{"Proceed?"}, "no\n", confirmNo, "no\n"},
+		{"Default no", []string{"Proceed?"}, "\n", confirmNo, "no\n"},
+		{"Default yes", []string{"-default", "yes", "Proceed?"}, "\n", confirmYes, "yes\n"},
+		{"Quiet yes", []string{"-quiet", "Proceed?"}, "yes\n", confirmYes, ""},
+		{"Quiet no", []string{"-quiet", "Proceed?"}, "n\n", confirmNo, ""},
+		{"End of input", []string{"Proceed?"}, "", confirmError, ""},
+		{"Invalid default", []string{"-default", "maybe", "Proceed?"}, "y\n", confirmError, ""},
+		{"No message", nil, "y\n", confirmError, ""},
+		{"Unknown flag", []string{"-bogus", "Proceed?"}, "y\n", confirmError, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := runConfirm(tt.args, strings.NewReader(tt.input), &stdout, &stderr)
+
+			status := confirmYes
+			var exit exitStatus
+			if errors.As(err, &exit) {
+				status = int(exit)
+			} else if err != nil {
+				t.Fatalf("runConfirm() error = %v, want nil or an exitStatus", err)
+			}
+			if status != tt.status {
+				t.Errorf("exit status = %d, want %d (stderr: %s)", status, tt.status, stderr.String())
+			}
+			if stdout.String() != tt.stdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.stdout)
+			}
+		})
+	}
+}
+
+func TestRunConfirmPromptsOnStderr(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := runConfirm([]string{"-quiet", "Proceed?"}, strings.NewReader("y\n"), &stdout, &stderr); err != nil {
+		t.Fatalf("runConfirm() error = %v", err)
+	}
+	if !strings.Contains(stderr.String(), "Proceed?") {
+		t.Errorf("prompt should be written to stderr, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/doctor"
+)
+
+// runDoctor 运行所有终端检查并输出报告
+func runDoctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	report := doctor.Run(doctor.DefaultEnv())
+	if *asJSON {
+		return report.WriteJSON(os.Stdout)
+	}
+	return report.WriteText(os.Stdout)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// exampleAnswers 把example子命令的位置参数按顺序作为问题的答案，例如"Alice Blue yes"，
+// 每个参数按问题类型检查：select接受选项或从1开始的编号，confirm按utils.ParseBool解析。
+// 参数个数必须与问题数相同
+func exampleAnswers(questions []survey.Question, args []string) (map[string]interface{}, error) {
+	if len(args) != len(questions) {
+		names := make([]string, len(questions))
+		for i, q := range questions {
+			names[i] = q.Name
+		}
+		return nil, fmt.Errorf("example takes %d answers (%s), got %d", len(questions), strings.Join(names, ", "), len(args))
+	}
+
+	answers := make(map[string]interface{}, len(questions))
+	for i, q := range questions {
+		value, err := argAnswer(q, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("answer %d (%s): %w", i+1, q.Name, err)
+		}
+		answers[q.Name] = value
+	}
+	return answers, nil
+}
+
+// argAnswer 把一个参数解析为问题q的答案
+func argAnswer(q survey.Question, arg string) (interface{}, error) {
+	switch q.Type {
+	case survey.TypeInput, "":
+		if q.Validate != nil {
+			if err := q.Validate(arg); err != nil {
+				return nil, err
+			}
+		}
+		return arg, nil
+	case survey.TypeConfirm:
+		return utils.ParseBool(arg)
+	case survey.TypeSelect:
+		if _, ok := utils.OptionIndex(q.Options, arg); ok {
+			return arg, nil
+		}
+		if n, err := strconv.Atoi(arg); err == nil {
+			if option, ok := utils.OptionAt(q.Options, n-1); ok {
+				return option, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid choice %q, want one of %s", arg, strings.Join(q.Options, ", "))
+	}
+	return nil, fmt.Errorf("%s questions cannot be answered from arguments", q.Type)
+}
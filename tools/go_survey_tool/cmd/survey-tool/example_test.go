@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+func TestExampleAnswers(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{"In order", []string{"Alice", "Blue", "yes"}, map[string]interface{}{"name": "Alice", "color": "Blue", "confirm": true}, false},
+		{"Select by number", []string{"Bob", "3", "n"}, map[string]interface{}{"name": "Bob", "color": "Green", "confirm": false}, false},
+		{"ParseBool forms", []string{"Carol", "Red", "TRUE"}, map[string]interface{}{"name": "Carol", "color": "Red", "confirm": true}, false},
+		{"Invalid boolean", []string{"Alice", "Blue", "maybe"}, nil, true},
+		{"Invalid color", []string{"Alice", "Purple", "yes"}, nil, true},
+		{"Color number out of range", []string{"Alice", "5", "yes"}, nil, true},
+		{"Too few", []string{"Alice", "Blue"}, nil, true},
+		{"Too many", []string{"Alice", "Blue", "yes", "extra"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := exampleAnswers(survey.CreateSurveyQuestions(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("exampleAnswers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("exampleAnswers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExampleAnswersUnsupportedType(t *testing.T) {
+	questions := []survey.Question{{Name: "tags", Type: survey.TypeMultiSelect, Options: []string{"a"}}}
+	if _, err := exampleAnswers(questions, []string{"a"}); err == nil {
+		t.Error("multiselect answers from arguments should be rejected")
+	}
+}
+
+func TestRunExampleChecksRecordFirst(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := *recordAnswers
+	*recordAnswers = path
+	t.Cleanup(func() { *recordAnswers = old })
+
+	// 标准输入不是终端且没有输入：应该在提问之前就因为文件已存在而失败，而不是先读取答案
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	defer r.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = stdin })
+
+	var out bytes.Buffer
+	if err := runExample(&out, nil); !errors.Is(err, utils.ErrFileExists) {
+		t.Fatalf("runExample() error = %v, want ErrFileExists", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("nothing should be asked or printed, got %q", out.String())
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("existing record file changed to %q", data)
+	}
+}
+
+func TestTranscriptOptionsExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.txt")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := *transcript
+	*transcript = path
+	t.Cleanup(func() { *transcript = old })
+
+	// 标准输入不是终端，无法确认覆盖
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	defer r.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = stdin })
+
+	if _, _, err := transcriptOptions(); !errors.Is(err, utils.ErrFileExists) {
+		t.Fatalf("transcriptOptions() error = %v, want ErrFileExists", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("existing transcript changed to %q", data)
+	}
+
+	oldForce := *force
+	*force = true
+	t.Cleanup(func() { *force = oldForce })
+	_, closeFile, err := transcriptOptions()
+	if err != nil {
+		t.Fatalf("transcriptOptions() with -force error = %v", err)
+	}
+	closeFile()
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+	"golang.org/x/term"
+)
+
+var (
+	fullscreen       = flag.Bool("fullscreen", false, "run prompts in the terminal's alternate screen buffer")
+	forceInteractive = flag.Bool("force-interactive", false, "use interactive prompts even when TERM is dumb or unset")
+	timing           = flag.Bool("timing", false, "print how long the example survey took")
+	postURL          = flag.String("post-url", "", "POST the example survey results as JSON to `URL`")
+	proto            = flag.String("proto", "", "read a request from stdin and write the answers to stdout (json)")
+	transcript       = flag.String("transcript", "", "write the questions and answers to `FILE`, passwords redacted")
+	quietMode        = flag.Bool("quiet", false, "print no banner or completion line; status messages go to stderr")
+	resultFormat     = flag.String("format", "text", "print the example survey results as `FORMAT` (text, toml, json or env)")
+	boolFormat       = flag.String("bool", "true/false", "write yes/no answers in toml, json and env output as `FORMAT` (true/false, yes/no or 1/0)")
+	recordAnswers    = flag.String("record", "", "write the example survey answers as JSON to `FILE` for -answers")
+	answersFile      = flag.String("answers", "", "answer the example survey from a JSON `FILE` written by -record")
+	force            = flag.Bool("force", false, "overwrite files given to -record or -transcript without asking")
+)
+
+func main() {
+	flag.Usage = printHelp
+	flag.Parse()
+	utils.ConfirmOverwrite = confirmOverwrite
+
+	if *proto != "" {
+		if err := runProto(*proto); err != nil {
+			utils.PrintError(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// 以下命令的输出会被脚本解析或捕获，不打印标题等额外内容
+	if script := scriptCommand(flag.Args()); script != nil {
+		if err := script(); err != nil {
+			var status exitStatus
+			if !errors.As(err, &status) {
+				utils.PrintError(err)
+				status = 1
+			}
+			os.Exit(int(status))
+		}
+		return
+	}
+
+	if err := runInteractive(flag.Args(), os.Stdout, os.Stderr); err != nil {
+		utils.PrintError(err)
+		os.Exit(1)
+	}
+}
+
+// runInteractive 运行交互式的子命令，前后输出标题和完成提示
+func runInteractive(args []string, out, errOut io.Writer) error {
+	announce(out, "=== Go Survey Tool ===\n")
+
+	for _, warning := range terminal.CheckIOConsistency() {
+		fmt.Fprintf(errOut, "%s %s\n", utils.CurrentSymbols().Warning, warning)
+	}
+	if err := checkInput(); err != nil {
+		return err
+	}
+
+	run := func() error { return runCommand(args, out, errOut) }
+	if *fullscreen {
+		// 在备用屏幕中运行，结束后恢复原来的屏幕内容；
+		// 结果先写入缓存，切回主屏幕后再输出，否则会随备用屏幕一起消失
+		run = func() error {
+			var results bytes.Buffer
+			err := terminal.WithAltScreen(out, func() error { return runCommand(args, &results, errOut) })
+			if _, werr := out.Write(results.Bytes()); werr != nil && err == nil {
+				err = werr
+			}
+			return err
+		}
+	}
+
+	if err := run(); err != nil {
+		return err
+	}
+
+	announce(out, "\nSurvey tool execution completed!\n")
+	return nil
+}
+
+// confirmOverwrite 询问是否覆盖已有的文件，提示输出到标准错误，不会混入被捕获的输出
+func confirmOverwrite(path string) (bool, error) {
+	runner := survey.NewRunner(survey.WithStdio(os.Stdin, os.Stderr, os.Stderr))
+	return runner.AskConfirmDangerous(fmt.Sprintf("Overwrite %s?", path))
+}
+
+// checkOutputFile 在提问之前用utils.CheckOverwrite确认可以写入-record等指定的文件，
+// 这样拒绝覆盖时不会白白回答完整个调查；已存在且无法确认覆盖时提示使用-force
+func checkOutputFile(path string) error {
+	err := utils.CheckOverwrite(path, *force)
+	if errors.Is(err, utils.ErrFileExists) {
+		return fmt.Errorf("%w, use -force to overwrite it", err)
+	}
+	return err
+}
+
+// writeOutputFile 原子地写入已经用checkOutputFile确认过的文件
+func writeOutputFile(path string, data []byte) error {
+	if err := utils.WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	return nil
+}
+
+// announce 输出标题、完成提示等装饰性内容，-quiet时不输出
+func announce(out io.Writer, format string, args ...interface{}) {
+	if !*quietMode {
+		fmt.Fprintf(out, format, args...)
+	}
+}
+
+// statusWriter 返回输出运行状态的位置：通常是out，-quiet时为errOut，
+// 这样状态仍然可见，但不会混入被捕获的标准输出
+func statusWriter(out, errOut io.Writer) io.Writer {
+	if *quietMode {
+		return errOut
+	}
+	return out
+}
+
+// probeTimeout 启动时等待终端回复状态查询的时间
+const probeTimeout = 2 * time.Second
+
+// checkInput 标准输入是终端时确认终端仍有响应，避免提问后读取一直挂起
+// 标准输入是管道或文件时不检查，数据可能要稍后才会写入
+func checkInput() error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil
+	}
+	if err := terminal.ProbeReadable(fd, probeTimeout); err != nil {
+		if errors.Is(err, terminal.ErrTerminalUnresponsive) {
+			return fmt.Errorf("%w: no reply within %v, run 'survey-tool doctor' to check the terminal", err, probeTimeout)
+		}
+		return fmt.Errorf("检查终端失败: %w", err)
+	}
+	return nil
+}
+
+// runCommand 根据命令行参数执行对应的子命令，状态信息输出到statusWriter
+func runCommand(args []string, out, errOut io.Writer) error {
+	status := statusWriter(out, errOut)
+	// 检查命令行参数
+	if len(args) == 0 {
+		// 默认运行示例
+		fmt.Fprintln(status, "No command specified. Running example survey...")
+		return runExample(out, nil)
+	}
+
+	switch args[0] {
+	case "example", "demo":
+		if len(args) > 1 && *answersFile != "" {
+			return fmt.Errorf("example takes either answers as arguments or -answers, not both")
+		}
+		if len(args) > 1 {
+			// 位置参数按顺序作为答案，不再提问
+			answers, err := exampleAnswers(survey.CreateSurveyQuestions(), args[1:])
+			if err != nil {
+				return err
+			}
+			return runExample(out, answers)
+		}
+		fmt.Fprintln(status, "Running survey example...")
+		return runExample(out, nil)
+	case "arrow", "select":
+		fmt.Fprintln(status, "Running arrow key selection example...")
+		return survey.RunArrowKeySelection()
+	case "help", "-h", "--help":
+		writeHelp(out)
+	default:
+		fmt.Fprintf(status, "Unknown command: %s\n", args[0])
+		writeHelp(status)
+	}
+	return nil
+}
+
+// runExample 运行示例调查
+// -timing、-post-url、-transcript、-record、-answers、-format toml或标准输出被重定向时改用Runner提问，
+// 最后按-format把结果输出到out，显示用时或把结果提交到指定地址；out不是标准输出（如-fullscreen）时也改用Runner。
+// answers不为nil时作为预置答案，所有问题都不再提问；否则设置了-answers时从文件读取预置答案
+func runExample(out io.Writer, answers map[string]interface{}) error {
+	opts, closeTranscript, err := transcriptOptions()
+	if err != nil {
+		return err
+	}
+	defer closeTranscript()
+	if *forceInteractive {
+		opts = append(opts, survey.WithForceInteractive())
+	}
+	// 标准输出被重定向时在标准错误上提问，标准输出只有结果，见terminal.IOKind.Warnings
+	kind := terminal.IO(os.Stdin, os.Stdout, os.Stderr)
+	promptOnStderr := kind.StdinTTY && !kind.StdoutTTY && kind.StderrTTY
+	if promptOnStderr {
+		opts = append(opts, survey.WithStdio(os.Stdin, os.Stderr, os.Stderr))
+	}
+	if answers == nil && *answersFile != "" {
+		if answers, err = survey.LoadAnswersFile(*answersFile); err != nil {
+			return err
+		}
+	}
+	switch *resultFormat {
+	case "text", "toml", "json", "env":
+	default:
+		return fmt.Errorf("invalid -format %q, want text, toml, json or env", *resultFormat)
+	}
+	bools, err := utils.ParseBoolFormat(*boolFormat)
+	if err != nil {
+		return err
+	}
+	// 答案先记录在内存中，调查结束后再写入文件；文件已存在时在提问之前询问是否覆盖
+	var record bytes.Buffer
+	if *recordAnswers != "" {
+		if err := checkOutputFile(*recordAnswers); err != nil {
+			return err
+		}
+		opts = append(opts, survey.WithAnswerRecord(&record))
+	}
+	if answers == nil && out == os.Stdout && !promptOnStderr && !*timing && *postURL == "" && *transcript == "" && *recordAnswers == "" && *resultFormat == "text" {
+		return survey.RunInteractiveSurvey(opts...)
+	}
+	questions := survey.CreateSurveyQuestions()
+	result, err := survey.NewRunner(opts...).AskAllResult(questions, answers)
+	if *recordAnswers != "" && record.Len() > 0 {
+		if err := writeOutputFile(*recordAnswers, record.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if err := writeResult(out, result, *resultFormat, survey.WithBoolFormat(bools)); err != nil {
+		return err
+	}
+	if *timing {
+		fmt.Fprintf(out, "\n%s\n", result.Summary())
+	}
+	if *postURL != "" {
+		if err := survey.PostResults(*postURL, result); err != nil {
+			return err
+		}
+		fmt.Fprintf(statusWriter(out, os.Stderr), "Results posted to %s\n", *postURL)
+	}
+	return nil
+}
+
+// writeResult 按-format输出示例调查的结果，text为两列对齐的文本，其他格式见survey.WriteResultTOML等
+func writeResult(w io.Writer, result survey.Result, format string, opts ...survey.OutputOption) error {
+	fmt.Fprintln(w)
+	switch format {
+	case "toml":
+		return survey.WriteResultTOML(w, result, opts...)
+	case "json":
+		return survey.WriteResultJSON(w, result, opts...)
+	case "env":
+		return survey.WriteResultEnv(w, result, opts...)
+	}
+	fmt.Fprint(w, result.FormatKeyValues())
+	return nil
+}
+
+// transcriptOptions 设置了-transcript时创建记录文件，返回对应的Runner选项和关闭文件的函数
+// 记录文件已存在时与-record一样需要确认或-force，见checkOutputFile
+func transcriptOptions() ([]survey.RunnerOption, func() error, error) {
+	if *transcript == "" {
+		return nil, func() error { return nil }, nil
+	}
+	if err := checkOutputFile(*transcript); err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Create(*transcript)
+	if err != nil {
+		return nil, nil, fmt.Errorf("无法创建记录文件: %w", err)
+	}
+	return []survey.RunnerOption{survey.WithTranscript(f)}, f.Close, nil
+}
+
+// scriptCommand 返回输出供脚本使用的子命令，其他命令返回nil
+// 不带参数的select仍然运行选择示例
+func scriptCommand(args []string) func() error {
+	switch {
+	case len(args) > 0 && args[0] == "doctor":
+		return func() error { return runDoctor(args[1:]) }
+	case len(args) > 1 && args[0] == "select":
+		return func() error { return runSelect(args[1:]) }
+	case len(args) > 0 && args[0] == "replay":
+		return func() error { return runReplay(args[1:]) }
+	case len(args) > 0 && args[0] == "confirm":
+		return func() error { return runConfirm(args[1:], os.Stdin, os.Stdout, os.Stderr) }
+	case len(args) > 0 && args[0] == "ask":
+		return func() error { return runAsk(args[1:]) }
+	case len(args) > 0 && args[0] == "preview":
+		return func() error { return runPreview(args[1:]) }
+	case len(args) > 0 && args[0] == "validate":
+		return func() error { return runValidate(args[1:], os.Stdout) }
+	case len(args) > 0 && args[0] == "bench-input":
+		return func() error { return runBenchInput(args[1:], os.Stdin, os.Stdout) }
+	case len(args) > 0 && args[0] == "selftest":
+		return func() error { return runSelfTest(args[1:]) }
+	case len(args) > 0 && args[0] == "init":
+		return func() error {
+			_, err := fmt.Print(survey.QuestionsSkeleton)
+			return err
+		}
+	}
+	return nil
+}
+
+// printHelp 把帮助输出到标准输出，也用作flag.Usage
+func printHelp() {
+	writeHelp(os.Stdout)
+}
+
+// writeHelp 把帮助输出到w
+func writeHelp(w io.Writer) {
+	fmt.Fprint(w, `
+Usage:
+  survey-tool [flags] [command]
+
+Commands:
+  example, demo [NAME COLOR LIKE]
+                   Run interactive survey example; with three arguments use
+                   them as the answers in order instead of asking
+  arrow, select    Run arrow key selection example
+  select [-shell] [-record FILE] [-message M] OPTION...
+                   Ask to choose one OPTION and print it to stdout
+  replay [-realtime] [-message M] FILE OPTION...
+                   Replay keys recorded with 'select -record' into the menu
+  ask [-env] [-bool FORMAT] [--input NAME[=DEFAULT]] [--password NAME] [--confirm NAME[=yes|no]]
+      [--select NAME:OPTION,...] [--multiselect NAME:OPTION,...]
+                   Ask the questions in order and print NAME=VALUE lines to stdout.
+                   With -env, print export NAME='VALUE' lines instead: names are
+                   uppercased with other characters replaced by _, booleans are
+                   true/false (or as set by -bool) and multiselect answers are
+                   joined with spaces
+  confirm [-default yes|no] [-quiet] MESSAGE
+                   Ask a yes/no question; exit status is 0 for yes, 1 for no
+                   and 2 on errors or interrupts. Prints yes or no unless -quiet
+  doctor [-json]   Report terminal capabilities for bug reports
+  bench-input [-count N] [-json]
+                   Measure the terminal's input round trip time N times and
+                   recommend an escape timeout, e.g. for terminals over SSH
+  init             Print an example questions file to start from
+  preview [-limit N] FILE|URL
+                   List the questions in FILE or an http(s) URL without asking,
+                   showing at most N options per question
+  selftest         Run a scripted survey with canned input and report PASS/FAIL
+  validate [-json] FILE
+                   Check FILE for unknown fields, wrong types and invalid
+                   questions, one FILE:LINE: problem per line; exit status
+                   is 1 if any are found
+  help, -h, --help Show this help message
+
+Flags:
+  -fullscreen      Run prompts in the terminal's alternate screen buffer
+  -quiet           Print no banner or completion line and send status messages
+                   to stderr, so stdout only has the command's output
+  -timing          Show how long the example survey took
+  -post-url URL    POST the example survey results as JSON to URL
+  -transcript FILE Save the questions and answers of the example survey or 'ask'
+                   to FILE, with passwords shown as ****. An existing FILE is
+                   only replaced after confirmation
+  -force-interactive
+                   Use interactive prompts even when TERM is dumb or unset
+  -format toml     Print the example survey results as a TOML document with a
+                   [meta] table instead of aligned text; -format json prints a
+                   JSON object and -format env prints export NAME='VALUE' lines
+  -bool FORMAT     Write yes/no answers in toml, json and env output as
+                   true/false (the default, native booleans in JSON and TOML),
+                   yes/no or 1/0
+  -record FILE     Save the example survey answers as JSON to FILE, without
+                   passwords, so the survey can be replayed with -answers.
+                   An existing FILE is only replaced after confirmation
+  -force           Overwrite files given to -record or -transcript without
+                   asking; needed when stdin is not a terminal
+  -answers FILE    Answer the example survey from FILE instead of asking;
+                   questions missing from FILE are still asked
+  -proto json      Read questions as JSON from stdin and write answers as JSON
+                   to stdout without using the terminal (requires OMNISH_SESSION_ID)
+
+Examples:
+  survey-tool example    Run the survey example
+  survey-tool example Alice Blue yes
+  survey-tool arrow      Run arrow key selection example
+  survey-tool            Run default example (same as 'example')
+  survey-tool -fullscreen example
+  survey-tool -timing example
+  survey-tool -quiet -format toml example Alice Blue yes > answers.toml
+  survey-tool -transcript session.txt example
+  survey-tool -record answers.json example
+  survey-tool -answers answers.json example
+  survey-tool doctor -json
+  survey-tool bench-input -count 10
+  survey-tool init > survey.yaml
+  survey-tool preview -limit 3 survey.yaml
+  survey-tool validate -json survey.yaml
+  survey-tool ask --input name --select color:Red,Blue,Green --confirm like
+  eval "$(survey-tool ask -env --input name --confirm like)"
+  COLOR=$(survey-tool select -shell -message "Color?" Red "Light Blue")
+  if survey-tool confirm -quiet "Proceed?"; then echo go; fi
+  survey-tool replay keys.json Red "Light Blue"
+  echo '{"questions":[{"name":"n","message":"Name?","default":"x"}]}' | survey-tool -proto json
+`)
+}
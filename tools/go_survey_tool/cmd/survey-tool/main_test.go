@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// setQuiet 在测试期间设置-quiet，结束后恢复
+func setQuiet(t *testing.T, quiet bool) {
+	t.Helper()
+	old := *quietMode
+	*quietMode = quiet
+	t.Cleanup(func() { *quietMode = old })
+}
+
+func TestRunInteractiveQuiet(t *testing.T) {
+	setQuiet(t, true)
+	var stdout, stderr bytes.Buffer
+	if err := runInteractive([]string{"bogus"}, &stdout, &stderr); err != nil {
+		t.Fatalf("runInteractive() error = %v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout should be empty with -quiet, got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "Unknown command: bogus") {
+		t.Errorf("status should go to stderr with -quiet, got %q", stderr.String())
+	}
+}
+
+func TestRunInteractiveBanner(t *testing.T) {
+	setQuiet(t, false)
+	var stdout, stderr bytes.Buffer
+	if err := runInteractive([]string{"help"}, &stdout, &stderr); err != nil {
+		t.Fatalf("runInteractive() error = %v", err)
+	}
+	got := stdout.String()
+	if !strings.HasPrefix(got, "=== Go Survey Tool ===\n") || !strings.HasSuffix(got, "Survey tool execution completed!\n") {
+		t.Errorf("stdout should have the banner and completion line, got %q", got)
+	}
+	if !strings.Contains(got, "Usage:") {
+		t.Errorf("help should be printed to stdout, got %q", got)
+	}
+}
+
+func TestRunInteractiveQuietHelp(t *testing.T) {
+	setQuiet(t, true)
+	var stdout, stderr bytes.Buffer
+	if err := runInteractive([]string{"help"}, &stdout, &stderr); err != nil {
+		t.Fatalf("runInteractive() error = %v", err)
+	}
+	if got := stdout.String(); !strings.HasPrefix(got, "\nUsage:") || strings.Contains(got, "completed") {
+		t.Errorf("stdout should only have the help text with -quiet, got %q", got)
+	}
+}
+
+func TestRunInteractiveFullscreenOutput(t *testing.T) {
+	setQuiet(t, false)
+	old := *fullscreen
+	*fullscreen = true
+	t.Cleanup(func() { *fullscreen = old })
+
+	// 输出不是终端时不切换备用屏幕，结果仍然写到传入的out，并在完成提示之前
+	var stdout, stderr bytes.Buffer
+	if err := runInteractive([]string{"help"}, &stdout, &stderr); err != nil {
+		t.Fatalf("runInteractive() error = %v", err)
+	}
+	got := stdout.String()
+	usage := strings.Index(got, "Usage:")
+	if usage < 0 || usage > strings.Index(got, "Survey tool execution completed!") {
+		t.Errorf("help should be written to out before the completion line, got %q", got)
+	}
+	if strings.Contains(got, "\x1b[?1049") {
+		t.Errorf("alternate screen should not be used for a non-terminal writer, got %q", got)
+	}
+}
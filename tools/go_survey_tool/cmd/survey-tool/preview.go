@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// runPreview 不提问，只列出问题文件（可以是http(s) URL）中的问题，选项太多时只显示前-limit个
+func runPreview(args []string) error {
+	fs := flag.NewFlagSet("preview", flag.ContinueOnError)
+	limit := fs.Int("limit", 5, "show at most `N` options per question, 0 for all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: survey-tool preview [-limit N] FILE|URL")
+	}
+
+	load := survey.LoadQuestionsFile
+	if strings.HasPrefix(fs.Arg(0), "http://") || strings.HasPrefix(fs.Arg(0), "https://") {
+		load = survey.LoadQuestionsURL
+	}
+	questions, err := load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	for i, q := range questions {
+		typ := q.Type
+		if typ == "" {
+			typ = survey.TypeInput
+		}
+		fmt.Printf("%d. %s (%s): %s\n", i+1, q.Name, typ, q.Message)
+		if len(q.Options) > 0 {
+			fmt.Printf("   options: %s\n", utils.FormatOptionsLimited(q.Options, *limit))
+		}
+		if q.Default != "" {
+			fmt.Printf("   default: %s\n", q.Default)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+// runProto 以程序化协议运行，供IDE等集成使用
+// 只在omnish会话中启用，避免在普通终端中误用时阻塞等待标准输入
+func runProto(name string) error {
+	if name != "json" {
+		return fmt.Errorf("unknown protocol %q", name)
+	}
+	if os.Getenv("OMNISH_SESSION_ID") == "" {
+		return fmt.Errorf("-proto json requires OMNISH_SESSION_ID to be set")
+	}
+	return survey.ServeJSON(os.Stdin, os.Stdout)
+}
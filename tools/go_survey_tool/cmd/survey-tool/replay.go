@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+// runReplay 把'select -record'录制的按键回放到同样的选择菜单中，逐帧输出菜单
+// 录制文件格式见terminal.KeyRecorder
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	message := fs.String("message", "Choose an option:", "the question to show")
+	realtime := fs.Bool("realtime", false, "wait between keys as long as during recording")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return errors.New("usage: survey-tool replay [-realtime] [-message M] FILE OPTION...")
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("无法打开录制文件: %w", err)
+	}
+	events, err := terminal.ReadRecording(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer pr.Close()
+	go func() {
+		terminal.ReplayKeys(pw, events, *realtime)
+		pw.Close()
+	}()
+
+	options := fs.Args()[1:]
+	index, err := survey.ReplayMenu(os.Stdout, pr, *message, []survey.Group{{Options: options}})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Selected: %s\n", options[index])
+	return nil
+}
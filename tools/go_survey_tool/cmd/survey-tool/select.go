@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// runSelect 询问一个选择题并把选中的值输出到标准输出，便于脚本用$(...)捕获
+// 提示显示在标准错误上，不会混入捕获的结果
+func runSelect(args []string) error {
+	fs := flag.NewFlagSet("select", flag.ContinueOnError)
+	message := fs.String("message", "Choose an option:", "the question to show")
+	shell := fs.Bool("shell", false, "quote the answer for safe use in a POSIX shell")
+	record := fs.String("record", "", "record the keys pressed to `FILE` for 'survey-tool replay'")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	options := fs.Args()
+	if len(options) == 0 {
+		return errors.New("no options given")
+	}
+
+	var value string
+	if *record != "" {
+		if err := checkOutputFile(*record); err != nil {
+			return err
+		}
+		// 录制时使用自绘菜单，回放时才能走同一套按键处理；自绘菜单可以在窗口大小变化时重绘
+		rec := terminal.NewKeyRecorder()
+		runner := survey.NewRunner(survey.WithStdio(os.Stdin, os.Stderr, os.Stderr), survey.WithKeyRecorder(rec))
+		index, err := runner.AskSelectGrouped(*message, []survey.Group{{Options: options}}, survey.WithReflowOnResize())
+		if saveErr := saveRecording(*record, rec); saveErr != nil {
+			return saveErr
+		}
+		if err != nil {
+			return err
+		}
+		value = options[index]
+	} else {
+		runner := survey.NewRunner(survey.WithStdio(os.Stdin, os.Stderr, os.Stderr))
+		answer, err := runner.Ask(survey.Question{Type: survey.TypeSelect, Message: *message, Options: options})
+		if err != nil {
+			return err
+		}
+		value = answer.(string)
+	}
+
+	if *shell {
+		value = utils.ShellQuote(value)
+	}
+	fmt.Println(value)
+	return nil
+}
+
+// saveRecording 把录制的按键写入path，调用前需要用checkOutputFile确认可以写入
+func saveRecording(path string, rec *terminal.KeyRecorder) error {
+	var buf bytes.Buffer
+	if err := rec.WriteJSON(&buf); err != nil {
+		return err
+	}
+	return writeOutputFile(path, buf.Bytes())
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+// selfTest 一个自检用例：用input作为键盘输入运行run，结果应等于want
+type selfTest struct {
+	name  string
+	input string
+	run   func(r *survey.Runner) (interface{}, error)
+	want  interface{}
+}
+
+// selfTests 自检用例，同时也是Runner用法的示例
+var selfTests = []selfTest{
+	{
+		name:  "input with default",
+		input: "\n",
+		run: func(r *survey.Runner) (interface{}, error) {
+			return r.AskInput("Name?", "guest", nil)
+		},
+		want: "guest",
+	},
+	{
+		name:  "input validation retry",
+		input: "\nAlice\n",
+		run: func(r *survey.Runner) (interface{}, error) {
+			return r.AskInput("Name?", "", func(s string) error {
+				if s == "" {
+					return errors.New("name is required")
+				}
+				return nil
+			})
+		},
+		want: "Alice",
+	},
+	{
+		name:  "password",
+		input: "s3cret\n",
+		run: func(r *survey.Runner) (interface{}, error) {
+			return r.AskPassword("Password?")
+		},
+		want: "s3cret",
+	},
+	{
+		name:  "confirm",
+		input: "y\n",
+		run: func(r *survey.Runner) (interface{}, error) {
+			return r.AskConfirm("Continue?", false)
+		},
+		want: true,
+	},
+	{
+		name:  "select by number",
+		input: "2\n",
+		run: func(r *survey.Runner) (interface{}, error) {
+			return r.AskSelect("Color?", []string{"Red", "Blue", "Green"})
+		},
+		want: "Blue",
+	},
+	{
+		name:  "multiselect",
+		input: "Green, 1\n",
+		run: func(r *survey.Runner) (interface{}, error) {
+			return r.AskMultiSelect("Colors?", []string{"Red", "Blue", "Green"})
+		},
+		want: []string{"Red", "Green"},
+	},
+	{
+		name:  "ask all with a skipped question",
+		input: "Bob\nn\n",
+		run: func(r *survey.Runner) (interface{}, error) {
+			return r.AskAll([]survey.Question{
+				{Name: "name", Message: "Name?"},
+				{Name: "admin", Type: survey.TypeConfirm, Message: "Admin?"},
+				{Name: "role", Message: "Role?", When: func(answers map[string]interface{}) bool {
+					return answers["admin"] == true
+				}},
+			})
+		},
+		want: map[string]interface{}{"name": "Bob", "admin": false},
+	},
+}
+
+// runSelfTest 用预置的输入运行所有自检用例，每个用例输出一行PASS或FAIL
+// 不读取终端，结果与用户的键盘和终端设置无关；有失败时返回错误
+func runSelfTest(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("selftest takes no arguments")
+	}
+	failed := 0
+	for _, tt := range selfTests {
+		if err := tt.check(); err != nil {
+			failed++
+			fmt.Printf("FAIL %s: %v\n", tt.name, err)
+			continue
+		}
+		fmt.Printf("PASS %s\n", tt.name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d self-tests failed", failed, len(selfTests))
+	}
+	fmt.Printf("\nAll %d self-tests passed.\n", len(selfTests))
+	return nil
+}
+
+// check 运行用例并比较结果，提示输出被丢弃
+func (tt selfTest) check() error {
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader(tt.input), io.Discard, io.Discard))
+	got, err := tt.run(r)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(got, tt.want) {
+		return fmt.Errorf("got %v, want %v", got, tt.want)
+	}
+	return nil
+}
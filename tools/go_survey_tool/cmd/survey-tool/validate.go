@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+// fileProblem -json输出中的一项，在Problem的基础上带上文件名
+type fileProblem struct {
+	File string `json:"file"`
+	survey.Problem
+}
+
+// runValidate 用survey.LintQuestions检查问题文件，每个问题输出一行FILE:LINE: 描述，
+// -json时输出问题的JSON数组。发现问题时返回错误，使退出状态不为0，适合在CI中使用
+func runValidate(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the problems as a JSON array")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: survey-tool validate [-json] FILE")
+	}
+	path := fs.Arg(0)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("读取问题文件失败: %w", err)
+	}
+	defer f.Close()
+	problems, err := survey.LintQuestions(f)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		items := make([]fileProblem, len(problems))
+		for i, p := range problems {
+			items[i] = fileProblem{File: path, Problem: p}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(items); err != nil {
+			return err
+		}
+	} else {
+		for _, p := range problems {
+			if p.Line > 0 {
+				fmt.Fprintf(out, "%s:%d: %s\n", path, p.Line, p)
+			} else {
+				fmt.Fprintf(out, "%s: %s\n", path, p)
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problem(s) found in %s", len(problems), path)
+	}
+	if !*asJSON {
+		fmt.Fprintf(out, "%s: ok\n", path)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// brokenSurvey 有未知字段、缺少选项和重复name的问题文件
+const brokenSurvey = `questions:
+  - name: color
+    type: select
+    message: Color?
+  - name: size
+    mesage: Size?
+  - name: color
+    message: Again?
+`
+
+// writeSurvey 把content写入临时目录中的survey.yaml，返回文件路径
+func writeSurvey(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "survey.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunValidateBrokenFile(t *testing.T) {
+	path := writeSurvey(t, brokenSurvey)
+	var out bytes.Buffer
+	err := runValidate([]string{path}, &out)
+	if err == nil || !strings.Contains(err.Error(), "4 problem(s)") {
+		t.Fatalf("runValidate() error = %v, want 4 problems", err)
+	}
+	expected := path + `:2: question "color": select needs options
+` + path + `:5: question "size": message is required
+` + path + `:6: question "size": field "mesage": unknown field
+` + path + `:7: question "color": duplicate name
+`
+	if out.String() != expected {
+		t.Errorf("runValidate() output =\n%s\nwant\n%s", out.String(), expected)
+	}
+}
+
+func TestRunValidateJSON(t *testing.T) {
+	path := writeSurvey(t, brokenSurvey)
+	var out bytes.Buffer
+	if err := runValidate([]string{"-json", path}, &out); err == nil {
+		t.Fatal("runValidate() should fail for a broken file")
+	}
+	var problems []map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &problems); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out.String())
+	}
+	if len(problems) != 4 {
+		t.Fatalf("got %d problems, want 4: %v", len(problems), problems)
+	}
+	first := problems[0]
+	if first["file"] != path || first["line"] != float64(2) || first["question"] != "color" || first["message"] != "select needs options" {
+		t.Errorf("first problem = %v", first)
+	}
+	if problems[2]["field"] != "mesage" {
+		t.Errorf("unknown field problem = %v, want the field name", problems[2])
+	}
+}
+
+func TestRunValidateValidFile(t *testing.T) {
+	path := writeSurvey(t, "questions:\n  - name: a\n    message: A?\n")
+	for _, args := range [][]string{{path}, {"-json", path}} {
+		var out bytes.Buffer
+		if err := runValidate(args, &out); err != nil {
+			t.Errorf("runValidate(%v) error = %v", args, err)
+		}
+		if want := map[bool]string{false: path + ": ok\n", true: "[]\n"}[args[0] == "-json"]; out.String() != want {
+			t.Errorf("runValidate(%v) output = %q, want %q", args, out.String(), want)
+		}
+	}
+}
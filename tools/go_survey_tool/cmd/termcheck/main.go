@@ -3,7 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
+
 	"golang.org/x/term"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
 )
 
 func main() {
@@ -11,12 +15,12 @@ func main() {
 
 	// Check if stdin is a terminal
 	fd := int(os.Stdin.Fd())
-	if !term.IsTerminal(fd) {
+	if !terminal.IO(os.Stdin, os.Stdout, os.Stderr).StdinTTY {
 		fmt.Println("ERROR: stdin is not a terminal")
 		os.Exit(1)
 	}
 
-	fmt.Println("✓ stdin is a terminal")
+	fmt.Println(utils.CurrentSymbols().Colored().Success, "stdin is a terminal")
 
 	// Get current terminal state
 	oldState, err := term.GetState(fd)
@@ -34,13 +38,13 @@ func main() {
 	fmt.Println("Checking raw mode detection...")
 
 	// Make raw temporarily to test
-	rawState, err := term.MakeRaw(fd)
+	restore, err := terminal.EnterRaw(fd)
 	if err != nil {
 		fmt.Printf("ERROR: Failed to set raw mode: %v\n", err)
 	} else {
-		fmt.Println("✓ Can set raw mode")
+		fmt.Println(utils.CurrentSymbols().Colored().Success, "Can set raw mode")
 		// Restore immediately
-		term.Restore(fd, rawState)
+		restore()
 	}
 
 	// Restore original state
@@ -57,7 +61,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Printf("Read %d byte(s): %q (hex: %02x)\n", n, buf[:n], buf[:n])
+	fmt.Printf("Read %d byte(s): %q (hex: %s)\n", n, buf[:n], utils.HexInline(buf[:n]))
 	if n > 0 && buf[0] == 0x1b {
 		fmt.Println("Detected ESC character (0x1b)")
 		fmt.Println("Note: Arrow keys typically send ESC [A, ESC [B, etc.")
@@ -66,15 +70,11 @@ func main() {
 		var seq [10]byte
 		seq[0] = buf[0]
 		m, _ := os.Stdin.Read(seq[1:])
-		fmt.Printf("Escape sequence: %q (hex:", seq[:1+m])
-		for i := 0; i < 1+m; i++ {
-			fmt.Printf(" %02x", seq[i])
-		}
-		fmt.Println(")")
+		fmt.Printf("Escape sequence: %q (hex: %s)\n", seq[:1+m], utils.HexInline(seq[:1+m]))
 	}
 
 	fmt.Println("\n=== Recommendations ===")
 	fmt.Println("1. If you see [A, [B, etc. displayed, terminal may not be processing escape sequences")
 	fmt.Println("2. Check if terminal is in raw mode or has special settings")
 	fmt.Println("3. Try running from a regular shell outside of omnish")
-}
\ No newline at end of file
+}
@@ -3,7 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
+
 	"golang.org/x/term"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
 )
 
 func main() {
@@ -11,28 +15,30 @@ func main() {
 
 	// Check isatty for stdin, stdout, stderr
 	fmt.Println("\n=== isatty() checks ===")
+	kind := terminal.IO(os.Stdin, os.Stdout, os.Stderr)
 	fds := []struct {
 		name string
 		fd   uintptr
+		tty  bool
 	}{
-		{"stdin", os.Stdin.Fd()},
-		{"stdout", os.Stdout.Fd()},
-		{"stderr", os.Stderr.Fd()},
+		{"stdin", os.Stdin.Fd(), kind.StdinTTY},
+		{"stdout", os.Stdout.Fd(), kind.StdoutTTY},
+		{"stderr", os.Stderr.Fd(), kind.StderrTTY},
 	}
 
+	symbols := utils.CurrentSymbols().Colored()
 	for _, f := range fds {
-		// Use term.IsTerminal which calls isatty internally
-		if term.IsTerminal(int(f.fd)) {
-			fmt.Printf("✓ %s is a terminal (fd=%d)\n", f.name, f.fd)
+		if f.tty {
+			fmt.Printf("%s %s is a terminal (fd=%d)\n", symbols.Success, f.name, f.fd)
 		} else {
-			fmt.Printf("✗ %s is NOT a terminal (fd=%d)\n", f.name, f.fd)
+			fmt.Printf("%s %s is NOT a terminal (fd=%d)\n", symbols.Failure, f.name, f.fd)
 		}
 	}
 
 	// Get terminal attributes
 	fmt.Println("\n=== Terminal Attributes ===")
 	fd := int(os.Stdin.Fd())
-	if term.IsTerminal(fd) {
+	if kind.StdinTTY {
 		// Try to get state
 		state, err := term.GetState(fd)
 		if err != nil {
@@ -62,7 +68,9 @@ func main() {
 
 	// Try to get window size
 	fmt.Println("\n=== Window Size ===")
-	if width, height, err := term.GetSize(fd); err == nil {
+	if width, height, err := term.GetSize(fd); err == nil && (width <= 0 || height <= 0) {
+		fmt.Printf("Terminal size: reported %dx%d, assuming %dx%d\n", width, height, terminal.DefaultWidth, terminal.DefaultHeight)
+	} else if err == nil {
 		fmt.Printf("Terminal size: %dx%d\n", width, height)
 	} else {
 		fmt.Printf("Failed to get terminal size: %v\n", err)
@@ -94,10 +102,8 @@ func main() {
 		fmt.Printf("Read error: %v\n", err)
 	} else {
 		fmt.Printf("Read %d bytes: %q\n", n, buf[:n])
-		for i := 0; i < n; i++ {
-			fmt.Printf("  [%d] = 0x%02x\n", i, buf[i])
-		}
+		fmt.Print(utils.HexDump(buf[:n]))
 	}
 
 	fmt.Println("\n=== Test Complete ===")
-}
\ No newline at end of file
+}
@@ -0,0 +1,252 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+
+	"golang.org/x/term"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// 检查结果状态
+const (
+	StatusPass = "pass"
+	StatusWarn = "warn"
+)
+
+// Check 单项检查的结果
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// Env 诊断所需的输入，测试中可以传入管道和自定义的环境变量
+type Env struct {
+	Stdin  *os.File
+	Stdout *os.File
+	Stderr *os.File
+	Vars   map[string]string
+}
+
+// DefaultEnv 返回当前进程的标准流和环境变量
+func DefaultEnv() Env {
+	return Env{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr, Vars: terminal.Environ()}
+}
+
+// Report 所有检查的结果
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+// Run 依次执行所有检查
+// Windows上额外报告控制台类型
+func Run(env Env) Report {
+	report := Report{Checks: []Check{
+		CheckTTY("stdin", env.Stdin),
+		CheckTTY("stdout", env.Stdout),
+		CheckTTY("stderr", env.Stderr),
+		CheckSize(env.Stdout),
+		CheckColor(env.Vars),
+		CheckMultiplexer(env.Vars),
+		CheckSession(env.Vars),
+		CheckLocale(env.Vars),
+		CheckKeyboard(env.Vars),
+		CheckRawMode(env.Stdin),
+		CheckBackground(env.Stdin, env.Vars),
+		CheckRenderer(env.Stdin, env.Vars),
+	}}
+	if runtime.GOOS == "windows" {
+		report.Checks = append(report.Checks, CheckConsole(terminal.IsWindowsTerminal(), terminal.HasConPTY()))
+	}
+	return report
+}
+
+// CheckTTY 检查文件是否连接到终端
+func CheckTTY(name string, f *os.File) Check {
+	if terminal.IO(f, nil, nil).StdinTTY {
+		return Check{Name: name, Status: StatusPass, Detail: "is a terminal"}
+	}
+	return Check{Name: name, Status: StatusWarn, Detail: "is not a terminal"}
+}
+
+// CheckSize 检查能否获取终端窗口大小
+func CheckSize(f *os.File) Check {
+	if f == nil {
+		return Check{Name: "size", Status: StatusWarn, Detail: "no output stream"}
+	}
+	width, height, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return Check{Name: "size", Status: StatusWarn, Detail: fmt.Sprintf("cannot get window size: %v", err)}
+	}
+	if width <= 0 || height <= 0 {
+		return Check{Name: "size", Status: StatusWarn, Detail: fmt.Sprintf("reported %dx%d, assuming %dx%d", width, height, terminal.DefaultWidth, terminal.DefaultHeight)}
+	}
+	return Check{Name: "size", Status: StatusPass, Detail: fmt.Sprintf("%dx%d", width, height)}
+}
+
+// CheckColor 检查终端的颜色支持，不支持颜色时给出警告
+func CheckColor(vars map[string]string) Check {
+	level := terminal.DetectColorLevel(vars)
+	detail := fmt.Sprintf("%s (TERM=%q)", level, vars["TERM"])
+	if level == terminal.ColorNone {
+		return Check{Name: "color", Status: StatusWarn, Detail: detail}
+	}
+	return Check{Name: "color", Status: StatusPass, Detail: detail}
+}
+
+// CheckMultiplexer 报告所在的终端复用器
+func CheckMultiplexer(vars map[string]string) Check {
+	mux := terminal.DetectMultiplexer(vars)
+	if mux == "" {
+		mux = "none"
+	}
+	return Check{Name: "multiplexer", Status: StatusPass, Detail: mux}
+}
+
+// CheckSession 报告会话类型
+func CheckSession(vars map[string]string) Check {
+	return Check{Name: "session", Status: StatusPass, Detail: terminal.DetectSessionKind(vars)}
+}
+
+// CheckLocale 报告字符处理使用的locale，以及是否需要按宽字符计算光标位置
+func CheckLocale(vars map[string]string) Check {
+	locale := terminal.Locale(vars)
+	if locale == "" {
+		locale = "unset"
+	}
+	if terminal.IsWideCharLocale(vars) {
+		locale += " (wide characters)"
+	}
+	return Check{Name: "locale", Status: StatusPass, Detail: locale}
+}
+
+// CheckKeyboard 报告终端能否区分Ctrl、Shift、Alt组合键（CSI u或modifyOtherKeys）
+// 不支持时只有方向键和Ctrl加字母等传统按键可用，不算作问题
+func CheckKeyboard(vars map[string]string) Check {
+	if protocol := terminal.DetectKeyProtocol(vars); protocol != "" {
+		return Check{Name: "keyboard", Status: StatusPass, Detail: protocol + " modifier keys supported"}
+	}
+	return Check{Name: "keyboard", Status: StatusPass, Detail: "legacy keys only: modified keys such as Ctrl-Enter are not distinguished"}
+}
+
+// CheckRawMode 检查能否进入并恢复raw模式
+// 失败通常说明有其他程序占用了终端设置，交互式提问会出现按键显示异常
+func CheckRawMode(f *os.File) Check {
+	if !terminal.IO(f, nil, nil).StdinTTY {
+		return Check{Name: "raw mode", Status: StatusWarn, Detail: "skipped: input is not a terminal"}
+	}
+	restore, err := terminal.EnterRaw(int(f.Fd()))
+	if err != nil {
+		return Check{Name: "raw mode", Status: StatusWarn, Detail: fmt.Sprintf("cannot enter raw mode: %v", err)}
+	}
+	if err := restore(); err != nil {
+		return Check{Name: "raw mode", Status: StatusWarn, Detail: fmt.Sprintf("cannot restore terminal state: %v", err)}
+	}
+	return Check{Name: "raw mode", Status: StatusPass, Detail: "can enter and restore raw mode"}
+}
+
+// CheckBackground 报告终端的默认背景是深色还是浅色
+// 输入是终端时用OSC 11查询，否则根据vars中的COLORFGBG判断；无法判断不算作问题
+func CheckBackground(f *os.File, vars map[string]string) Check {
+	var dark bool
+	var err error
+	if terminal.IO(f, nil, nil).StdinTTY {
+		dark, err = terminal.BackgroundIsDark(int(f.Fd()))
+	} else {
+		var ok bool
+		if dark, ok = terminal.BackgroundFromEnv(vars); !ok {
+			err = terminal.ErrBackgroundUnknown
+		}
+	}
+	switch {
+	case err != nil:
+		return Check{Name: "background", Status: StatusPass, Detail: fmt.Sprintf("unknown: %v", err)}
+	case dark:
+		return Check{Name: "background", Status: StatusPass, Detail: "dark"}
+	}
+	return Check{Name: "background", Status: StatusPass, Detail: "light"}
+}
+
+// CheckRenderer 报告survey提问时的渲染方式，见terminal.SurveyRendererMode
+// 简单模式下逐行提问，显示与交互式的菜单不同，给出警告和原因
+func CheckRenderer(f *os.File, vars map[string]string) Check {
+	tty := terminal.IO(f, nil, nil).StdinTTY
+	if terminal.SurveyRendererModeFor(vars["TERM"], tty) == terminal.RendererRich {
+		return Check{Name: "renderer", Status: StatusPass, Detail: terminal.RendererRich}
+	}
+	reason := "stdin is not a terminal"
+	if tty {
+		reason = fmt.Sprintf("TERM=%q cannot redraw prompts", vars["TERM"])
+	}
+	return Check{Name: "renderer", Status: StatusWarn, Detail: fmt.Sprintf("%s: %s, questions are asked line by line", terminal.RendererSimple, reason)}
+}
+
+// CheckConsole 报告Windows控制台类型，传统conhost上给出警告
+// 没有ConPTY时转义序列和raw模式的处理与其他终端不同，方向键和颜色可能无法正常工作
+func CheckConsole(windowsTerminal, conPTY bool) Check {
+	switch {
+	case windowsTerminal:
+		return Check{Name: "console", Status: StatusPass, Detail: "Windows Terminal"}
+	case conPTY:
+		return Check{Name: "console", Status: StatusPass, Detail: "ConPTY console"}
+	}
+	return Check{Name: "console", Status: StatusWarn, Detail: "legacy conhost: arrow keys and colors may not work, try Windows Terminal"}
+}
+
+// Passed 返回通过的检查数
+func (r Report) Passed() int {
+	return r.count(StatusPass)
+}
+
+// Warnings 返回警告数
+func (r Report) Warnings() int {
+	return r.count(StatusWarn)
+}
+
+func (r Report) count(status string) int {
+	n := 0
+	for _, c := range r.Checks {
+		if c.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Summary 返回一行总结
+func (r Report) Summary() string {
+	return fmt.Sprintf("%d checks passed, %d warnings.", r.Passed(), r.Warnings())
+}
+
+// WriteText 以便于阅读的格式输出报告
+func (r Report) WriteText(w io.Writer) error {
+	symbols := utils.CurrentSymbols()
+	for _, c := range r.Checks {
+		marker := symbols.Success
+		if c.Status != StatusPass {
+			marker = symbols.Warning
+		}
+		if _, err := fmt.Fprintf(w, "%s %-12s %s\n", marker, c.Name, c.Detail); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", r.Summary())
+	return err
+}
+
+// WriteJSON 以JSON格式输出报告，便于粘贴到问题反馈中
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Report
+		Passed   int `json:"passed"`
+		Warnings int `json:"warnings"`
+	}{r, r.Passed(), r.Warnings()})
+}
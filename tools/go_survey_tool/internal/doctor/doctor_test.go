@@ -0,0 +1,125 @@
+package doctor_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/doctor"
+)
+
+// pipeEnv 返回标准流都是管道的Env
+func pipeEnv(t *testing.T, vars map[string]string) doctor.Env {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { r.Close(); w.Close() })
+	return doctor.Env{Stdin: r, Stdout: w, Stderr: w, Vars: vars}
+}
+
+func TestChecksWithPipes(t *testing.T) {
+	env := pipeEnv(t, nil)
+
+	tests := []struct {
+		name  string
+		check doctor.Check
+	}{
+		{"stdin", doctor.CheckTTY("stdin", env.Stdin)},
+		{"nil file", doctor.CheckTTY("stderr", nil)},
+		{"size", doctor.CheckSize(env.Stdout)},
+		{"raw mode", doctor.CheckRawMode(env.Stdin)},
+		{"renderer", doctor.CheckRenderer(env.Stdin, map[string]string{"TERM": "xterm"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.check.Status != doctor.StatusWarn {
+				t.Errorf("check on a pipe = %+v, want a warning", tt.check)
+			}
+		})
+	}
+}
+
+func TestEnvChecks(t *testing.T) {
+	vars := map[string]string{"TERM": "xterm-256color", "TMUX": "/tmp/tmux", "SSH_TTY": "/dev/pts/3"}
+
+	tests := []struct {
+		check  doctor.Check
+		status string
+		detail string
+	}{
+		{doctor.CheckColor(vars), doctor.StatusPass, "256"},
+		{doctor.CheckColor(map[string]string{"TERM": "dumb"}), doctor.StatusWarn, "none"},
+		{doctor.CheckMultiplexer(vars), doctor.StatusPass, "tmux"},
+		{doctor.CheckMultiplexer(nil), doctor.StatusPass, "none"},
+		{doctor.CheckSession(vars), doctor.StatusPass, "ssh"},
+		{doctor.CheckLocale(map[string]string{"LANG": "zh_CN.UTF-8"}), doctor.StatusPass, "zh_CN.UTF-8 (wide characters)"},
+		{doctor.CheckLocale(nil), doctor.StatusPass, "unset"},
+		{doctor.CheckKeyboard(map[string]string{"TERM": "xterm-kitty"}), doctor.StatusPass, "CSI u"},
+		{doctor.CheckKeyboard(map[string]string{"TERM": "xterm"}), doctor.StatusPass, "legacy keys only"},
+		{doctor.CheckBackground(nil, map[string]string{"COLORFGBG": "15;0"}), doctor.StatusPass, "dark"},
+		{doctor.CheckBackground(nil, map[string]string{"COLORFGBG": "0;15"}), doctor.StatusPass, "light"},
+		{doctor.CheckBackground(nil, nil), doctor.StatusPass, "unknown"},
+		{doctor.CheckConsole(true, true), doctor.StatusPass, "Windows Terminal"},
+		{doctor.CheckConsole(false, true), doctor.StatusPass, "ConPTY"},
+		{doctor.CheckConsole(false, false), doctor.StatusWarn, "legacy conhost"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.check.Name+"/"+tt.detail, func(t *testing.T) {
+			if tt.check.Status != tt.status || !strings.Contains(tt.check.Detail, tt.detail) {
+				t.Errorf("check = %+v, want status %s with %q", tt.check, tt.status, tt.detail)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	report := doctor.Run(pipeEnv(t, map[string]string{"TERM": "xterm"}))
+	want := 12
+	if runtime.GOOS == "windows" {
+		want++ // console
+	}
+	if len(report.Checks) != want {
+		t.Fatalf("Run() returned %d checks, want %d", len(report.Checks), want)
+	}
+	if report.Passed()+report.Warnings() != len(report.Checks) {
+		t.Errorf("passed %d + warnings %d != %d checks", report.Passed(), report.Warnings(), len(report.Checks))
+	}
+
+	var text bytes.Buffer
+	if err := report.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(text.String(), report.Summary()+"\n") {
+		t.Errorf("text report should end with the summary:\n%s", text.String())
+	}
+
+	var out bytes.Buffer
+	if err := report.WriteJSON(&out); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Checks   []doctor.Check `json:"checks"`
+		Passed   int            `json:"passed"`
+		Warnings int            `json:"warnings"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(decoded.Checks) != len(report.Checks) || decoded.Passed != report.Passed() || decoded.Warnings != report.Warnings() {
+		t.Errorf("JSON report = %+v, want it to match %+v", decoded, report)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	report := doctor.Report{Checks: []doctor.Check{
+		{Status: doctor.StatusPass}, {Status: doctor.StatusPass}, {Status: doctor.StatusWarn},
+	}}
+	if got := report.Summary(); got != "2 checks passed, 1 warnings." {
+		t.Errorf("Summary() = %q", got)
+	}
+}
@@ -0,0 +1,68 @@
+// Package omnish 连接运行survey-tool的omnish进程的socket
+package omnish
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// SocketEnv omnish在子进程环境中设置的socket地址变量
+const SocketEnv = "OMNISH_SOCKET"
+
+// ErrNoSocket 没有设置OMNISH_SOCKET，通常表示不是在omnish中运行
+var ErrNoSocket = errors.New(SocketEnv + " is not set")
+
+// Conn 与omnish的连接
+type Conn struct {
+	net.Conn
+}
+
+// Dial 连接OMNISH_SOCKET指定的地址
+func Dial() (*Conn, error) {
+	return DialWithRetry(1, 0)
+}
+
+// DialWithRetry 与Dial相同，但连接被拒绝时（omnish启动了survey-tool但还没开始监听）
+// 最多尝试attempts次，每次失败后等待的时间从backoff开始加倍。
+// 其他错误，例如socket文件不存在，立即返回；用完次数后返回最后一次的错误
+func DialWithRetry(attempts int, backoff time.Duration) (*Conn, error) {
+	addr := os.Getenv(SocketEnv)
+	if addr == "" {
+		return nil, ErrNoSocket
+	}
+	network, address := parseAddr(addr)
+
+	var err error
+	for i := 0; i < max(attempts, 1); i++ {
+		if i > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		var conn net.Conn
+		conn, err = net.Dial(network, address)
+		if err == nil {
+			return &Conn{Conn: conn}, nil
+		}
+		if !errors.Is(err, syscall.ECONNREFUSED) {
+			break
+		}
+	}
+	return nil, fmt.Errorf("连接omnish失败: %w", err)
+}
+
+// parseAddr 与omnish的地址格式一致：tcp://HOST:PORT，或不以/、.开头且包含冒号的HOST:PORT为TCP地址，
+// 其他都是unix socket路径
+func parseAddr(addr string) (network, address string) {
+	if hostPort, ok := strings.CutPrefix(addr, "tcp://"); ok {
+		return "tcp", hostPort
+	}
+	if !strings.HasPrefix(addr, "/") && !strings.HasPrefix(addr, ".") && strings.Contains(addr, ":") {
+		return "tcp", addr
+	}
+	return "unix", addr
+}
@@ -0,0 +1,112 @@
+//go:build unix
+
+package omnish
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// refusingSocket 在path留下一个没有人监听的socket文件，连接它会得到ECONNREFUSED
+func refusingSocket(t *testing.T, path string) {
+	t.Helper()
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.SetUnlinkOnClose(false)
+	l.Close()
+}
+
+func TestDialWithRetryDelayedListen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "omnish.sock")
+	refusingSocket(t, path)
+	t.Setenv(SocketEnv, path)
+
+	// 模拟omnish稍后才开始监听
+	accepted := make(chan struct{})
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		os.Remove(path)
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer l.Close()
+		if conn, err := l.Accept(); err == nil {
+			conn.Close()
+		}
+		close(accepted)
+	}()
+
+	conn, err := DialWithRetry(8, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("DialWithRetry() error = %v", err)
+	}
+	conn.Close()
+	<-accepted
+}
+
+func TestDialWithRetryGivesUp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "omnish.sock")
+	refusingSocket(t, path)
+	t.Setenv(SocketEnv, path)
+
+	start := time.Now()
+	_, err := DialWithRetry(3, 10*time.Millisecond)
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Fatalf("DialWithRetry() error = %v, want ECONNREFUSED", err)
+	}
+	// 两次等待：10ms和20ms
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("DialWithRetry() returned after %v, want it to back off between attempts", elapsed)
+	}
+}
+
+func TestDialWithRetryMissingSocket(t *testing.T) {
+	t.Setenv(SocketEnv, filepath.Join(t.TempDir(), "missing.sock"))
+
+	start := time.Now()
+	_, err := DialWithRetry(5, time.Second)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("DialWithRetry() error = %v, want a missing socket error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("DialWithRetry() retried a missing socket for %v", elapsed)
+	}
+}
+
+func TestDialNoSocket(t *testing.T) {
+	t.Setenv(SocketEnv, "")
+	if _, err := Dial(); !errors.Is(err, ErrNoSocket) {
+		t.Errorf("Dial() error = %v, want ErrNoSocket", err)
+	}
+}
+
+func TestParseAddr(t *testing.T) {
+	tests := []struct {
+		addr    string
+		network string
+		address string
+	}{
+		{"/tmp/omnish.sock", "unix", "/tmp/omnish.sock"},
+		{"./omnish.sock", "unix", "./omnish.sock"},
+		{"omnish.sock", "unix", "omnish.sock"},
+		{"tcp://127.0.0.1:9000", "tcp", "127.0.0.1:9000"},
+		{"localhost:9000", "tcp", "localhost:9000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			network, address := parseAddr(tt.addr)
+			if network != tt.network || address != tt.address {
+				t.Errorf("parseAddr(%q) = %s %s, want %s %s", tt.addr, network, address, tt.network, tt.address)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package survey
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// WithAnswerRecord 调查（AskAll、AskAllResult等）结束后把答案以JSON对象写入w，见Runner.AnswerRecord
+func WithAnswerRecord(w io.Writer) RunnerOption {
+	return func(r *Runner) {
+		r.AnswerRecord = w
+	}
+}
+
+// writeAnswerRecord 把answers中除密码以外的答案写入AnswerRecord，以问题名为键
+// 写入失败只记录日志，不影响调查的结果
+func (r *Runner) writeAnswerRecord(questions []Question, answers map[string]interface{}) {
+	if r.AnswerRecord == nil {
+		return
+	}
+	recorded := make(map[string]interface{}, len(answers))
+	for _, q := range questions {
+		if value, ok := answers[q.Name]; ok && q.kind() != TypePassword {
+			recorded[q.Name] = value
+		}
+	}
+	data, err := json.MarshalIndent(recorded, "", "  ")
+	if err == nil {
+		_, err = r.AnswerRecord.Write(append(data, '\n'))
+	}
+	if err != nil {
+		r.log(LevelWarn, "answer record write failed", "error", err)
+	}
+}
+
+// LoadAnswers 读取WithAnswerRecord写入的JSON答案，结果可以作为AskAll等的预置答案重放调查，
+// 这时答案会按问题类型转换（例如多选的JSON数组转换为[]string）
+func LoadAnswers(r io.Reader) (map[string]interface{}, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取答案失败: %w", err)
+	}
+	answers := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(utils.StripBOM(string(data))), &answers); err != nil {
+		return nil, fmt.Errorf("解析答案失败: %w", err)
+	}
+	return answers, nil
+}
+
+// LoadAnswersFile 从文件读取答案，见LoadAnswers
+func LoadAnswersFile(path string) (map[string]interface{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取答案文件失败: %w", err)
+	}
+	defer f.Close()
+	return LoadAnswers(f)
+}
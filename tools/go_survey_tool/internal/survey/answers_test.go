@@ -0,0 +1,93 @@
+package survey_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+// recordQuestions 记录和重放测试使用的问题
+var recordQuestions = []survey.Question{
+	{Name: "name", Message: "Name?"},
+	{Name: "admin", Type: survey.TypeConfirm, Message: "Admin?"},
+	{Name: "color", Type: survey.TypeSelect, Message: "Color?", Options: []string{"Red", "Blue"}},
+	{Name: "colors", Type: survey.TypeMultiSelect, Message: "Colors?", Options: []string{"Red", "Blue", "Green"}},
+	{Name: "role", Message: "Role?", When: func(answers map[string]interface{}) bool {
+		return answers["admin"] == true
+	}},
+}
+
+func TestAnswerRecordReplay(t *testing.T) {
+	var record bytes.Buffer
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader("Alice\ny\n2\n3,1\nowner\n"), &bytes.Buffer{}, &bytes.Buffer{}), survey.WithAnswerRecord(&record))
+	recorded, err := r.AskAllResult(recordQuestions)
+	if err != nil {
+		t.Fatalf("AskAllResult() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "answers.json")
+	if err := os.WriteFile(path, record.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	answers, err := survey.LoadAnswersFile(path)
+	if err != nil {
+		t.Fatalf("LoadAnswersFile() error = %v", err)
+	}
+
+	// 重放时没有任何输入，所有问题都由记录的答案回答
+	out := &bytes.Buffer{}
+	replayed, err := survey.NewRunner(survey.WithStdio(strings.NewReader(""), out, out)).AskAllResult(recordQuestions, answers)
+	if err != nil {
+		t.Fatalf("replay error = %v", err)
+	}
+	if !reflect.DeepEqual(replayed.Answers, recorded.Answers) {
+		t.Errorf("replayed answers = %#v, want %#v", replayed.Answers, recorded.Answers)
+	}
+	if out.Len() != 0 {
+		t.Errorf("replay should not prompt, got %q", out.String())
+	}
+}
+
+func TestAnswerRecordExcludesPasswords(t *testing.T) {
+	var record bytes.Buffer
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader("alice\nhunter2\n"), &bytes.Buffer{}, &bytes.Buffer{}), survey.WithAnswerRecord(&record))
+	_, err := r.AskAll([]survey.Question{
+		{Name: "user", Message: "User?"},
+		{Name: "password", Type: survey.TypePassword, Message: "Password?"},
+	})
+	if err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+	answers, err := survey.LoadAnswers(&record)
+	if err != nil {
+		t.Fatalf("LoadAnswers() error = %v", err)
+	}
+	if !reflect.DeepEqual(answers, map[string]interface{}{"user": "alice"}) {
+		t.Errorf("recorded %v, want only the user", answers)
+	}
+}
+
+func TestAnswerRecordOnError(t *testing.T) {
+	var record bytes.Buffer
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader("Alice\n"), &bytes.Buffer{}, &bytes.Buffer{}), survey.WithAnswerRecord(&record))
+	if _, err := r.AskAll(recordQuestions); err == nil {
+		t.Fatal("AskAll() should fail at the end of input")
+	}
+	if !strings.Contains(record.String(), `"name": "Alice"`) {
+		t.Errorf("answers collected before the error should be recorded, got %s", record.String())
+	}
+}
+
+func TestLoadAnswersInvalid(t *testing.T) {
+	if _, err := survey.LoadAnswers(strings.NewReader("[1, 2]")); err == nil {
+		t.Error("LoadAnswers() should reject JSON that is not an object")
+	}
+	if _, err := survey.LoadAnswersFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadAnswersFile() should fail for a missing file")
+	}
+}
@@ -0,0 +1,115 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// errReadCancelled 读取输入时问题的ctx被取消，由ask转换为默认答案或ctx的错误
+var errReadCancelled = errors.New("read cancelled")
+
+// pollInterval 问题可以被取消或延长时，等待终端输入的最长分段。
+// terminal.InputReady不能被打断，分段等待才能及时响应取消和延长
+const pollInterval = 50 * time.Millisecond
+
+// TimeoutControl 让其他goroutine延长正在进行的带超时问题的期限，
+// 例如omnish收到用户仍在操作的消息时。用NewTimeoutControl创建，通过WithTimeoutControl传给提问函数
+type TimeoutControl struct {
+	mu     sync.Mutex
+	extra  time.Duration
+	notify chan struct{}
+}
+
+// NewTimeoutControl 创建TimeoutControl
+func NewTimeoutControl() *TimeoutControl {
+	return &TimeoutControl{notify: make(chan struct{}, 1)}
+}
+
+// ExtendTimeout 把问题的期限延后d，可以在任何goroutine中调用，不会阻塞。
+// 问题开始前的延长在它开始等待输入后生效；问题没有设置超时时不起作用
+func (c *TimeoutControl) ExtendTimeout(d time.Duration) {
+	c.mu.Lock()
+	c.extra += d
+	c.mu.Unlock()
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+// notified 返回有新的延长时收到通知的通道，c为nil时返回nil通道
+func (c *TimeoutControl) notified() <-chan struct{} {
+	if c == nil {
+		return nil
+	}
+	return c.notify
+}
+
+// take 取出累积的延长
+func (c *TimeoutControl) take() time.Duration {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d := c.extra
+	c.extra = 0
+	return d
+}
+
+// WithTimeoutControl 让AskSelectTimeoutCtx的超时可以通过c.ExtendTimeout延长
+func WithTimeoutControl(c *TimeoutControl) SelectOption {
+	return func(cfg *selectConfig) {
+		cfg.timeoutControl = c
+	}
+}
+
+// AskSelectTimeoutCtx 使用标准输入输出询问带超时、可取消的单选
+func AskSelectTimeoutCtx(ctx context.Context, message string, options []string, def string, timeout time.Duration, opts ...SelectOption) (string, error) {
+	return NewRunner().AskSelectTimeoutCtx(ctx, message, options, def, timeout, opts...)
+}
+
+// AskSelectTimeoutCtx 与AskSelectTimeout相同，但ctx被取消时立即结束提问：
+// 有def时返回def，否则返回ctx.Err()。父进程可以借此远程放弃一个无人操作的提示，
+// 配合WithTimeoutControl还可以在提问期间延长超时
+func (r *Runner) AskSelectTimeoutCtx(ctx context.Context, message string, options []string, def string, timeout time.Duration, opts ...SelectOption) (string, error) {
+	q := Question{Type: TypeSelect, Message: message, Options: options, Default: def, Timeout: timeout}
+	cfg := newSelectConfig(opts)
+	cfg.ctx = ctx
+	answer, err := r.ask(q, cfg)
+	if err != nil {
+		return "", fmt.Errorf("选择失败: %w", err)
+	}
+	return answer.(string), nil
+}
+
+// ctxDone 返回ctx的Done通道，ctx为nil时返回nil通道
+func ctxDone(ctx context.Context) <-chan struct{} {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Done()
+}
+
+// extendDeadline 把TimeoutControl累积的延长加到问题期限上，返回是否延长了期限
+func (r *Runner) extendDeadline() bool {
+	d := r.timeoutControl.take()
+	if d <= 0 || r.questionDeadline.IsZero() {
+		return false
+	}
+	r.questionDeadline = r.questionDeadline.Add(d)
+	r.log(LevelDebug, "question timeout extended", "by", d)
+	return true
+}
+
+// cancelAnswer 问题被取消后使用的答案：有默认值时接受默认值，否则返回ctx的错误
+func (r *Runner) cancelAnswer(q Question, ctx context.Context) (interface{}, error) {
+	if q.Default == "" {
+		return nil, ctx.Err()
+	}
+	fmt.Fprintf(r.Out, "\n(cancelled, using default: %s)\n", q.Default)
+	return parseLineAnswer(q, "")
+}
@@ -0,0 +1,100 @@
+package survey
+
+import (
+	"strings"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// DefaultsFromResult 把上一次调查的答案转换为WithDefaults使用的默认值，
+// 用于"以上次的答案为默认值重新填写"。nil、空字符串和空列表不会成为默认值
+func DefaultsFromResult(res Result) map[string]interface{} {
+	defaults := make(map[string]interface{}, len(res.Answers))
+	for name, value := range res.Answers {
+		if utils.FormatValue(value) == "" {
+			continue
+		}
+		defaults[name] = value
+	}
+	return defaults
+}
+
+// WithDefaults 用defaults中以问题Name为键的值代替问题自己的Default，但仍会提问，
+// 与AskAll的presets（直接作为答案，不再提问）不同。defaults通常来自DefaultsFromResult。
+// 值为空、select的值不在选项中或问题是password时使用问题自己的默认值；
+// DefaultEnv指定的环境变量非空时仍然优先
+func WithDefaults(defaults map[string]interface{}) RunnerOption {
+	return func(r *Runner) {
+		r.defaults = defaults
+	}
+}
+
+// applyDefault 返回q使用WithDefaults中的默认值后的副本
+func (r *Runner) applyDefault(q Question) Question {
+	value, ok := r.defaults[q.Name]
+	if !ok || q.kind() == TypePassword {
+		return q
+	}
+	if def := defaultText(q, value); def != "" {
+		q.Default = def
+	}
+	return q
+}
+
+// defaultText 把答案value转换为q.Default的文本形式，无法作为默认值时返回空字符串
+func defaultText(q Question, value interface{}) string {
+	switch q.kind() {
+	case TypeConfirm:
+		if b, ok := value.(bool); ok {
+			if b {
+				return "yes"
+			}
+			return "no"
+		}
+	case TypeSelect:
+		if s := utils.FormatValue(value); optionExists(q.Options, s) {
+			return s
+		}
+		return ""
+	case TypeMultiSelect:
+		// 只保留仍然存在的选项，选项可能在两次调查之间被改过
+		var selected []string
+		for _, item := range answerItems(value) {
+			if optionExists(q.Options, item) {
+				selected = append(selected, item)
+			}
+		}
+		return strings.Join(selected, ",")
+	case TypeList:
+		return strings.Join(answerItems(value), ", ")
+	case TypeDate:
+		if t, ok := value.(time.Time); ok {
+			return t.Format(dateLayout)
+		}
+	}
+	return utils.FormatValue(value)
+}
+
+// answerItems 返回多选答案中的各项，答案可能是[]string或从JSON读取的[]interface{}
+func answerItems(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = utils.FormatValue(item)
+		}
+		return items
+	case string:
+		return splitDefault(v)
+	}
+	return nil
+}
+
+// optionExists 返回options中是否有s
+func optionExists(options []string, s string) bool {
+	_, ok := utils.OptionIndex(options, s)
+	return ok
+}
@@ -0,0 +1,78 @@
+package survey_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+var defaultsQuestions = []survey.Question{
+	{Name: "name", Message: "Name?", Default: "guest"},
+	{Name: "admin", Type: survey.TypeConfirm, Message: "Admin?"},
+	{Name: "color", Type: survey.TypeSelect, Message: "Color?", Options: []string{"Red", "Blue"}},
+	{Name: "tags", Type: survey.TypeMultiSelect, Message: "Tags?", Options: []string{"a", "b", "c"}},
+}
+
+func TestDefaultsFromResultBecomeDefaults(t *testing.T) {
+	prev := survey.Result{Answers: map[string]interface{}{
+		"name":  "Alice",
+		"admin": true,
+		"color": "Blue",
+		"tags":  []string{"c", "a"},
+	}}
+
+	r := survey.NewRunner(
+		survey.WithStdio(strings.NewReader("\n\n\n\n"), &bytes.Buffer{}, &bytes.Buffer{}),
+		survey.WithDefaults(survey.DefaultsFromResult(prev)),
+	)
+	answers, err := r.AskAll(defaultsQuestions)
+	if err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+	want := map[string]interface{}{"name": "Alice", "admin": true, "color": "Blue", "tags": []string{"a", "c"}}
+	if !reflect.DeepEqual(answers, want) {
+		t.Errorf("AskAll() = %v, want the previous answers %v", answers, want)
+	}
+}
+
+func TestDefaultsFallThrough(t *testing.T) {
+	prev := survey.Result{Answers: map[string]interface{}{
+		"name":  "",
+		"color": "Purple", // 不再是选项之一
+		"tags":  []interface{}{"b", "gone"},
+	}}
+	defaults := survey.DefaultsFromResult(prev)
+	if _, ok := defaults["name"]; ok {
+		t.Errorf("DefaultsFromResult() should drop empty answers, got %v", defaults)
+	}
+
+	r := survey.NewRunner(
+		survey.WithStdio(strings.NewReader("\nn\n1\n\n"), &bytes.Buffer{}, &bytes.Buffer{}),
+		survey.WithDefaults(defaults),
+	)
+	answers, err := r.AskAll(defaultsQuestions)
+	if err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+	want := map[string]interface{}{"name": "guest", "admin": false, "color": "Red", "tags": []string{"b"}}
+	if !reflect.DeepEqual(answers, want) {
+		t.Errorf("AskAll() = %v, want %v", answers, want)
+	}
+}
+
+func TestDefaultsSkipPassword(t *testing.T) {
+	r := survey.NewRunner(
+		survey.WithStdio(strings.NewReader("\n"), &bytes.Buffer{}, &bytes.Buffer{}),
+		survey.WithDefaults(map[string]interface{}{"token": "old"}),
+	)
+	answer, err := r.Ask(survey.Question{Name: "token", Type: survey.TypePassword, Message: "Token?"})
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if answer != "" {
+		t.Error("a previous password should never become the default")
+	}
+}
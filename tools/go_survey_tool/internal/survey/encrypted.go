@@ -0,0 +1,168 @@
+package survey
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// ErrWrongKey 加密的状态文件无法用给定的密钥解密：密钥不对，或者文件被修改过
+var ErrWrongKey = errors.New("cannot decrypt state: wrong key or corrupted file")
+
+// encryptedMagic 加密状态文件的开头，用于区分明文的JSON文件和不认识的格式版本
+const encryptedMagic = "OSE1"
+
+// encryptedState 加密前序列化的内容
+type encryptedState struct {
+	Answers       map[string]interface{} `json:"answers"`
+	Order         []string               `json:"order,omitempty"`
+	DurationMS    int64                  `json:"duration_ms"`
+	QuestionCount int                    `json:"question_count"`
+	SessionID     string                 `json:"session_id,omitempty"`
+	Completed     time.Time              `json:"completed"`
+}
+
+// 加密状态文件头中的盐，以及从口令派生密钥的scrypt参数（N=2^15、r=8、p=1，2017年推荐的交互式登录参数）
+const (
+	stateSaltSize = 16
+	scryptN       = 1 << 15
+	scryptR       = 8
+	scryptP       = 1
+	stateKeySize  = 32
+)
+
+// WriteEncryptedState 把结果用AES-GCM加密后原子地写入path（权限0600），key为16、24或32字节的随机密钥，
+// 例如从环境变量读取的密钥。文件格式为4字节的"OSE1"、16字节的随机盐、12字节的随机nonce、
+// 之后是JSON序列化的结果的密文（最后16字节为认证标签），文件头（"OSE1"和盐）作为附加数据参与认证。
+// 直接使用key时盐不参与加密；要用人记得住的口令时改用WriteEncryptedStatePassphrase。
+// 不需要加密的结果照常用WriteResultTOML等写为明文
+func WriteEncryptedState(path string, r Result, key []byte) error {
+	return writeEncryptedState(path, r, func([]byte) ([]byte, error) { return key, nil })
+}
+
+// WriteEncryptedStatePassphrase 与WriteEncryptedState相同，但密钥由scrypt从passphrase和文件头中的随机盐派生，
+// 每次写入使用新的盐，离线穷举口令的代价随scrypt的参数增长
+func WriteEncryptedStatePassphrase(path string, r Result, passphrase string) error {
+	return writeEncryptedState(path, r, func(salt []byte) ([]byte, error) { return passphraseKey(passphrase, salt) })
+}
+
+// writeEncryptedState 生成盐和nonce，用keyFor(盐)得到的密钥加密r并写入path
+func writeEncryptedState(path string, r Result, keyFor func(salt []byte) ([]byte, error)) error {
+	salt := make([]byte, stateSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("生成盐失败: %w", err)
+	}
+	key, err := keyFor(salt)
+	if err != nil {
+		return err
+	}
+	gcm, err := newStateCipher(key)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(encryptedState{
+		Answers:       r.Answers,
+		Order:         r.Order,
+		DurationMS:    r.Duration.Milliseconds(),
+		QuestionCount: r.QuestionCount,
+		SessionID:     r.SessionID,
+		Completed:     r.Completed,
+	})
+	if err != nil {
+		return fmt.Errorf("序列化状态失败: %w", err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("生成nonce失败: %w", err)
+	}
+	header := append([]byte(encryptedMagic), salt...)
+	data := append(append([]byte{}, header...), nonce...)
+	data = gcm.Seal(data, nonce, plain, header)
+	if err := utils.WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("写入状态文件失败: %w", err)
+	}
+	return nil
+}
+
+// ReadEncryptedState 读取WriteEncryptedState写入的结果，密钥不对或文件被修改时返回ErrWrongKey。
+// 答案经过JSON，数字读回为float64，切片读回为[]interface{}
+func ReadEncryptedState(path string, key []byte) (Result, error) {
+	return readEncryptedState(path, func([]byte) ([]byte, error) { return key, nil })
+}
+
+// ReadEncryptedStatePassphrase 读取WriteEncryptedStatePassphrase写入的结果，口令不对或文件被修改时返回ErrWrongKey
+func ReadEncryptedStatePassphrase(path string, passphrase string) (Result, error) {
+	return readEncryptedState(path, func(salt []byte) ([]byte, error) { return passphraseKey(passphrase, salt) })
+}
+
+// readEncryptedState 读取path，用keyFor(文件头中的盐)得到的密钥解密
+func readEncryptedState(path string, keyFor func(salt []byte) ([]byte, error)) (Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Result{}, fmt.Errorf("读取状态文件失败: %w", err)
+	}
+	// GCM的nonce为12字节，认证标签为16字节
+	headerSize := len(encryptedMagic) + stateSaltSize
+	if len(data) < headerSize+12+16 || !bytes.HasPrefix(data, []byte(encryptedMagic)) {
+		return Result{}, fmt.Errorf("%s is not an encrypted state file", path)
+	}
+	header := data[:headerSize]
+	key, err := keyFor(header[len(encryptedMagic):])
+	if err != nil {
+		return Result{}, err
+	}
+	gcm, err := newStateCipher(key)
+	if err != nil {
+		return Result{}, err
+	}
+	nonceEnd := headerSize + gcm.NonceSize()
+	plain, err := gcm.Open(nil, data[headerSize:nonceEnd], data[nonceEnd:], header)
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %s", ErrWrongKey, path)
+	}
+
+	var state encryptedState
+	if err := json.Unmarshal(plain, &state); err != nil {
+		return Result{}, fmt.Errorf("解析状态文件失败: %w", err)
+	}
+	return Result{
+		Answers:       state.Answers,
+		Order:         state.Order,
+		Duration:      time.Duration(state.DurationMS) * time.Millisecond,
+		QuestionCount: state.QuestionCount,
+		SessionID:     state.SessionID,
+		Completed:     state.Completed,
+	}, nil
+}
+
+// passphraseKey 用scrypt从口令和盐派生32字节的密钥
+func passphraseKey(passphrase string, salt []byte) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("invalid state passphrase: empty")
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, stateKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("派生密钥失败: %w", err)
+	}
+	return key, nil
+}
+
+// newStateCipher 用key创建AES-GCM
+func newStateCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
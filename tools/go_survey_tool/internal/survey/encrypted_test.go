@@ -0,0 +1,115 @@
+package survey_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestEncryptedStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.enc")
+	key := bytes.Repeat([]byte{0x42}, 32)
+	res := survey.Result{
+		Answers:       map[string]interface{}{"name": "Alice", "token": "s3cret", "like": true, "colors": []interface{}{"Red", "Blue"}},
+		Order:         []string{"name", "token", "like", "colors"},
+		Duration:      1500 * time.Millisecond,
+		QuestionCount: 4,
+		SessionID:     "abc-123",
+		Completed:     time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC),
+	}
+	if err := survey.WriteEncryptedState(path, res, key); err != nil {
+		t.Fatalf("WriteEncryptedState() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("OSE1")) || bytes.Contains(data, []byte("s3cret")) || bytes.Contains(data, []byte("Alice")) {
+		t.Errorf("state file should start with the magic and hold no plaintext answers: %q", data)
+	}
+
+	got, err := survey.ReadEncryptedState(path, key)
+	if err != nil {
+		t.Fatalf("ReadEncryptedState() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, res) {
+		t.Errorf("round trip = %#v\nwant %#v", got, res)
+	}
+
+	// 口令：每次写入使用新的盐，同一口令得到的文件也不同
+	if err := survey.WriteEncryptedStatePassphrase(path, res, "correct horse battery staple"); err != nil {
+		t.Fatalf("WriteEncryptedStatePassphrase() error = %v", err)
+	}
+	first, _ := os.ReadFile(path)
+	got, err = survey.ReadEncryptedStatePassphrase(path, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("ReadEncryptedStatePassphrase() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, res) {
+		t.Errorf("passphrase round trip = %#v\nwant %#v", got, res)
+	}
+	if err := survey.WriteEncryptedStatePassphrase(path, res, "correct horse battery staple"); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := os.ReadFile(path)
+	if bytes.Equal(first[4:20], second[4:20]) {
+		t.Error("each passphrase write should use a new salt")
+	}
+}
+
+func TestEncryptedStateWrongKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.enc")
+	res := survey.Result{Answers: map[string]interface{}{"name": "Alice"}}
+	if err := survey.WriteEncryptedStatePassphrase(path, res, "right"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := survey.ReadEncryptedStatePassphrase(path, "wrong"); !errors.Is(err, survey.ErrWrongKey) {
+		t.Errorf("ReadEncryptedStatePassphrase() with the wrong passphrase = %v, want ErrWrongKey", err)
+	}
+	if _, err := survey.ReadEncryptedState(path, bytes.Repeat([]byte{1}, 32)); !errors.Is(err, survey.ErrWrongKey) {
+		t.Errorf("ReadEncryptedState() with the wrong key = %v, want ErrWrongKey", err)
+	}
+
+	// 文件被修改同样无法通过认证
+	data, _ := os.ReadFile(path)
+	data[len(data)-1] ^= 1
+	os.WriteFile(path, data, 0600)
+	if _, err := survey.ReadEncryptedStatePassphrase(path, "right"); !errors.Is(err, survey.ErrWrongKey) {
+		t.Errorf("ReadEncryptedStatePassphrase() of a modified file = %v, want ErrWrongKey", err)
+	}
+
+	// 盐属于认证的文件头，修改盐同样无法解密
+	if err := survey.WriteEncryptedStatePassphrase(path, res, "right"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(path)
+	data[4] ^= 1
+	os.WriteFile(path, data, 0600)
+	if _, err := survey.ReadEncryptedStatePassphrase(path, "right"); !errors.Is(err, survey.ErrWrongKey) {
+		t.Errorf("ReadEncryptedStatePassphrase() with a modified salt = %v, want ErrWrongKey", err)
+	}
+}
+
+func TestEncryptedStateErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := survey.WriteEncryptedState(filepath.Join(dir, "x"), survey.Result{}, []byte("short")); err == nil || !strings.Contains(err.Error(), "invalid state key") {
+		t.Errorf("WriteEncryptedState() with a 5 byte key = %v, want an invalid key error", err)
+	}
+
+	plain := filepath.Join(dir, "plain.json")
+	os.WriteFile(plain, []byte(`{"name": "Alice"}`), 0600)
+	if _, err := survey.ReadEncryptedState(plain, bytes.Repeat([]byte{1}, 32)); err == nil || !strings.Contains(err.Error(), "not an encrypted state file") {
+		t.Errorf("ReadEncryptedState() of a plaintext file = %v, want a format error", err)
+	}
+	if err := survey.WriteEncryptedStatePassphrase(filepath.Join(dir, "y"), survey.Result{}, ""); err == nil {
+		t.Error("WriteEncryptedStatePassphrase() with an empty passphrase should fail")
+	}
+}
@@ -2,6 +2,8 @@ package survey
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	surveyv2 "github.com/AlecAivazis/survey/v2"
 )
@@ -41,33 +43,44 @@ func ExampleSurvey() error {
 		return fmt.Errorf("确认失败: %w", err)
 	}
 
-	fmt.Printf("\nHello %s! You chose %s and ", name, color)
+	printGreeting(os.Stdout, name, color, confirm)
+	return nil
+}
+
+// printGreeting 输出示例调查的总结
+func printGreeting(w io.Writer, name, color string, confirm bool) {
+	fmt.Fprintf(w, "\nHello %s! You chose %s and ", name, color)
 	if confirm {
-		fmt.Println("you like Go!")
+		fmt.Fprintln(w, "you like Go!")
 	} else {
-		fmt.Println("you don't like Go.")
+		fmt.Fprintln(w, "you don't like Go.")
 	}
-
-	return nil
 }
 
 // RunInteractiveSurvey 运行交互式调查
-func RunInteractiveSurvey() error {
-	fmt.Println("=== Interactive Survey Example ===")
-	return ExampleSurvey()
+// TERM为dumb或未设置时survey无法正确重绘，改用Runner的简单模式提问，并在标准错误上说明；
+// 传入WithForceInteractive可以跳过这个判断
+func RunInteractiveSurvey(opts ...RunnerOption) error {
+	r := NewRunner(opts...)
+	fmt.Fprintln(r.Out, "=== Interactive Survey Example ===")
+	if !r.dumbTerminal() {
+		return ExampleSurvey()
+	}
+
+	fmt.Fprintln(r.Err, "Note: TERM is dumb or unset, using plain line prompts (use -force-interactive to override).")
+	answers, err := r.AskAll(CreateSurveyQuestions())
+	if err != nil {
+		return err
+	}
+	printGreeting(r.Out, answers["name"].(string), answers["color"].(string), answers["confirm"].(bool))
+	return nil
 }
 
 // CreateSurveyQuestions 创建调查问题（用于测试）
-func CreateSurveyQuestions() []struct {
-	Name    string
-	Message string
-} {
-	return []struct {
-		Name    string
-		Message string
-	}{
-		{"name", "What is your name?"},
-		{"color", "Choose a color:"},
-		{"confirm", "Do you like Go?"},
+func CreateSurveyQuestions() []Question {
+	return []Question{
+		{Name: "name", Type: TypeInput, Message: "What is your name?"},
+		{Name: "color", Type: TypeSelect, Message: "Choose a color:", Options: []string{"Red", "Blue", "Green", "Yellow"}, Default: "Blue"},
+		{Name: "confirm", Type: TypeConfirm, Message: "Do you like Go?", Default: "yes"},
 	}
-}
\ No newline at end of file
+}
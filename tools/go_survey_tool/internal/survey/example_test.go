@@ -1,6 +1,8 @@
 package survey_test
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
@@ -33,4 +35,20 @@ func TestCreateSurveyQuestions(t *testing.T) {
 			}
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestRunInteractiveSurveyDumbTerminal(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+
+	err := survey.RunInteractiveSurvey(survey.WithStdio(strings.NewReader("Alice\n3\nn\n"), out, errOut))
+	if err != nil {
+		t.Fatalf("RunInteractiveSurvey() error = %v", err)
+	}
+	if !strings.Contains(errOut.String(), "TERM is dumb or unset") {
+		t.Errorf("expected a note on stderr, got %q", errOut.String())
+	}
+	if !strings.Contains(out.String(), "Hello Alice! You chose Green and you don't like Go.") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
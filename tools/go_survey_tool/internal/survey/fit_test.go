@@ -0,0 +1,85 @@
+//go:build linux
+
+package survey_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/ptytest"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+// askOnPTY 在伪终端上用survey提问，模拟终端程序回复光标位置查询并输入keys，返回答案和终端收到的输出
+func askOnPTY(t *testing.T, q survey.Question, keys string) (interface{}, string) {
+	t.Helper()
+	master, pty := ptytest.Open(t)
+	t.Setenv("TERM", "xterm")
+
+	var mu sync.Mutex
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 1024)
+		typed := false
+		for {
+			n, err := master.Read(buf)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			out.Write(buf[:n])
+			mu.Unlock()
+			if bytes.Contains(buf[:n], []byte("\x1b[6n")) {
+				master.Write([]byte("\x1b[1;1R"))
+			}
+			if !typed {
+				typed = true
+				go func() {
+					time.Sleep(50 * time.Millisecond)
+					master.Write([]byte(keys))
+				}()
+			}
+		}
+	}()
+
+	r := survey.NewRunner(survey.WithStdio(pty, pty, pty))
+	r.Width = 20
+	answer, err := r.Ask(q)
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	// 等survey的最后一次重绘到达主设备
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	return answer, out.String()
+}
+
+func TestAskSurveyFitsPasswordAndConfirm(t *testing.T) {
+	long := "Please enter the secret value for the deployment"
+
+	t.Run("Password", func(t *testing.T) {
+		answer, out := askOnPTY(t, survey.Question{Type: survey.TypePassword, Message: long}, "s3\r")
+		if answer != "s3" {
+			t.Errorf("Ask() = %v, want s3", answer)
+		}
+		if strings.Contains(out, long) || !strings.Contains(out, "Please enter the…") {
+			t.Errorf("password prompt not fitted to 20 columns, output %q", out)
+		}
+	})
+
+	t.Run("Confirm", func(t *testing.T) {
+		answer, out := askOnPTY(t, survey.Question{Type: survey.TypeConfirm, Message: long}, "y\r")
+		if answer != true {
+			t.Errorf("Ask() = %v, want true", answer)
+		}
+		if strings.Contains(out, long) || !strings.Contains(out, "Please enter the…") {
+			t.Errorf("confirm prompt not fitted to 20 columns, output %q", out)
+		}
+	})
+}
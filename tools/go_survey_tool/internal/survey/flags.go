@@ -0,0 +1,53 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// ParseQuestionSpec 把survey-tool ask的一个命令行参数解析为问题，typ为问题类型。语法：
+//
+//	input、password：NAME 或 NAME=DEFAULT（password不支持默认值）
+//	confirm：NAME 或 NAME=yes|no
+//	select、multiselect：NAME:OPTION,OPTION...
+//
+// NAME同时作为提示信息；选项按逗号分隔，去掉首尾空白后不能为空
+func ParseQuestionSpec(typ, spec string) (Question, error) {
+	q := Question{Type: typ}
+	switch typ {
+	case TypeInput, TypeConfirm:
+		q.Name, q.Default, _ = strings.Cut(spec, "=")
+		if typ == TypeConfirm && q.Default != "" {
+			if _, err := utils.ParseBool(q.Default); err != nil {
+				return Question{}, err
+			}
+		}
+	case TypePassword:
+		q.Name = spec
+	case TypeSelect, TypeMultiSelect:
+		name, list, ok := strings.Cut(spec, ":")
+		if !ok || strings.TrimSpace(list) == "" {
+			return Question{}, errors.New("expected NAME:OPTION,OPTION")
+		}
+		q.Name = name
+		for _, option := range strings.Split(list, ",") {
+			option = strings.TrimSpace(option)
+			if option == "" {
+				return Question{}, errors.New("empty option")
+			}
+			q.Options = append(q.Options, option)
+		}
+	default:
+		return Question{}, fmt.Errorf("unknown question type %q", typ)
+	}
+
+	q.Name = strings.TrimSpace(q.Name)
+	if q.Name == "" {
+		return Question{}, errors.New("missing name")
+	}
+	q.Message = q.Name
+	return q, nil
+}
@@ -0,0 +1,63 @@
+package survey_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestParseQuestionSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      string
+		spec     string
+		expected survey.Question
+	}{
+		{"Input", survey.TypeInput, "name", survey.Question{Name: "name", Type: survey.TypeInput, Message: "name"}},
+		{"Input default", survey.TypeInput, "region=us-east", survey.Question{Name: "region", Type: survey.TypeInput, Message: "region", Default: "us-east"}},
+		{"Password", survey.TypePassword, "token", survey.Question{Name: "token", Type: survey.TypePassword, Message: "token"}},
+		{"Confirm", survey.TypeConfirm, "like", survey.Question{Name: "like", Type: survey.TypeConfirm, Message: "like"}},
+		{"Confirm default", survey.TypeConfirm, "like=no", survey.Question{Name: "like", Type: survey.TypeConfirm, Message: "like", Default: "no"}},
+		{"Select", survey.TypeSelect, "color:Red,Blue,Green", survey.Question{Name: "color", Type: survey.TypeSelect, Message: "color", Options: []string{"Red", "Blue", "Green"}}},
+		{"MultiSelect trims", survey.TypeMultiSelect, "tags: a , b", survey.Question{Name: "tags", Type: survey.TypeMultiSelect, Message: "tags", Options: []string{"a", "b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := survey.ParseQuestionSpec(tt.typ, tt.spec)
+			if err != nil {
+				t.Fatalf("ParseQuestionSpec() error = %v", err)
+			}
+			if !reflect.DeepEqual(q, tt.expected) {
+				t.Errorf("ParseQuestionSpec() = %+v, want %+v", q, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseQuestionSpecErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		spec    string
+		errText string
+	}{
+		{"Select without options", survey.TypeSelect, "color", "expected NAME:OPTION,OPTION"},
+		{"Select with empty list", survey.TypeSelect, "color:", "expected NAME:OPTION,OPTION"},
+		{"Empty option", survey.TypeMultiSelect, "tags:a,,b", "empty option"},
+		{"Missing name", survey.TypeInput, "=x", "missing name"},
+		{"Bad confirm default", survey.TypeConfirm, "like=maybe", "invalid boolean value"},
+		{"Unknown type", "slider", "level", "unknown question type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := survey.ParseQuestionSpec(tt.typ, tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("ParseQuestionSpec() error = %v, want %q", err, tt.errText)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// AskConfirm 使用标准输入输出询问是否确认
+func AskConfirm(message string, def bool) (bool, error) {
+	return NewRunner().AskConfirm(message, def)
+}
+
+// AskConfirm 询问是否确认，直接回车时使用def
+func (r *Runner) AskConfirm(message string, def bool) (bool, error) {
+	defaultValue := "no"
+	if def {
+		defaultValue = "yes"
+	}
+	answer, err := r.Ask(Question{Type: TypeConfirm, Message: message, Default: defaultValue})
+	if err != nil {
+		return false, fmt.Errorf("确认失败: %w", err)
+	}
+	return answer.(bool), nil
+}
+
+// AskConfirmDangerous 使用标准输入输出确认不可撤销的操作，见Runner.AskConfirmDangerous
+func AskConfirmDangerous(message string) (bool, error) {
+	return NewRunner().AskConfirmDangerous(message)
+}
+
+// AskConfirmDangerous 确认覆盖文件、删除数据等不可撤销的操作：message带着警告符号显示在方框中
+// （见utils.BoxText），Out是终端时方框为红色，然后询问是否继续。默认为no，直接回车不会确认
+func (r *Runner) AskConfirmDangerous(message string) (bool, error) {
+	box := utils.BoxText(utils.CurrentSymbols().Warning+" "+message, r.width())
+	fmt.Fprintln(r.Out, utils.ColorizeFor(r.Out, box, utils.Red))
+	return r.AskConfirm("Are you sure?", false)
+}
+
+// FormValidator 检查整个表单的答案，用于跨字段的规则，例如结束日期必须晚于开始日期
+// 返回*FieldError时只重新填写其中列出的问题，返回其他错误时重新填写整个表单
+type FormValidator func(answers map[string]interface{}) error
+
+// FieldError FormValidator发现的错误，Names为需要重新填写的问题
+type FieldError struct {
+	Names []string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// WithFormValidator 在AskAll系列函数（包括AskForm）回答完所有问题后用validate检查答案。
+// 检查失败时显示错误并重新提问：返回*FieldError时只问其中列出的问题，其他错误时重问除预置答案外的所有问题；
+// 还没有答案的问题按新的答案重新判断When。重新提问后再次检查，直到通过或输入出错。
+// 没有可以重新提问的问题时返回validate的错误
+func WithFormValidator(validate FormValidator) RunnerOption {
+	return func(r *Runner) {
+		r.formValidator = validate
+	}
+}
+
+// reaskNames 返回FormValidator的错误err要求重新填写的问题名，preset中的预置答案只在被明确列出时重问
+func reaskNames(questions []Question, preset map[string]interface{}, err error) []string {
+	var fieldErr *FieldError
+	listed := make(map[string]bool)
+	if errors.As(err, &fieldErr) {
+		for _, name := range fieldErr.Names {
+			listed[name] = true
+		}
+	}
+
+	var names []string
+	for _, q := range questions {
+		_, isPreset := preset[q.Name]
+		if listed[q.Name] || (fieldErr == nil && !isPreset) {
+			names = append(names, q.Name)
+		}
+	}
+	return names
+}
+
+// AskForm 使用标准输入输出询问表单
+func AskForm(questions []Question) (map[string]interface{}, error) {
+	return NewRunner().AskForm(questions)
+}
+
+// AskForm 依次询问所有问题，然后列出全部答案供用户检查，设置了WithFormValidator时先通过检查再列出
+// 用户确认提交后返回答案，否则重新填写整个表单。密码答案在列表中被隐藏
+func (r *Runner) AskForm(questions []Question) (map[string]interface{}, error) {
+	order := make([]string, len(questions))
+	for i, q := range questions {
+		order[i] = q.Name
+	}
+
+	for {
+		answers, err := r.AskAll(questions)
+		if err != nil {
+			return answers, err
+		}
+
+		shown := make(map[string]interface{}, len(answers))
+		for _, q := range questions {
+			if value, ok := answers[q.Name]; ok {
+				shown[q.Name] = loggedAnswer(q, value)
+			}
+		}
+		fmt.Fprintf(r.Out, "\n%s\n", utils.FormatKeyValues(shown, order))
+
+		submit, err := r.AskConfirm("Submit these answers?", true)
+		if err != nil {
+			return answers, err
+		}
+		if submit {
+			return answers, nil
+		}
+	}
+}
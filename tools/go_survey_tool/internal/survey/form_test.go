@@ -0,0 +1,187 @@
+package survey_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+func TestAskConfirm(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		def      bool
+		expected bool
+	}{
+		{"Default yes", "\n", true, true},
+		{"Default no", "\n", false, false},
+		{"Explicit no", "n\n", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, _ := newLineRunner(tt.input)
+			got, err := runner.AskConfirm("Proceed?", tt.def)
+			if err != nil || got != tt.expected {
+				t.Errorf("AskConfirm() = %v, %v, want %v", got, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAskConfirmDangerous(t *testing.T) {
+	// 即使环境允许颜色，Out不是终端时也不输出转义序列
+	t.Setenv("NO_COLOR", "")
+	os.Unsetenv("NO_COLOR")
+	t.Setenv("TERM", "xterm")
+	runner, out := newLineRunner("\ny\n")
+	if got, err := runner.AskConfirmDangerous("Overwrite answers.json?"); err != nil || got {
+		t.Errorf("AskConfirmDangerous() with Enter = %v, %v, want false", got, err)
+	}
+	if got, err := runner.AskConfirmDangerous("Overwrite answers.json?"); err != nil || !got {
+		t.Errorf("AskConfirmDangerous() with y = %v, %v, want true", got, err)
+	}
+	if !strings.Contains(out.String(), utils.BoxText(utils.CurrentSymbols().Warning+" Overwrite answers.json?", 0)+"\n? Are you sure?") {
+		t.Errorf("the message should be boxed with the warning symbol before the prompt:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "\x1b[") {
+		t.Errorf("captured output should not be colored: %q", out.String())
+	}
+}
+
+func TestAskFormShowsSummaryAndRetries(t *testing.T) {
+	questions := []survey.Question{
+		{Name: "user", Message: "User?"},
+		{Name: "secret", Type: survey.TypePassword, Message: "Password"},
+	}
+	// 第一次填写后拒绝提交，第二次填写后确认
+	runner, out := newLineRunner("alice\nhunter2\nn\nbob\nhunter3\n\n")
+
+	answers, err := runner.AskForm(questions)
+	if err != nil {
+		t.Fatalf("AskForm() error = %v", err)
+	}
+	expected := map[string]interface{}{"user": "bob", "secret": "hunter3"}
+	if !reflect.DeepEqual(answers, expected) {
+		t.Errorf("AskForm() = %v, want %v", answers, expected)
+	}
+
+	output := out.String()
+	if !strings.Contains(output, "user    alice\nsecret  ****\n") {
+		t.Errorf("summary table missing or not aligned:\n%s", output)
+	}
+	if strings.Contains(output, "hunter") {
+		t.Errorf("password leaked into the summary:\n%s", output)
+	}
+	if got := strings.Count(output, "Submit these answers?"); got != 2 {
+		t.Errorf("submit prompt shown %d times, want 2", got)
+	}
+}
+
+// endAfterStart 跨字段规则：end必须晚于start，失败时只重新填写end
+func endAfterStart(answers map[string]interface{}) error {
+	if answers["end"].(string) <= answers["start"].(string) {
+		return &survey.FieldError{Names: []string{"end"}, Err: errors.New("end date must be after start date")}
+	}
+	return nil
+}
+
+func TestFormValidatorReasksOffendingFields(t *testing.T) {
+	questions := []survey.Question{
+		{Name: "start", Message: "Start date?"},
+		{Name: "end", Message: "End date?"},
+	}
+	out := &bytes.Buffer{}
+	runner := survey.NewRunner(
+		survey.WithStdio(strings.NewReader("2024-01-05\n2024-01-01\n2024-01-10\n"), out, out),
+		survey.WithFormValidator(endAfterStart),
+	)
+
+	answers, err := runner.AskAll(questions)
+	if err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+	expected := map[string]interface{}{"start": "2024-01-05", "end": "2024-01-10"}
+	if !reflect.DeepEqual(answers, expected) {
+		t.Errorf("AskAll() = %v, want %v", answers, expected)
+	}
+	output := out.String()
+	if !strings.Contains(output, "X end date must be after start date\n") {
+		t.Errorf("validation error not shown:\n%s", output)
+	}
+	if got := strings.Count(output, "Start date?"); got != 1 {
+		t.Errorf("start asked %d times, want 1", got)
+	}
+	if got := strings.Count(output, "End date?"); got != 2 {
+		t.Errorf("end asked %d times, want 2", got)
+	}
+}
+
+func TestFormValidatorReasksWholeForm(t *testing.T) {
+	questions := []survey.Question{
+		{Name: "a", Message: "A?"},
+		{Name: "b", Message: "B?"},
+		{Name: "c", Message: "C?"},
+	}
+	calls := 0
+	validate := func(answers map[string]interface{}) error {
+		calls++
+		if answers["a"] == answers["b"] {
+			return errors.New("a and b must differ")
+		}
+		return nil
+	}
+	out := &bytes.Buffer{}
+	runner := survey.NewRunner(
+		survey.WithStdio(strings.NewReader("x\nx\n2\n3\n4\n"), out, out),
+		survey.WithFormValidator(validate),
+	)
+
+	// c是预置答案，重新填写整个表单时也不再询问
+	answers, err := runner.AskAll(questions, map[string]interface{}{"c": "preset"})
+	if err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+	expected := map[string]interface{}{"a": "2", "b": "3", "c": "preset"}
+	if !reflect.DeepEqual(answers, expected) || calls != 2 {
+		t.Errorf("AskAll() = %v after %d checks, want %v after 2", answers, calls, expected)
+	}
+}
+
+func TestFormValidatorInAskForm(t *testing.T) {
+	questions := []survey.Question{
+		{Name: "start", Message: "Start date?"},
+		{Name: "end", Message: "End date?"},
+	}
+	out := &bytes.Buffer{}
+	runner := survey.NewRunner(
+		survey.WithStdio(strings.NewReader("b\na\nc\n\n"), out, out),
+		survey.WithFormValidator(endAfterStart),
+	)
+	answers, err := runner.AskForm(questions)
+	if err != nil {
+		t.Fatalf("AskForm() error = %v", err)
+	}
+	if answers["end"] != "c" {
+		t.Errorf("AskForm() = %v, want end c", answers)
+	}
+	if got := strings.Count(out.String(), "Submit these answers?"); got != 1 {
+		t.Errorf("submit prompt shown %d times, want 1 after the answers pass validation", got)
+	}
+}
+
+func TestFormValidatorNothingToReask(t *testing.T) {
+	rule := errors.New("impossible")
+	runner := survey.NewRunner(survey.WithStdio(strings.NewReader("x\n"), &bytes.Buffer{}, &bytes.Buffer{}),
+		survey.WithFormValidator(func(map[string]interface{}) error {
+			return &survey.FieldError{Names: []string{"missing"}, Err: rule}
+		}))
+	if _, err := runner.AskAll([]survey.Question{{Name: "a", Message: "A?"}}); !errors.Is(err, rule) {
+		t.Errorf("AskAll() error = %v, want the validator's error when no listed question exists", err)
+	}
+}
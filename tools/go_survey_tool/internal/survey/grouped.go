@@ -0,0 +1,83 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Group Select选项的一个分组
+type Group struct {
+	Title   string
+	Options []string
+}
+
+// AskSelectGrouped 使用标准输入输出询问分组选择
+func AskSelectGrouped(message string, groups []Group, opts ...SelectOption) (int, error) {
+	return NewRunner().AskSelectGrouped(message, groups, opts...)
+}
+
+// AskSelectGrouped 分组显示选项：分组标题作为不可选的标题行，选项缩进显示在标题下方，
+// 上下移动时会跳过标题。返回选中项在所有分组中的扁平序号
+func (r *Runner) AskSelectGrouped(message string, groups []Group, opts ...SelectOption) (int, error) {
+	var flat []string
+	for _, group := range groups {
+		flat = append(flat, group.Options...)
+	}
+	if len(flat) == 0 {
+		return -1, errors.New("no options to select from")
+	}
+
+	var index int
+	var err error
+	if r.interactive() {
+		err = WithTerminalMode(func() error {
+			m := newGroupedMenu(groups)
+			m.config = newSelectConfig(opts)
+			if err := r.waitForSize(m.config); err != nil {
+				return err
+			}
+			index, err = r.runMenu(message, m)
+			return err
+		})
+	} else {
+		index, err = r.askGroupedLine(message, groups, flat)
+	}
+	if err != nil {
+		return -1, fmt.Errorf("选择失败: %w", err)
+	}
+	r.writeTranscript(Question{Type: TypeSelect, Message: message}, flat[index])
+	return index, nil
+}
+
+// askGroupedLine 简单模式下的分组选择：按分组输出带编号的选项，读取序号或选项文本
+func (r *Runner) askGroupedLine(message string, groups []Group, flat []string) (int, error) {
+	for {
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s %s\n", r.questionMark(), message)
+		n := 0
+		for _, group := range groups {
+			indent := "  "
+			if group.Title != "" {
+				fmt.Fprintf(&b, "  %s\n", group.Title)
+				indent = "    "
+			}
+			for _, option := range group.Options {
+				n++
+				fmt.Fprintf(&b, "%s%d. %s\n", indent, n, option)
+			}
+		}
+		b.WriteString("Enter a number: ")
+		fmt.Fprint(r.Out, b.String())
+
+		line, err := r.readLine()
+		if err != nil {
+			return -1, err
+		}
+		token := strings.TrimSpace(line)
+		if index, ok := resolveOptionIndex(flat, token); ok {
+			return index, nil
+		}
+		fmt.Fprintf(r.Out, "X invalid choice %q\n", token)
+	}
+}
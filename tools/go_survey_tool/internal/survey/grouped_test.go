@@ -0,0 +1,237 @@
+package survey
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+var testGroups = []Group{
+	{Title: "Fruits", Options: []string{"Apple", "Banana"}},
+	{Title: "Vegetables", Options: []string{"Carrot"}},
+}
+
+func TestGroupedMenuNavigationSkipsHeaders(t *testing.T) {
+	m := newGroupedMenu(testGroups)
+	if m.selected() != 0 {
+		t.Fatalf("initial selection = %d, want 0 (first option, not the header)", m.selected())
+	}
+
+	down := terminal.KeyEvent{Key: terminal.KeyDown}
+	up := terminal.KeyEvent{Key: terminal.KeyUp}
+
+	steps := []struct {
+		key      terminal.KeyEvent
+		expected int
+	}{
+		{down, 1}, // Banana
+		{down, 2}, // 跳过Vegetables标题到Carrot
+		{down, 0}, // 回绕到Apple
+		{up, 2},   // 向上回绕到Carrot
+		{up, 1},   // 跳过标题回到Banana
+	}
+	for i, step := range steps {
+		if _, err := m.handleKey(step.key); err != nil {
+			t.Fatalf("step %d: handleKey() error = %v", i, err)
+		}
+		if m.selected() != step.expected {
+			t.Errorf("step %d: selected = %d, want %d", i, m.selected(), step.expected)
+		}
+		if m.items[m.cursor].header {
+			t.Errorf("step %d: cursor landed on a header", i)
+		}
+	}
+
+	done, err := m.handleKey(terminal.KeyEvent{Key: terminal.KeyEnter})
+	if !done || err != nil {
+		t.Errorf("Enter should confirm the selection, got done=%v err=%v", done, err)
+	}
+}
+
+func TestGroupedMenuInterrupt(t *testing.T) {
+	m := newGroupedMenu(testGroups)
+	_, err := m.handleKey(terminal.KeyEvent{Key: terminal.KeyRune, Rune: 'c', Ctrl: true})
+	if !errors.Is(err, ErrInterrupted) {
+		t.Errorf("Ctrl-C error = %v, want ErrInterrupted", err)
+	}
+}
+
+func TestGroupedMenuRender(t *testing.T) {
+	m := newGroupedMenu(testGroups)
+	m.move(1)
+
+	expected := []string{
+		"? Pick one:  [Use arrows to move, enter to select]",
+		"Fruits",
+		"    Apple",
+		"  > Banana",
+		"Vegetables",
+		"    Carrot",
+	}
+	if got := m.render("Pick one:"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("render() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestGroupedMenuScrollKeepsHeaderVisible(t *testing.T) {
+	m := newGroupedMenu([]Group{
+		{Title: "A", Options: []string{"a1", "a2", "a3", "a4", "a5", "a6"}},
+		{Title: "B", Options: []string{"b1"}},
+	})
+	m.move(6) // 移动到b1
+
+	lines := m.render("Pick")
+	if lines[len(lines)-1] != "  > b1" {
+		t.Errorf("cursor line not visible: %q", lines)
+	}
+	if lines[len(lines)-2] != "B" {
+		t.Errorf("header of the current group should be visible: %q", lines)
+	}
+}
+
+func TestGroupedMenuListKey(t *testing.T) {
+	m := newGroupedMenu(testGroups)
+	var paged []string
+	m.pager = func(content string) error {
+		paged = append(paged, content)
+		return nil
+	}
+
+	r := NewRunner()
+	index, err := r.menuLoop(bufio.NewReader(strings.NewReader("?\x1b[B\r")), io.Discard, "Pick", m)
+	if err != nil {
+		t.Fatalf("menuLoop() error = %v", err)
+	}
+	if index != 1 {
+		t.Errorf("menuLoop() = %d, want 1", index)
+	}
+	want := "Fruits\n  1. Apple\n  2. Banana\nVegetables\n  3. Carrot\n"
+	if len(paged) != 1 || paged[0] != want {
+		t.Errorf("pager got %q, want [%q]", paged, want)
+	}
+}
+
+func TestGroupedMenuPagerFailsWithoutLogger(t *testing.T) {
+	m := newGroupedMenu(testGroups)
+	m.pager = func(string) error { return errors.New("no pager") }
+
+	// 直接构造的Runner没有Logger，分页程序失败时也不能panic
+	r := &Runner{In: strings.NewReader(""), Out: io.Discard}
+	index, err := r.menuLoop(bufio.NewReader(strings.NewReader("?\r")), io.Discard, "Pick", m)
+	if err != nil || index != 0 {
+		t.Errorf("menuLoop() = %d, %v, want 0", index, err)
+	}
+}
+
+func TestGroupedMenuListKeyWithoutPager(t *testing.T) {
+	m := newGroupedMenu(testGroups)
+	if _, err := m.handleKey(terminal.KeyEvent{Key: terminal.KeyRune, Rune: '?'}); err != nil {
+		t.Fatal(err)
+	}
+	if m.listPending {
+		t.Error("? should be ignored when the menu has no pager")
+	}
+}
+
+func TestAskSelectGroupedLineMode(t *testing.T) {
+	out := &bytes.Buffer{}
+	r := NewRunner(WithStdio(strings.NewReader("9\n3\n"), out, out))
+
+	index, err := r.AskSelectGrouped("Pick one:", testGroups)
+	if err != nil {
+		t.Fatalf("AskSelectGrouped() error = %v", err)
+	}
+	if index != 2 {
+		t.Errorf("AskSelectGrouped() = %d, want 2", index)
+	}
+	if !strings.Contains(out.String(), "  Vegetables\n    3. Carrot\n") {
+		t.Errorf("group headers not rendered:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), `X invalid choice "9"`) {
+		t.Errorf("expected an invalid choice error:\n%s", out.String())
+	}
+}
+
+func TestAskSelectGroupedNoOptions(t *testing.T) {
+	r := NewRunner(WithStdio(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{}))
+	if _, err := r.AskSelectGrouped("Pick", []Group{{Title: "Empty"}}); err == nil {
+		t.Error("expected an error when no options are given")
+	}
+}
+
+func TestDrawnRows(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		width int
+		want  int
+	}{
+		{"Fits", []string{"abc", "de"}, 10, 2},
+		{"Exact width", []string{"abcde"}, 5, 1},
+		{"Wraps", []string{"abcdefghijk", "x"}, 5, 4},
+		{"Empty line", []string{""}, 5, 1},
+		{"Wide characters", []string{"中文字符"}, 5, 2},
+		{"Unknown width", []string{"abcdefghijk"}, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := drawnRows(tt.lines, tt.width); got != tt.want {
+				t.Errorf("drawnRows(%q, %d) = %d, want %d", tt.lines, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupedMenuRenderFitsWidth(t *testing.T) {
+	m := newGroupedMenu(testGroups)
+	m.width = 12
+	for _, line := range m.render("A rather long question") {
+		if w := utils.DisplayWidth(line); w > 11 {
+			t.Errorf("line %q is %d columns wide, want at most 11", line, w)
+		}
+	}
+}
+
+// TestMenuLoopReflowsOnResize 手动测试：在终端中运行
+// survey-tool select -record /tmp/keys.json -message "A rather long question" A B C，
+// 把窗口拖窄再拖宽，菜单应始终按当前宽度完整重绘，不留下残行
+func TestMenuLoopReflowsOnResize(t *testing.T) {
+	m := newGroupedMenu(testGroups)
+	m.width = 80
+	resize := make(chan terminal.Size)
+	m.resize = resize
+	before := m.render("Pick")
+
+	keys, input := io.Pipe()
+	go func() {
+		// resize没有缓冲，发送完成时menuLoop已经收到新的大小
+		resize <- terminal.Size{Width: 20, Height: 24}
+		input.Write([]byte("\r"))
+	}()
+
+	var out bytes.Buffer
+	r := NewRunner()
+	index, err := r.menuLoop(bufio.NewReader(keys), &out, "Pick", m)
+	if err != nil {
+		t.Fatalf("menuLoop() error = %v", err)
+	}
+	if index != 0 {
+		t.Errorf("menuLoop() = %d, want 0", index)
+	}
+	up := fmt.Sprintf("\x1b[%dA\r", drawnRows(before, 20))
+	if !strings.Contains(out.String(), up) {
+		t.Errorf("output %q should move up %q over the rows the old menu wraps to", out.String(), up)
+	}
+	header := utils.FitToWidth(before[0], 19)
+	if !strings.Contains(out.String(), up+clearDown+header+"\r\n") {
+		t.Errorf("output %q should redraw the header fitted to the new width as %q", out.String(), header)
+	}
+}
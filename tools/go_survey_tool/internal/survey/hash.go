@@ -0,0 +1,32 @@
+package survey
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// hashedQuestion 参与QuestionsHash计算的字段，字段顺序固定，
+// 因此结果与问题文件中键的书写顺序无关
+type hashedQuestion struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Message string   `json:"message"`
+	Options []string `json:"options"`
+}
+
+// QuestionsHash 返回问题集的稳定哈希（SHA-256的十六进制），只取决于各问题的名称、类型、提示和选项，
+// 可用于判断缓存或保存的答案是否仍对应同一组问题。空类型与input视为相同
+func QuestionsHash(qs []Question) string {
+	canonical := make([]hashedQuestion, len(qs))
+	for i, q := range qs {
+		canonical[i] = hashedQuestion{Name: q.Name, Type: q.kind(), Message: q.Message, Options: q.Options}
+		if canonical[i].Options == nil {
+			canonical[i].Options = []string{}
+		}
+	}
+	// 只包含字符串，序列化不会失败
+	data, _ := json.Marshal(canonical)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,85 @@
+package survey_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestQuestionsHash(t *testing.T) {
+	load := func(input string) []survey.Question {
+		t.Helper()
+		questions, err := survey.LoadQuestions(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("LoadQuestions() error = %v", err)
+		}
+		return questions
+	}
+	base := load(`
+questions:
+  - name: color
+    type: select
+    message: Color?
+    options: [Red, Blue]
+`)
+	tests := []struct {
+		name  string
+		input string
+		same  bool
+	}{
+		{"Fields reordered", `
+questions:
+  - options: [Red, Blue]
+    message: Color?
+    type: select
+    name: color
+`, true},
+		{"Default changed", `
+questions:
+  - name: color
+    type: select
+    message: Color?
+    options: [Red, Blue]
+    default: Blue
+`, true},
+		{"Message changed", `
+questions:
+  - name: color
+    type: select
+    message: Favourite color?
+    options: [Red, Blue]
+`, false},
+		{"Options reordered", `
+questions:
+  - name: color
+    type: select
+    message: Color?
+    options: [Blue, Red]
+`, false},
+		{"Name changed", `
+questions:
+  - name: colour
+    type: select
+    message: Color?
+    options: [Red, Blue]
+`, false},
+	}
+	want := survey.QuestionsHash(base)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := survey.QuestionsHash(load(tt.input))
+			if (got == want) != tt.same {
+				t.Errorf("QuestionsHash() = %s, base %s, want same = %v", got, want, tt.same)
+			}
+		})
+	}
+}
+
+func TestQuestionsHashEmptyTypeIsInput(t *testing.T) {
+	a := survey.QuestionsHash([]survey.Question{{Name: "name", Message: "Name?"}})
+	b := survey.QuestionsHash([]survey.Question{{Name: "name", Type: survey.TypeInput, Message: "Name?"}})
+	if a != b || len(a) != 64 {
+		t.Errorf("QuestionsHash() = %q and %q, want equal 64-char hashes", a, b)
+	}
+}
@@ -0,0 +1,91 @@
+package survey
+
+import (
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+// ErrIdleTimeout 用户长时间没有输入，调查被放弃
+var ErrIdleTimeout = errors.New("survey idle timeout")
+
+// AskAllWithIdleTimeout 使用标准输入输出运行带空闲超时的调查
+func AskAllWithIdleTimeout(questions []Question, idle time.Duration) (map[string]interface{}, error) {
+	return NewRunner().AskAllWithIdleTimeout(questions, idle)
+}
+
+// AskAllWithIdleTimeout 与AskAll相同，但整个调查共用一个空闲计时器：
+// 每收到一个答案计时器重新开始，等待任何一个问题的输入超过idle时返回ErrIdleTimeout和已收集的答案。
+// 等待通过terminal.InputReady实现，不需要中途打断survey的读取；只有In是*os.File时生效
+func (r *Runner) AskAllWithIdleTimeout(questions []Question, idle time.Duration) (map[string]interface{}, error) {
+	r.idleDeadline = r.clock().Now().Add(idle)
+	defer func() { r.idleDeadline = time.Time{} }()
+
+	return r.askAll(questions, map[string]interface{}{}, func(string, map[string]interface{}) error {
+		r.idleDeadline = r.clock().Now().Add(idle)
+		return nil
+	})
+}
+
+// input 返回读取答案使用的输入
+// In是文件时包装为idleFile，设置了空闲期限或问题期限时在读取前先等待输入；
+// 其他输入包装为timedReader，只支持问题期限
+func (r *Runner) input() io.Reader {
+	if f, ok := r.In.(*os.File); ok {
+		return idleFile{File: f, runner: r}
+	}
+	return timedReader{src: r.In, runner: r}
+}
+
+// idleFile 读取前检查Runner的空闲期限和问题期限，实现了survey需要的Fd方法
+type idleFile struct {
+	*os.File
+	runner *Runner
+}
+
+// Read 在期限内等待输入，空闲超时返回ErrIdleTimeout，问题超时返回errReadTimeout，
+// 问题的ctx被取消时返回errReadCancelled
+func (f idleFile) Read(p []byte) (int, error) {
+	r := f.runner
+	for {
+		deadline, expiredErr := r.idleDeadline, ErrIdleTimeout
+		if q := r.questionDeadline; !q.IsZero() && (deadline.IsZero() || q.Before(deadline)) {
+			deadline, expiredErr = q, errReadTimeout
+		}
+		// 可以被取消或延长时分段等待，每段结束后检查一次
+		polling := r.questionCtx != nil || r.timeoutControl != nil
+		if deadline.IsZero() && !polling {
+			break
+		}
+
+		wait := time.Duration(-1)
+		if !deadline.IsZero() {
+			wait = max(deadline.Sub(r.clock().Now()), 0)
+		}
+		if polling && (wait < 0 || wait > pollInterval) {
+			wait = pollInterval
+		}
+		ready, err := terminal.InputReady(int(f.Fd()), wait)
+		if err != nil {
+			return 0, err
+		}
+		if ready {
+			break
+		}
+		if !polling {
+			return 0, expiredErr
+		}
+		select {
+		case <-ctxDone(r.questionCtx):
+			return 0, errReadCancelled
+		default:
+		}
+		if !r.extendDeadline() && !deadline.IsZero() && !r.clock().Now().Before(deadline) {
+			return 0, expiredErr
+		}
+	}
+	return f.File.Read(p)
+}
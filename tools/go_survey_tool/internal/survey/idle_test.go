@@ -0,0 +1,64 @@
+package survey_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+// pipeRunner 创建从管道读取输入的Runner，返回管道的写端
+func pipeRunner(t *testing.T) (*survey.Runner, *os.File) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { r.Close(); w.Close() })
+	return survey.NewRunner(survey.WithStdio(r, &bytes.Buffer{}, &bytes.Buffer{})), w
+}
+
+var idleQuestions = []survey.Question{
+	{Name: "first", Message: "First?"},
+	{Name: "second", Message: "Second?"},
+}
+
+func TestAskAllWithIdleTimeoutAborts(t *testing.T) {
+	runner, w := pipeRunner(t)
+	w.WriteString("one\n")
+
+	start := time.Now()
+	answers, err := runner.AskAllWithIdleTimeout(idleQuestions, 50*time.Millisecond)
+	if !errors.Is(err, survey.ErrIdleTimeout) {
+		t.Fatalf("AskAllWithIdleTimeout() error = %v, want ErrIdleTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("timeout took %v", elapsed)
+	}
+	if !reflect.DeepEqual(answers, map[string]interface{}{"first": "one"}) {
+		t.Errorf("answers = %v, want only the first answer", answers)
+	}
+}
+
+func TestAskAllWithIdleTimeoutResetsOnAnswer(t *testing.T) {
+	runner, w := pipeRunner(t)
+	go func() {
+		// 每个答案之间的间隔都小于idle，但总时长超过idle
+		for _, line := range []string{"one\n", "two\n"} {
+			time.Sleep(60 * time.Millisecond)
+			w.WriteString(line)
+		}
+	}()
+
+	answers, err := runner.AskAllWithIdleTimeout(idleQuestions, 150*time.Millisecond)
+	if err != nil {
+		t.Fatalf("AskAllWithIdleTimeout() error = %v", err)
+	}
+	if !reflect.DeepEqual(answers, map[string]interface{}{"first": "one", "second": "two"}) {
+		t.Errorf("answers = %v", answers)
+	}
+}
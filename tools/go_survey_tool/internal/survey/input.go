@@ -0,0 +1,43 @@
+package survey
+
+import (
+	"fmt"
+	"strings"
+
+	surveyv2 "github.com/AlecAivazis/survey/v2"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// surveyDefaultHint survey的input模板中显示默认值的部分
+const surveyDefaultHint = `{{color "white"}}({{.Default}}) {{color "reset"}}`
+
+// inputTemplate 返回交互模式下input使用的模板，默认值与简单模式一样用defaultHint显示
+// 每次提问时重新生成，是否着色遵循当时的NO_COLOR和TERM；交互模式的输出总是终端
+func inputTemplate() string {
+	return strings.Replace(surveyv2.InputQuestionTemplate, surveyDefaultHint, defaultHint("{{.Default}}", utils.ColorEnabled())+" ", 1)
+}
+
+// defaultHint 把默认值显示为"(def)"，例如"What is your name? (Alice):"，color为true时为暗色
+func defaultHint(def string, color bool) string {
+	hint := "(" + def + ")"
+	if !color {
+		return hint
+	}
+	return utils.Colorize(hint, utils.Dim)
+}
+
+// AskInput 使用标准输入输出询问文本输入
+func AskInput(message, def string, validate func(string) error) (string, error) {
+	return NewRunner().AskInput(message, def, validate)
+}
+
+// AskInput 询问一行文本输入，def不为空时以暗色显示在提示后的括号中，直接回车时使用def
+// validate可以用utils.AllValidators等组合多个验证函数，为nil时不验证
+func (r *Runner) AskInput(message, def string, validate func(string) error) (string, error) {
+	answer, err := r.Ask(Question{Type: TypeInput, Message: message, Default: def, Validate: validate})
+	if err != nil {
+		return "", fmt.Errorf("输入失败: %w", err)
+	}
+	return answer.(string), nil
+}
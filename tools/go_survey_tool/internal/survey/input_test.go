@@ -0,0 +1,54 @@
+package survey
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	surveyv2 "github.com/AlecAivazis/survey/v2"
+)
+
+// enableColor 让utils.Colorize输出颜色，测试结束后恢复环境变量
+func enableColor(t *testing.T) {
+	t.Helper()
+	t.Setenv("NO_COLOR", "")
+	os.Unsetenv("NO_COLOR")
+	t.Setenv("TERM", "xterm")
+}
+
+const dimAlice = "\x1b[2m(Alice)\x1b[0m"
+
+func TestAskInputDefaultHint(t *testing.T) {
+	// 环境允许颜色，但Out不是终端，默认值不着色
+	enableColor(t)
+	out := &bytes.Buffer{}
+	r := NewRunner(WithStdio(strings.NewReader("\n"), out, out))
+	answer, err := r.AskInput("What is your name?", "Alice", nil)
+	if err != nil {
+		t.Fatalf("AskInput() error = %v", err)
+	}
+	if answer != "Alice" {
+		t.Errorf("AskInput() = %q, want the default on enter", answer)
+	}
+	if want := "? What is your name? (Alice): "; out.String() != want {
+		t.Errorf("prompt = %q, want %q", out.String(), want)
+	}
+}
+
+func TestInputTemplateDefaultHint(t *testing.T) {
+	enableColor(t)
+	data := surveyv2.InputTemplateData{
+		Input:  surveyv2.Input{Message: "What is your name?", Default: "Alice"},
+		Config: promptConfig(),
+	}
+	got := renderSurveyTemplate(t, inputTemplate(), data)
+	if want := "? What is your name? " + dimAlice + " "; got != want {
+		t.Errorf("input template = %q, want %q", got, want)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if got := renderSurveyTemplate(t, inputTemplate(), data); got != "? What is your name? (Alice) " {
+		t.Errorf("input template with NO_COLOR = %q, want a plain hint", got)
+	}
+}
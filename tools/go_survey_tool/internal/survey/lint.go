@@ -0,0 +1,183 @@
+package survey
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem LintQuestions在问题文件中发现的一个问题
+type Problem struct {
+	Line     int    `json:"line,omitempty"`     // 所在行，从1开始，未知时为0
+	Question string `json:"question,omitempty"` // 所在问题的name
+	Field    string `json:"field,omitempty"`    // 出错的字段，例如options
+	Message  string `json:"message"`
+}
+
+// String 返回不含行号的描述，例如`question "size": field "options": cannot unmarshal !!str into []string`
+func (p Problem) String() string {
+	var b strings.Builder
+	if p.Question != "" {
+		fmt.Fprintf(&b, "question %q: ", p.Question)
+	}
+	if p.Field != "" {
+		fmt.Fprintf(&b, "field %q: ", p.Field)
+	}
+	b.WriteString(p.Message)
+	return b.String()
+}
+
+// yamlErrorLine yaml错误信息开头的行号，例如"line 7: ..."
+var yamlErrorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// unknownFieldError yaml.v3对未知字段的报错，其中的Go类型名对问题文件的作者没有意义
+var unknownFieldError = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+
+// LintQuestions 检查问题文件，返回发现的所有问题，没有问题时返回nil。
+// 与LoadQuestions不同，它不在第一个错误处停止：YAML语法错误之外，未知字段、类型错误、
+// 无效的timeout和ValidateQuestions发现的错误都会列出，并尽量标注行号和所在的问题
+func LintQuestions(r io.Reader) ([]Problem, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取问题文件失败: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return []Problem{yamlProblem(err.Error())}, nil
+	}
+	pos := newQuestionPositions(&root)
+
+	var problems []Problem
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var file questionsFile
+	var typeErr *yaml.TypeError
+	switch err := dec.Decode(&file); {
+	case errors.As(err, &typeErr):
+		// 类型错误不影响其他字段的解码，继续检查解码出的内容
+		for _, msg := range typeErr.Errors {
+			p := yamlProblem(msg)
+			question, field := pos.at(p.Line)
+			p.Question = question
+			if p.Field == "" {
+				p.Field = field
+			}
+			problems = append(problems, p)
+		}
+	case errors.Is(err, io.EOF):
+		return []Problem{{Message: "file is empty"}}, nil
+	case err != nil:
+		return []Problem{yamlProblem(err.Error())}, nil
+	}
+	if len(file.Questions) == 0 && len(problems) == 0 {
+		return []Problem{{Message: "no questions defined"}}, nil
+	}
+
+	questions := make([]Question, len(file.Questions))
+	for i, fq := range file.Questions {
+		q, err := fq.question()
+		if err != nil {
+			problems = append(problems, Problem{Line: pos.field(i, "timeout"), Question: fq.Name, Field: "timeout", Message: errors.Unwrap(err).Error()})
+			fq.Timeout = ""
+			q, _ = fq.question()
+		}
+		questions[i] = q
+	}
+	checkQuestions(questions, func(i int, err error) {
+		problems = append(problems, Problem{Line: pos.item(i), Question: questions[i].Name, Message: err.Error()})
+	})
+
+	// 按行号排序，没有行号的排在最后
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i].Line, problems[j].Line
+		return a != 0 && (b == 0 || a < b)
+	})
+	return problems, nil
+}
+
+// yamlProblem 把yaml的错误信息转换为Problem，分离出其中的行号
+func yamlProblem(msg string) Problem {
+	p := Problem{Message: strings.TrimPrefix(msg, "yaml: ")}
+	if m := yamlErrorLine.FindStringSubmatch(msg); m != nil {
+		p.Line, _ = strconv.Atoi(m[1])
+		p.Message = m[2]
+	}
+	if m := unknownFieldError.FindStringSubmatch(p.Message); m != nil {
+		p.Field, p.Message = m[1], "unknown field"
+	}
+	return p
+}
+
+// questionPositions 各问题及其字段在文件中的行号
+type questionPositions struct {
+	items []*yaml.Node // questions列表中每一项的节点
+}
+
+// newQuestionPositions 从解析出的节点树中找到questions列表
+func newQuestionPositions(root *yaml.Node) questionPositions {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return questionPositions{}
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return questionPositions{}
+	}
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value == "questions" && doc.Content[i+1].Kind == yaml.SequenceNode {
+			return questionPositions{items: doc.Content[i+1].Content}
+		}
+	}
+	return questionPositions{}
+}
+
+// item 返回第i个问题开始的行号
+func (p questionPositions) item(i int) int {
+	if i < 0 || i >= len(p.items) {
+		return 0
+	}
+	return p.items[i].Line
+}
+
+// field 返回第i个问题中字段key所在的行号，找不到时返回问题开始的行号
+func (p questionPositions) field(i int, key string) int {
+	if i >= 0 && i < len(p.items) {
+		item := p.items[i]
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			if item.Content[j].Value == key {
+				return item.Content[j].Line
+			}
+		}
+	}
+	return p.item(i)
+}
+
+// at 返回line所在的问题的name和字段名，不在任何问题中时返回空字符串
+func (p questionPositions) at(line int) (question, field string) {
+	for _, item := range p.items {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			key, value := item.Content[j], item.Content[j+1]
+			if key.Value == "name" {
+				question = value.Value
+			}
+			if key.Line == line || value.Line == line {
+				field = key.Value
+			}
+		}
+		if field != "" {
+			return question, field
+		}
+		question = ""
+	}
+	return "", ""
+}
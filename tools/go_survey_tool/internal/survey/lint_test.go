@@ -0,0 +1,83 @@
+package survey_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+// brokenQuestionsFile 包含各类错误的问题文件
+const brokenQuestionsFile = `questions:
+  - name: color
+    type: select
+    message: Color?
+    options: Red
+  - name: size
+    mesage: Size?
+    timeout: soon
+  - name: color
+    type: slider
+    message: Again?
+`
+
+func TestLintQuestions(t *testing.T) {
+	problems, err := survey.LintQuestions(strings.NewReader(brokenQuestionsFile))
+	if err != nil {
+		t.Fatalf("LintQuestions() error = %v", err)
+	}
+	expected := []survey.Problem{
+		{Line: 2, Question: "color", Message: "select needs options"},
+		{Line: 5, Question: "color", Field: "options", Message: "cannot unmarshal !!str `Red` into []string"},
+		{Line: 6, Question: "size", Message: "message is required"},
+		{Line: 7, Question: "size", Field: "mesage", Message: "unknown field"},
+		{Line: 8, Question: "size", Field: "timeout", Message: `invalid timeout "soon"`},
+		{Line: 9, Question: "color", Message: `unknown question type "slider"`},
+		{Line: 9, Question: "color", Message: "duplicate name"},
+	}
+	if !reflect.DeepEqual(problems, expected) {
+		t.Errorf("LintQuestions() =\n%+v\nwant\n%+v", problems, expected)
+	}
+}
+
+func TestLintQuestionsFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected survey.Problem
+	}{
+		{"Syntax error", "questions:\n  - name: a\n   message: b\n", survey.Problem{Line: 1, Message: "did not find expected '-' indicator"}},
+		{"Empty file", "", survey.Problem{Message: "file is empty"}},
+		{"No questions", "questions: []\n", survey.Problem{Message: "no questions defined"}},
+		{"Unknown top-level field", "question:\n  - name: a\n", survey.Problem{Line: 1, Field: "question", Message: "unknown field"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, err := survey.LintQuestions(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("LintQuestions() error = %v", err)
+			}
+			if len(problems) == 0 || problems[0] != tt.expected {
+				t.Errorf("LintQuestions() = %+v, want first problem %+v", problems, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLintQuestionsValid(t *testing.T) {
+	problems, err := survey.LintQuestions(strings.NewReader(survey.QuestionsSkeleton))
+	if err != nil || problems != nil {
+		t.Errorf("LintQuestions(skeleton) = %v, %v, want no problems", problems, err)
+	}
+}
+
+func TestProblemString(t *testing.T) {
+	p := survey.Problem{Line: 5, Question: "size", Field: "options", Message: "bad"}
+	if got := p.String(); got != `question "size": field "options": bad` {
+		t.Errorf("Problem.String() = %q", got)
+	}
+	if got := (survey.Problem{Message: "file is empty"}).String(); got != "file is empty" {
+		t.Errorf("Problem.String() = %q", got)
+	}
+}
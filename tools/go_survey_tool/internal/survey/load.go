@@ -0,0 +1,183 @@
+package survey
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// questionsFile 问题文件的格式
+//
+//	questions:
+//	  - name: color
+//	    type: select
+//	    message: Choose a color
+//	    options: [Red, Blue]
+//	    default: Blue
+type questionsFile struct {
+	Questions []fileQuestion `yaml:"questions"`
+}
+
+// fileQuestion 问题文件中的一个问题，字段含义与Question相同
+// timeout使用time.ParseDuration的格式，如"30s"
+type fileQuestion struct {
+	Name       string   `yaml:"name"`
+	Type       string   `yaml:"type"`
+	Message    string   `yaml:"message"`
+	Default    string   `yaml:"default"`
+	DefaultEnv string   `yaml:"default_env"`
+	Options    []string `yaml:"options"`
+	Timeout    string   `yaml:"timeout"`
+	SecretEnv  string   `yaml:"secret_env"`
+	SecretFile string   `yaml:"secret_file"`
+}
+
+// LoadQuestionsFile 从YAML文件读取问题
+func LoadQuestionsFile(path string) ([]Question, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取问题文件失败: %w", err)
+	}
+	defer f.Close()
+	return LoadQuestions(f)
+}
+
+// 从URL读取问题文件的限制
+const (
+	questionsURLTimeout = 10 * time.Second
+	maxQuestionsSize    = 1 << 20 // 1 MiB，问题文件远小于这个大小
+)
+
+// LoadQuestionsURL 通过HTTP(S)读取YAML或JSON格式的问题文件，用于集中维护的问卷。
+// 格式按Content-Type判断，没有可用的Content-Type时按URL的扩展名判断；JSON是YAML的子集，
+// 两者都由LoadQuestions解析。请求超时为10秒，响应超过1 MiB或是HTML等其他格式时返回错误。
+// 远程文件不能使用secret_file、secret_env和default_env，见rejectLocalSources
+func LoadQuestionsURL(rawURL string) ([]Question, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid questions URL %q: only http and https are supported", rawURL)
+	}
+
+	client := &http.Client{Timeout: questionsURLTimeout}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("下载问题文件失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("下载问题文件失败: server returned %s", resp.Status)
+	}
+	if !questionsContent(resp.Header.Get("Content-Type"), u.Path) {
+		return nil, fmt.Errorf("%s is not a YAML or JSON questions file (Content-Type %q)", rawURL, resp.Header.Get("Content-Type"))
+	}
+	if resp.ContentLength > maxQuestionsSize {
+		return nil, fmt.Errorf("questions file at %s is larger than %d bytes", rawURL, maxQuestionsSize)
+	}
+
+	// 多读一个字节，用来发现没有Content-Length的超大响应
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxQuestionsSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("下载问题文件失败: %w", err)
+	}
+	if len(data) > maxQuestionsSize {
+		return nil, fmt.Errorf("questions file at %s is larger than %d bytes", rawURL, maxQuestionsSize)
+	}
+	questions, err := LoadQuestions(strings.NewReader(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	if err := rejectLocalSources(questions); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// rejectLocalSources 检查远程问题文件没有从本机读取答案或默认值的字段：
+// secret_file和secret_env会不经提问读取本地的文件或环境变量并放进结果，default_env会把环境变量显示为默认值，
+// 这些内容随后可能被输出或提交，因此只能由本地的问题文件使用
+func rejectLocalSources(questions []Question) error {
+	for _, q := range questions {
+		var field string
+		switch {
+		case q.SecretFile != "":
+			field = "secret_file"
+		case q.SecretEnv != "":
+			field = "secret_env"
+		case q.DefaultEnv != "":
+			field = "default_env"
+		default:
+			continue
+		}
+		return &QuestionError{Name: q.Name, Err: fmt.Errorf("%s is not allowed in a remote questions file", field)}
+	}
+	return nil
+}
+
+// questionsContent 按Content-Type或URL路径的扩展名判断响应是否可能是YAML或JSON的问题文件。
+// text/plain和application/octet-stream这类通用类型不能说明格式，改看扩展名，扩展名也无法判断时当作YAML
+func questionsContent(contentType, urlPath string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || strings.Contains(mediaType, "yaml"):
+		return true
+	case mediaType != "" && mediaType != "text/plain" && mediaType != "application/octet-stream":
+		return false
+	}
+	ext := strings.ToLower(path.Ext(urlPath))
+	return ext != ".html" && ext != ".htm"
+}
+
+// LoadQuestions 从YAML读取问题并用ValidateQuestions检查，返回所有发现的问题。
+// 文件中出现未知字段时报错，以便发现拼写错误
+func LoadQuestions(r io.Reader) ([]Question, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var file questionsFile
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("解析问题文件失败: %w", err)
+	}
+
+	questions := make([]Question, 0, len(file.Questions))
+	for _, fq := range file.Questions {
+		q, err := fq.question()
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, q)
+	}
+	if err := ValidateQuestions(questions); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// question 转换为Question，其余字段的检查由ValidateQuestions完成
+func (fq fileQuestion) question() (Question, error) {
+	q := Question{
+		Name:       fq.Name,
+		Type:       fq.Type,
+		Message:    fq.Message,
+		Default:    fq.Default,
+		DefaultEnv: fq.DefaultEnv,
+		Options:    fq.Options,
+		SecretEnv:  fq.SecretEnv,
+		SecretFile: fq.SecretFile,
+	}
+	if fq.Timeout != "" {
+		timeout, err := time.ParseDuration(fq.Timeout)
+		if err != nil || timeout < 0 {
+			return Question{}, &QuestionError{Name: fq.Name, Err: fmt.Errorf("invalid timeout %q", fq.Timeout)}
+		}
+		q.Timeout = timeout
+	}
+	return q, nil
+}
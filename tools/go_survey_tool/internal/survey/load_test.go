@@ -0,0 +1,183 @@
+package survey_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestLoadQuestionsSkeleton(t *testing.T) {
+	questions, err := survey.LoadQuestions(strings.NewReader(survey.QuestionsSkeleton))
+	if err != nil {
+		t.Fatalf("LoadQuestions(skeleton) error = %v", err)
+	}
+
+	types := map[string]bool{}
+	for _, q := range questions {
+		types[q.Type] = true
+	}
+	for _, typ := range []string{survey.TypeInput, survey.TypePassword, survey.TypeConfirm, survey.TypeSelect, survey.TypeMultiSelect} {
+		if !types[typ] {
+			t.Errorf("skeleton has no %s question", typ)
+		}
+	}
+
+	last := questions[len(questions)-1]
+	if last.Default != "no" || last.Timeout != 30*time.Second {
+		t.Errorf("confirm question = %+v, want default no and a 30s timeout", last)
+	}
+}
+
+func TestLoadQuestions(t *testing.T) {
+	input := `
+questions:
+  - name: region
+    message: Region?
+    default: us-east
+    default_env: REGION
+  - name: size
+    type: select
+    message: Size?
+    options: [S, M, L]
+  - name: token
+    type: password
+    message: Token?
+    secret_env: TOKEN
+    secret_file: /run/secrets/token
+`
+	questions, err := survey.LoadQuestions(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("LoadQuestions() error = %v", err)
+	}
+	expected := []survey.Question{
+		{Name: "region", Message: "Region?", Default: "us-east", DefaultEnv: "REGION"},
+		{Name: "size", Type: survey.TypeSelect, Message: "Size?", Options: []string{"S", "M", "L"}},
+		{Name: "token", Type: survey.TypePassword, Message: "Token?", SecretEnv: "TOKEN", SecretFile: "/run/secrets/token"},
+	}
+	if !reflect.DeepEqual(questions, expected) {
+		t.Errorf("LoadQuestions() = %+v, want %+v", questions, expected)
+	}
+}
+
+func TestLoadQuestionsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		errText string
+	}{
+		{"Missing name", "questions:\n  - message: A?\n", "name is required"},
+		{"Missing message", "questions:\n  - name: a\n", `question "a": message is required`},
+		{"Unknown type", "questions:\n  - name: a\n    type: slider\n    message: A?\n", "unknown question type"},
+		{"Select without options", "questions:\n  - name: a\n    type: select\n    message: A?\n", "select needs options"},
+		{"Duplicate name", "questions:\n  - name: a\n    message: A?\n  - name: a\n    message: B?\n", `question "a": duplicate name`},
+		{"Bad timeout", "questions:\n  - name: a\n    message: A?\n    timeout: soon\n", "invalid timeout"},
+		{"Unknown field", "questions:\n  - name: a\n    mesage: A?\n", "mesage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := survey.LoadQuestions(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("LoadQuestions() error = %v, want %q", err, tt.errText)
+			}
+		})
+	}
+}
+
+func TestLoadQuestionsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "survey.yaml")
+	if err := os.WriteFile(path, []byte(survey.QuestionsSkeleton), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := survey.LoadQuestionsFile(path); err != nil {
+		t.Errorf("LoadQuestionsFile() error = %v", err)
+	}
+	if _, err := survey.LoadQuestionsFile(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadQuestionsFile(missing) error = %v, want os.ErrNotExist", err)
+	}
+}
+
+// questionsServer 返回一个按路径提供问题文件的测试服务器
+func questionsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/form.yaml":
+			w.Header().Set("Content-Type", "application/yaml")
+			io.WriteString(w, "questions:\n  - name: color\n    type: select\n    message: Color?\n    options: [Red, Blue]\n")
+		case "/form":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			io.WriteString(w, `{"questions": [{"name": "email", "message": "Email?"}]}`)
+		case "/secret.yaml":
+			w.Header().Set("Content-Type", "application/yaml")
+			io.WriteString(w, "questions:\n  - name: token\n    type: password\n    message: Token?\n    secret_file: /etc/passwd\n")
+		case "/env.yaml":
+			w.Header().Set("Content-Type", "application/yaml")
+			io.WriteString(w, "questions:\n  - name: home\n    message: Home?\n    default_env: HOME\n")
+		case "/page":
+			w.Header().Set("Content-Type", "text/html")
+			io.WriteString(w, "<html>login required</html>")
+		case "/huge.yaml":
+			w.Header().Set("Content-Type", "text/plain")
+			w.(http.Flusher).Flush() // 分块传输，没有Content-Length
+			io.WriteString(w, "questions:\n"+strings.Repeat("# padding\n", 200000))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLoadQuestionsURL(t *testing.T) {
+	server := questionsServer(t)
+
+	questions, err := survey.LoadQuestionsURL(server.URL + "/form.yaml")
+	if err != nil {
+		t.Fatalf("LoadQuestionsURL(yaml) error = %v", err)
+	}
+	want := []survey.Question{{Name: "color", Type: survey.TypeSelect, Message: "Color?", Options: []string{"Red", "Blue"}}}
+	if !reflect.DeepEqual(questions, want) {
+		t.Errorf("LoadQuestionsURL(yaml) = %#v, want %#v", questions, want)
+	}
+
+	questions, err = survey.LoadQuestionsURL(server.URL + "/form")
+	if err != nil {
+		t.Fatalf("LoadQuestionsURL(json) error = %v", err)
+	}
+	if len(questions) != 1 || questions[0].Name != "email" {
+		t.Errorf("LoadQuestionsURL(json) = %#v, want the email question", questions)
+	}
+}
+
+func TestLoadQuestionsURLErrors(t *testing.T) {
+	server := questionsServer(t)
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"Not found", server.URL + "/missing.yaml", "404"},
+		{"HTML page", server.URL + "/page", "not a YAML or JSON questions file"},
+		{"Huge response", server.URL + "/huge.yaml", "larger than"},
+		{"Unsupported scheme", "file:///etc/passwd", "only http and https"},
+		{"Secret file", server.URL + "/secret.yaml", `question "token": secret_file is not allowed`},
+		{"Default from env", server.URL + "/env.yaml", `question "home": default_env is not allowed`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := survey.LoadQuestionsURL(tt.url); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadQuestionsURL() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
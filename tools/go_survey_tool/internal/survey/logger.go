@@ -0,0 +1,75 @@
+package survey
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// 日志级别
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// Logger 面向运维诊断的日志接口，kv为交替出现的键和值
+// 日志不会写入Runner的Out，避免污染标准输出中的提示和结果
+type Logger interface {
+	Log(level, msg string, kv ...interface{})
+}
+
+// nopLogger 默认的空日志
+type nopLogger struct{}
+
+func (nopLogger) Log(string, string, ...interface{}) {}
+
+// StderrLogger 以 "[level] msg key=value ..." 的格式逐行输出日志
+// W为nil时写到标准错误
+type StderrLogger struct {
+	W io.Writer
+}
+
+// Log 实现Logger接口
+func (l StderrLogger) Log(level, msg string, kv ...interface{}) {
+	w := l.W
+	if w == nil {
+		w = os.Stderr
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", level, msg)
+	for i := 0; i < len(kv); i += 2 {
+		if i+1 < len(kv) {
+			fmt.Fprintf(&b, " %v=%v", kv[i], kv[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", kv[i])
+		}
+	}
+	b.WriteByte('\n')
+	io.WriteString(w, b.String())
+}
+
+// WithLogger 设置Runner的诊断日志
+func WithLogger(logger Logger) RunnerOption {
+	return func(r *Runner) {
+		r.Logger = logger
+	}
+}
+
+// log 记录一条日志，Logger为nil时忽略
+func (r *Runner) log(level, msg string, kv ...interface{}) {
+	if r.Logger != nil {
+		r.Logger.Log(level, msg, kv...)
+	}
+}
+
+// loggedAnswer 返回适合写入日志的答案，密码会被隐藏
+func loggedAnswer(q Question, answer interface{}) interface{} {
+	if q.kind() == TypePassword {
+		return "****"
+	}
+	return answer
+}
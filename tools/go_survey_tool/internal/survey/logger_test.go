@@ -0,0 +1,92 @@
+package survey_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// capturingLogger 记录所有日志事件用于断言
+type capturingLogger struct {
+	entries []logEntry
+}
+
+type logEntry struct {
+	level, msg string
+	kv         []interface{}
+}
+
+func (l *capturingLogger) Log(level, msg string, kv ...interface{}) {
+	l.entries = append(l.entries, logEntry{level, msg, kv})
+}
+
+func (l *capturingLogger) messages() []string {
+	var msgs []string
+	for _, e := range l.entries {
+		msgs = append(msgs, e.msg)
+	}
+	return msgs
+}
+
+func TestRunnerLogsQuestionEvents(t *testing.T) {
+	logger := &capturingLogger{}
+	out := &bytes.Buffer{}
+	runner := survey.NewRunner(
+		survey.WithStdio(strings.NewReader("\nAlice\nhunter2\n"), out, out),
+		survey.WithLogger(logger),
+	)
+
+	_, err := runner.AskAll([]survey.Question{
+		{Name: "name", Message: "Name?", Validate: utils.ValidateNotEmpty},
+		{Name: "secret", Type: survey.TypePassword, Message: "Password"},
+	})
+	if err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+
+	expected := []string{
+		"question shown", "validation failed", "answer received",
+		"question shown", "answer received",
+	}
+	got := logger.messages()
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Fatalf("logged events = %v, want %v", got, expected)
+	}
+
+	// 密码答案不能出现在日志中
+	last := logger.entries[len(logger.entries)-1]
+	if last.kv[3] != "****" {
+		t.Errorf("password answer should be redacted in logs, got %v", last.kv[3])
+	}
+
+	// 日志不应写入Out
+	if strings.Contains(out.String(), "answer received") {
+		t.Errorf("log output leaked into Out:\n%s", out.String())
+	}
+}
+
+func TestRunnerDefaultLoggerIsSilent(t *testing.T) {
+	out := &bytes.Buffer{}
+	runner := survey.NewRunner(survey.WithStdio(strings.NewReader("Alice\n"), out, out))
+	if _, err := runner.Ask(survey.Question{Name: "name", Message: "Name?"}); err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if out.String() != "? Name?: " {
+		t.Errorf("unexpected output with default logger: %q", out.String())
+	}
+}
+
+func TestStderrLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := survey.StderrLogger{W: &buf}
+	logger.Log(survey.LevelInfo, "answer received", "name", "color", "value", "Blue")
+	logger.Log(survey.LevelWarn, "odd", "dangling")
+
+	expected := "[info] answer received name=color value=Blue\n[warn] odd dangling\n"
+	if buf.String() != expected {
+		t.Errorf("StderrLogger output = %q, want %q", buf.String(), expected)
+	}
+}
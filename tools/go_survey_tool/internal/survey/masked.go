@@ -0,0 +1,98 @@
+package survey
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+// AskMasked 使用标准输入输出询问部分遮盖的输入
+func AskMasked(message string, mask func(typed string) string) (string, error) {
+	return NewRunner().AskMasked(message, mask)
+}
+
+// AskMasked 询问一行输入，回显的内容由mask根据已输入的原始内容生成，返回的是原始内容。
+// 例如电话号码可以只显示后四位。交互模式下在raw模式中逐键读取；
+// 简单模式下无法控制回显，直接读取一行
+func (r *Runner) AskMasked(message string, mask func(typed string) string) (string, error) {
+	var answer string
+	var err error
+	if r.interactive() {
+		err = WithTerminalMode(func() error {
+			in := r.In.(*os.File)
+			restore, err := terminal.EnterRaw(int(in.Fd()))
+			if err != nil {
+				return fmt.Errorf("无法进入raw模式: %w", err)
+			}
+			defer restore()
+			answer, err = r.maskedLoop(bufio.NewReader(r.input()), r.Out, message, mask)
+			return err
+		})
+	} else {
+		fmt.Fprintf(r.Out, "%s %s: ", r.questionMark(), message)
+		answer, err = r.readLine()
+	}
+	if err != nil {
+		return "", fmt.Errorf("输入失败: %w", err)
+	}
+	// 记录中只保留遮盖后的内容，与用户看到的一致
+	r.writeTranscript(Question{Message: message}, mask(answer))
+	return answer, nil
+}
+
+// maskedLoop 读取按键并在每次修改后重绘遮盖后的输入，直到回车
+// 退格删除最后一个字符（按rune计算，不会切开多字节字符），Ctrl-U清空输入
+func (r *Runner) maskedLoop(reader *bufio.Reader, out io.Writer, message string, mask func(string) string) (string, error) {
+	var typed []rune
+	prompt := fmt.Sprintf("%s %s: ", r.questionMark(), message)
+	draw := func() {
+		fmt.Fprintf(out, "\r%s%s%s", clearLine, prompt, mask(string(typed)))
+	}
+
+	draw()
+	for {
+		ev, err := terminal.ReadKey(reader)
+		if err != nil {
+			return "", err
+		}
+		if r.Recorder != nil {
+			r.Recorder.Record(ev)
+		}
+
+		switch {
+		case ev.Key == terminal.KeyEnter:
+			fmt.Fprint(out, "\r\n")
+			return string(typed), nil
+		case ev.IsCtrl('c'):
+			fmt.Fprint(out, "\r\n")
+			return "", ErrInterrupted
+		case ev.IsCtrl('u'):
+			typed = typed[:0]
+		case ev.Key == terminal.KeyBackspace:
+			if len(typed) > 0 {
+				typed = typed[:len(typed)-1]
+			}
+		case ev.Key == terminal.KeyRune && !ev.Ctrl:
+			typed = append(typed, ev.Rune)
+		default:
+			continue
+		}
+		draw()
+	}
+}
+
+// MaskExceptLast 返回只显示最后n个字符、其余字符显示为*的遮盖函数
+func MaskExceptLast(n int) func(string) string {
+	return func(typed string) string {
+		runes := []rune(typed)
+		hidden := len(runes) - n
+		if hidden <= 0 {
+			return typed
+		}
+		return strings.Repeat("*", hidden) + string(runes[hidden:])
+	}
+}
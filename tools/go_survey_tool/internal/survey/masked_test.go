@@ -0,0 +1,90 @@
+package survey
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMaskExceptLast(t *testing.T) {
+	tests := []struct {
+		typed    string
+		n        int
+		expected string
+	}{
+		{"", 4, ""},
+		{"123", 4, "123"},
+		{"5551234567", 4, "******4567"},
+		{"电话号码", 1, "***码"},
+		{"abc", 0, "***"},
+	}
+	for _, tt := range tests {
+		if got := MaskExceptLast(tt.n)(tt.typed); got != tt.expected {
+			t.Errorf("MaskExceptLast(%d)(%q) = %q, want %q", tt.n, tt.typed, got, tt.expected)
+		}
+	}
+}
+
+func TestMaskedLoop(t *testing.T) {
+	var shown []string
+	mask := func(typed string) string {
+		shown = append(shown, typed)
+		return strings.Repeat("#", len([]rune(typed)))
+	}
+
+	t.Run("Backspace and multibyte runes", func(t *testing.T) {
+		shown = nil
+		out := &bytes.Buffer{}
+		// 输入"12号"，退格删掉"号"，再输入"3"，回车
+		keys := "12号\x7f3\r"
+		value, err := NewRunner().maskedLoop(bufio.NewReader(strings.NewReader(keys)), out, "Phone", mask)
+		if err != nil {
+			t.Fatalf("maskedLoop() error = %v", err)
+		}
+		if value != "123" {
+			t.Errorf("maskedLoop() = %q, want the raw value 123", value)
+		}
+		expected := []string{"", "1", "12", "12号", "12", "123"}
+		if strings.Join(shown, "|") != strings.Join(expected, "|") {
+			t.Errorf("mask saw %q, want %q", shown, expected)
+		}
+		if strings.Contains(out.String(), "123") {
+			t.Errorf("raw value should not be echoed:\n%q", out.String())
+		}
+		if !strings.HasSuffix(out.String(), "###\r\n") {
+			t.Errorf("last frame should show the masked value:\n%q", out.String())
+		}
+	})
+
+	t.Run("Backspace on empty input", func(t *testing.T) {
+		value, err := NewRunner().maskedLoop(bufio.NewReader(strings.NewReader("\x7f\x7fa\r")), &bytes.Buffer{}, "X", mask)
+		if err != nil || value != "a" {
+			t.Errorf("maskedLoop() = %q, %v, want a", value, err)
+		}
+	})
+
+	t.Run("Ctrl-U clears", func(t *testing.T) {
+		value, err := NewRunner().maskedLoop(bufio.NewReader(strings.NewReader("abc\x15d\r")), &bytes.Buffer{}, "X", mask)
+		if err != nil || value != "d" {
+			t.Errorf("maskedLoop() = %q, %v, want d", value, err)
+		}
+	})
+
+	t.Run("Ctrl-C interrupts", func(t *testing.T) {
+		_, err := NewRunner().maskedLoop(bufio.NewReader(strings.NewReader("ab\x03")), &bytes.Buffer{}, "X", mask)
+		if !errors.Is(err, ErrInterrupted) {
+			t.Errorf("maskedLoop() error = %v, want ErrInterrupted", err)
+		}
+	})
+}
+
+func TestAskMaskedLineMode(t *testing.T) {
+	out := &bytes.Buffer{}
+	r := NewRunner(WithStdio(strings.NewReader("5551234567\n"), out, out))
+	value, err := r.AskMasked("Phone", MaskExceptLast(4))
+	if err != nil || value != "5551234567" {
+		t.Errorf("AskMasked() = %q, %v", value, err)
+	}
+}
@@ -0,0 +1,357 @@
+package survey
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// 自绘菜单使用的控制序列
+const (
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+	clearDown  = "\x1b[J"
+)
+
+// defaultPageSize 菜单一次显示的行数，与survey库的默认值一致
+const defaultPageSize = 7
+
+// menuItem 菜单中的一行，header为不可选的分组标题
+type menuItem struct {
+	label  string
+	header bool
+	index  int // 可选项在扁平列表中的序号，标题为-1
+}
+
+// menu 自绘选择菜单的状态
+// survey库的Select无法跳过不可选的行，需要分组等功能时使用这个菜单
+type menu struct {
+	items    []menuItem
+	cursor   int // 当前高亮项在items中的下标
+	offset   int // 可见窗口的起始下标
+	pageSize int
+	grouped  bool // 是否包含分组标题，决定选项的缩进
+	config   selectConfig
+	icon     string // 问题前缀，为空时使用Symbols中的问题图标
+
+	// pager 不为nil时按?用它显示全部选项，回放和测试中为nil，?被忽略
+	pager       func(content string) error
+	listPending bool // 按了?，等待menuLoop显示全部选项
+
+	// width 大于0时每行截断到这个终端宽度以内，WithReflowOnResize时使用
+	width int
+	// resize 不为nil时menuLoop在等待按键期间收到新的终端大小后立即重绘
+	resize <-chan terminal.Size
+	// pending 正在后台读取的按键，等待resize时按键在goroutine中读取
+	pending chan keyResult
+}
+
+// keyResult 后台读取一个按键的结果
+type keyResult struct {
+	ev  terminal.KeyEvent
+	err error
+}
+
+// newGroupedMenu 创建分组菜单，标题行不可选，标题为空的分组不显示标题行
+func newGroupedMenu(groups []Group) *menu {
+	m := &menu{pageSize: defaultPageSize}
+	index := 0
+	for _, group := range groups {
+		if group.Title != "" {
+			m.items = append(m.items, menuItem{label: group.Title, header: true, index: -1})
+			m.grouped = true
+		}
+		for _, option := range group.Options {
+			m.items = append(m.items, menuItem{label: option, index: index})
+			index++
+		}
+	}
+	m.cursor = -1
+	m.move(1)
+	return m
+}
+
+// selectable 返回可选项的数量
+func (m *menu) selectable() int {
+	n := 0
+	for _, item := range m.items {
+		if !item.header {
+			n++
+		}
+	}
+	return n
+}
+
+// move 把光标移动delta个可选项，跳过标题，到达两端时回绕（与survey一致）
+func (m *menu) move(delta int) {
+	if m.selectable() == 0 {
+		return
+	}
+	step := 1
+	if delta < 0 {
+		step, delta = -1, -delta
+	}
+	for ; delta > 0; delta-- {
+		for {
+			m.cursor = (m.cursor + step + len(m.items)) % len(m.items)
+			if !m.items[m.cursor].header {
+				break
+			}
+		}
+	}
+	m.scroll()
+}
+
+// scroll 调整可见窗口使光标可见，光标上方紧邻的标题也尽量保持可见
+func (m *menu) scroll() {
+	top := m.cursor
+	if top > 0 && m.items[top-1].header {
+		top--
+	}
+	if top < m.offset {
+		m.offset = top
+	}
+	if m.cursor >= m.offset+m.pageSize {
+		m.offset = m.cursor - m.pageSize + 1
+	}
+}
+
+// selected 返回当前选中项的扁平序号，没有可选项时返回-1
+func (m *menu) selected() int {
+	if m.cursor < 0 || m.cursor >= len(m.items) {
+		return -1
+	}
+	return m.items[m.cursor].index
+}
+
+// handleKey 处理一次按键，返回是否已确认选择
+func (m *menu) handleKey(ev terminal.KeyEvent) (done bool, err error) {
+	switch {
+	case ev.IsCtrl('c'):
+		return false, ErrInterrupted
+	case ev.Key == terminal.KeyEnter:
+		return m.selected() >= 0, nil
+	case ev.Key == terminal.KeyUp, ev.IsCtrl('p'):
+		m.move(-1)
+	case ev.Key == terminal.KeyDown, ev.IsCtrl('n'), ev.Key == terminal.KeyTab:
+		m.move(1)
+	case ev.Key == terminal.KeyRune && !ev.Ctrl && ev.Rune == '?':
+		m.listPending = m.pager != nil
+	}
+	return false, nil
+}
+
+// listText 返回全部选项的文本，分组标题单独成行，选项带有从1开始的序号
+func (m *menu) listText() string {
+	var b strings.Builder
+	indent := ""
+	if m.grouped {
+		indent = "  "
+	}
+	for _, item := range m.items {
+		if item.header {
+			fmt.Fprintf(&b, "%s\n", item.label)
+			continue
+		}
+		fmt.Fprintf(&b, "%s%d. %s\n", indent, item.index+1, item.label)
+	}
+	return b.String()
+}
+
+// render 返回菜单当前应显示的各行
+func (m *menu) render(message string) []string {
+	symbols := utils.CurrentSymbols()
+	hint := "Use arrows to move, enter to select"
+	defaults := []string{arrowsHint(), "enter select"}
+	if m.pager != nil && len(m.items) > m.pageSize {
+		hint += ", ? to list all"
+		defaults = append(defaults, "? list all")
+	}
+	icon := m.icon
+	if icon == "" {
+		icon = symbols.Question
+	}
+	hintLine := m.config.hintLine(m.width-1, defaults...)
+	header := fmt.Sprintf("%s %s  [%s]", icon, message, hint)
+	if m.config.hideFooter || hintLine != "" {
+		header = icon + " " + message
+	}
+	lines := []string{header}
+
+	end := m.offset + m.pageSize
+	if end > len(m.items) {
+		end = len(m.items)
+	}
+	indent := ""
+	if m.grouped {
+		indent = "  "
+	}
+	for i := m.offset; i < end; i++ {
+		item := m.items[i]
+		switch {
+		case item.header:
+			lines = append(lines, item.label)
+		case i == m.cursor:
+			lines = append(lines, indent+symbols.Selected+" "+item.label)
+		default:
+			lines = append(lines, indent+"  "+item.label)
+		}
+	}
+	if m.width > 0 {
+		// 留出最后一列，避免终端在行尾自动换行
+		for i, line := range lines {
+			lines[i] = utils.FitToWidth(line, m.width-1)
+		}
+	}
+	// 提示行在hintLine中按宽度截断后才着色，不再参与上面的截断
+	if hintLine != "" {
+		lines = append(lines, hintLine)
+	}
+	return lines
+}
+
+// nextKey 读取下一个按键；等待期间终端大小变化时调用onResize并返回ok=false，
+// 这时按键仍在后台读取，下次调用时继续等待同一个按键
+func (m *menu) nextKey(reader *bufio.Reader, onResize func(terminal.Size)) (ev terminal.KeyEvent, ok bool, err error) {
+	if m.resize == nil {
+		ev, err = terminal.ReadKey(reader)
+		return ev, true, err
+	}
+	if m.pending == nil {
+		m.pending = make(chan keyResult, 1)
+		go func(ch chan<- keyResult) {
+			ev, err := terminal.ReadKey(reader)
+			ch <- keyResult{ev, err}
+		}(m.pending)
+	}
+	select {
+	case res := <-m.pending:
+		m.pending = nil
+		return res.ev, true, res.err
+	case size, open := <-m.resize:
+		if !open {
+			m.resize = nil
+		} else {
+			onResize(size)
+		}
+		return ev, false, nil
+	}
+}
+
+// drawnRows 返回lines在宽度为width的终端上实际占用的行数。
+// 上次绘制的长行在终端变窄后会被折成多行（大多数终端在大小变化时重排已有内容），
+// 重绘时需要按新的宽度向上移动这么多行
+func drawnRows(lines []string, width int) int {
+	if width <= 0 {
+		return len(lines)
+	}
+	rows := 0
+	for _, line := range lines {
+		rows += max(1, (utils.DisplayWidth(line)+width-1)/width)
+	}
+	return rows
+}
+
+// runMenu 在raw模式下运行自绘菜单，返回选中项的扁平序号
+func (r *Runner) runMenu(message string, m *menu) (int, error) {
+	in := r.In.(*os.File)
+
+	fd := int(in.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return -1, fmt.Errorf("无法进入raw模式: %w", err)
+	}
+	defer term.Restore(fd, oldState)
+
+	if out, ok := r.Out.(*os.File); ok && m.config.reflow {
+		outFd := int(out.Fd())
+		sizes, stop := terminal.WatchResize(outFd)
+		defer stop()
+		m.resize = sizes
+		m.width, _ = terminal.SafeGetSize(outFd)
+	}
+	if out, ok := r.Out.(*os.File); ok {
+		// 分页程序需要正常的终端模式，显示期间临时退出raw模式
+		m.pager = func(content string) error {
+			term.Restore(fd, oldState)
+			defer term.MakeRaw(fd)
+			return utils.PageThroughTo(out, content)
+		}
+	}
+	return r.menuLoop(bufio.NewReader(r.input()), r.Out, message, m)
+}
+
+// menuLoop 读取按键并重绘菜单直到确认选择，不涉及终端模式，回放录制的按键时也使用它
+func (r *Runner) menuLoop(reader *bufio.Reader, out io.Writer, message string, m *menu) (int, error) {
+	fmt.Fprint(out, hideCursor)
+	defer fmt.Fprint(out, showCursor)
+
+	m.icon = r.questionMark()
+	if m.width == 0 {
+		m.width = r.Width
+	}
+	drawn := 0
+	var lines []string
+	for {
+		lines = m.render(message)
+		drawn = redraw(out, drawn, lines)
+
+		ev, ok, err := m.nextKey(reader, func(size terminal.Size) {
+			m.width = size.Width
+			drawn = drawnRows(lines, size.Width)
+		})
+		if err != nil {
+			return -1, err
+		}
+		if !ok {
+			continue
+		}
+		if r.Recorder != nil {
+			r.Recorder.Record(ev)
+		}
+		done, err := m.handleKey(ev)
+		if err != nil {
+			redraw(out, drawn, nil)
+			return -1, err
+		}
+		if m.listPending {
+			m.listPending = false
+			// 先清除菜单，分页程序退出后在原位置重新绘制
+			redraw(out, drawn, nil)
+			fmt.Fprint(out, showCursor)
+			if err := m.pager(m.listText()); err != nil {
+				r.log(LevelWarn, "pager failed", "error", err)
+			}
+			fmt.Fprint(out, hideCursor)
+			drawn = 0
+		}
+		if done {
+			item := m.items[m.cursor]
+			redraw(out, drawn, []string{fmt.Sprintf("%s %s %s", r.questionMark(), message, item.label)})
+			return item.index, nil
+		}
+	}
+}
+
+// redraw 清除上次绘制的drawn行并输出新内容，返回新绘制的行数
+// raw模式下换行不会回到行首，所以每行以\r\n结尾
+func redraw(out io.Writer, drawn int, lines []string) int {
+	var b strings.Builder
+	if drawn > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA\r", drawn)
+	}
+	b.WriteString(clearDown)
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\r\n")
+	}
+	fmt.Fprint(out, b.String())
+	return len(lines)
+}
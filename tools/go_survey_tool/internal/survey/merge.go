@@ -0,0 +1,96 @@
+package survey
+
+import (
+	"fmt"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// MergeAnswers 按顺序合并多个答案来源，后面的来源覆盖前面的
+// 值为nil、空白字符串或空列表时视为未提供，不会覆盖之前的值。
+// 典型用法：MergeAnswers(defaults, configFile, envOverrides)
+func MergeAnswers(sources ...map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, source := range sources {
+		for name, value := range source {
+			if isEmptyAnswer(value) {
+				continue
+			}
+			merged[name] = value
+		}
+	}
+	return merged
+}
+
+// isEmptyAnswer 判断答案是否为空
+func isEmptyAnswer(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return utils.IsEmpty(v)
+	case []string:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// knownAnswers 只保留questions中存在的问题的答案，并转换为各问题对应的答案类型
+// 有无法转换的答案时返回*QuestionError
+func knownAnswers(questions []Question, values map[string]interface{}) (map[string]interface{}, error) {
+	answers := make(map[string]interface{}, len(values))
+	for _, q := range questions {
+		if value, ok := values[q.Name]; ok {
+			answer, err := coerceAnswer(q, value)
+			if err != nil {
+				return nil, &QuestionError{Name: q.Name, Err: err}
+			}
+			answers[q.Name] = answer
+		}
+	}
+	return answers, nil
+}
+
+// coerceAnswer 将JSON解码或配置中得到的值转换为问题类型对应的答案类型
+// confirm接受布尔值和yes/no等字符串，select接受选项、别名或序号，两者都按parseLineAnswer校验；
+// multiselect接受逗号分隔的字符串或JSON数组，list接受逗号或空白分隔的字符串或JSON数组
+func coerceAnswer(q Question, value interface{}) (interface{}, error) {
+	switch q.kind() {
+	case TypeConfirm:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return parseLineAnswer(q, v)
+		}
+		return nil, fmt.Errorf("unexpected answer type %T", value)
+	case TypeSelect:
+		switch v := value.(type) {
+		case string:
+			return parseLineAnswer(q, v)
+		case float64, int:
+			// JSON或YAML中的数字按序号处理
+			return parseLineAnswer(q, fmt.Sprint(v))
+		}
+		return nil, fmt.Errorf("unexpected answer type %T", value)
+	case TypeMultiSelect, TypeList:
+		switch v := value.(type) {
+		case string:
+			if q.kind() == TypeList {
+				return utils.SplitList(v), nil
+			}
+			return splitDefault(v), nil
+		case []interface{}:
+			selected := make([]string, 0, len(v))
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					selected = append(selected, s)
+				}
+			}
+			return selected, nil
+		}
+	}
+	return value, nil
+}
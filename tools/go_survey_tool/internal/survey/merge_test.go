@@ -0,0 +1,93 @@
+package survey_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestMergeAnswersOverride(t *testing.T) {
+	defaults := map[string]interface{}{"name": "Anonymous", "color": "Red", "confirm": true}
+	config := map[string]interface{}{"color": "Blue"}
+	env := map[string]interface{}{"color": "Green", "confirm": false}
+
+	merged := survey.MergeAnswers(defaults, config, env)
+	expected := map[string]interface{}{"name": "Anonymous", "color": "Green", "confirm": false}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("MergeAnswers() = %v, want %v", merged, expected)
+	}
+}
+
+func TestMergeAnswersSkipsEmpty(t *testing.T) {
+	base := map[string]interface{}{"name": "Alice", "tags": []string{"a"}, "color": "Red"}
+	override := map[string]interface{}{
+		"name":  "   ",
+		"tags":  []string{},
+		"color": nil,
+		"extra": "",
+	}
+
+	merged := survey.MergeAnswers(base, override)
+	expected := map[string]interface{}{"name": "Alice", "tags": []string{"a"}, "color": "Red"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("MergeAnswers() = %v, want %v", merged, expected)
+	}
+}
+
+func TestMergeAnswersNoSources(t *testing.T) {
+	merged := survey.MergeAnswers()
+	if merged == nil || len(merged) != 0 {
+		t.Errorf("MergeAnswers() = %#v, want an empty map", merged)
+	}
+}
+
+func TestAskAllWithPresetSkipsQuestions(t *testing.T) {
+	runner, out := newLineRunner("Bob\n")
+	answers, err := runner.AskAll(survey.CreateSurveyQuestions(),
+		map[string]interface{}{"color": "Blue"},
+		map[string]interface{}{"color": "Green", "confirm": "no", "unknown": "ignored"},
+	)
+	if err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+
+	expected := map[string]interface{}{"name": "Bob", "color": "Green", "confirm": false}
+	if !reflect.DeepEqual(answers, expected) {
+		t.Errorf("AskAll() = %v, want %v", answers, expected)
+	}
+	if strings.Contains(out.String(), "Choose a color") || strings.Contains(out.String(), "Do you like Go?") {
+		t.Errorf("preset questions should not be prompted:\n%s", out.String())
+	}
+}
+
+func TestRunnerAskAllRejectsInvalidPresets(t *testing.T) {
+	questions := []survey.Question{
+		{Name: "enabled", Type: survey.TypeConfirm, Message: "Enabled?"},
+		{Name: "env", Type: survey.TypeSelect, Message: "Env?", Options: []string{"dev", "prod"}},
+	}
+	tests := []struct {
+		name    string
+		presets map[string]interface{}
+		want    string
+	}{
+		{"confirm", map[string]interface{}{"enabled": "maybe"}, "enabled"},
+		{"select", map[string]interface{}{"env": "staging"}, "env"},
+		{"type", map[string]interface{}{"enabled": 1.0}, "enabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, out := newLineRunner("")
+			_, err := runner.AskAll(questions, tt.presets)
+			var qerr *survey.QuestionError
+			if !errors.As(err, &qerr) || qerr.Name != tt.want {
+				t.Fatalf("AskAll() error = %v, want QuestionError for %q", err, tt.want)
+			}
+			if out.Len() != 0 {
+				t.Errorf("AskAll() asked a question before rejecting the preset: %q", out.String())
+			}
+		})
+	}
+}
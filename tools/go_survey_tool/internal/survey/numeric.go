@@ -0,0 +1,40 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// AskSelectNumeric 使用标准输入输出按编号询问单选
+func AskSelectNumeric(message string, options []string) (int, error) {
+	return NewRunner().AskSelectNumeric(message, options)
+}
+
+// AskSelectNumeric 显示带编号的选项列表并读取一个编号，返回选中项从0开始的序号。
+// 不使用方向键和raw模式，终端无法逐键读取时（见terminal.DiagnoseRawConflict）也能选择。
+// 输入的不是有效编号时提示错误并重新读取
+func (r *Runner) AskSelectNumeric(message string, options []string) (int, error) {
+	if len(options) == 0 {
+		return -1, errors.New("no options to select from")
+	}
+	fmt.Fprintf(r.Out, "%s %s\n", r.questionMark(), message)
+	fmt.Fprint(r.Out, utils.FormatOptionsAligned(options, nil))
+
+	for {
+		fmt.Fprintf(r.Out, "Enter a number (1-%d): ", len(options))
+		line, err := r.readLine()
+		if err != nil {
+			return -1, fmt.Errorf("选择失败: %w", err)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil || n < 1 || n > len(options) {
+			fmt.Fprintf(r.Out, "X please enter a number between 1 and %d\n", len(options))
+			continue
+		}
+		return n - 1, nil
+	}
+}
@@ -0,0 +1,54 @@
+package survey_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+var numericOptions = []string{"Red", "Blue", "Green"}
+
+func TestAskSelectNumeric(t *testing.T) {
+	var out bytes.Buffer
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader("3\n"), &out, &out))
+	index, err := r.AskSelectNumeric("Color?", numericOptions)
+	if err != nil {
+		t.Fatalf("AskSelectNumeric() error = %v", err)
+	}
+	if index != 2 {
+		t.Errorf("AskSelectNumeric() = %d, want 2", index)
+	}
+	if !strings.Contains(out.String(), utils.FormatOptionsAligned(numericOptions, nil)) {
+		t.Errorf("output %q should contain the numbered options", out.String())
+	}
+}
+
+func TestAskSelectNumericRetries(t *testing.T) {
+	var out bytes.Buffer
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader("blue\n0\n4\n 2 \n"), &out, &out))
+	index, err := r.AskSelectNumeric("Color?", numericOptions)
+	if err != nil {
+		t.Fatalf("AskSelectNumeric() error = %v", err)
+	}
+	if index != 1 {
+		t.Errorf("AskSelectNumeric() = %d, want 1", index)
+	}
+	if n := strings.Count(out.String(), "please enter a number between 1 and 3"); n != 3 {
+		t.Errorf("got %d retry messages, want 3:\n%s", n, out.String())
+	}
+}
+
+func TestAskSelectNumericErrors(t *testing.T) {
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader(""), io.Discard, io.Discard))
+	if _, err := r.AskSelectNumeric("Color?", numericOptions); !errors.Is(err, io.EOF) {
+		t.Errorf("AskSelectNumeric() at end of input error = %v, want io.EOF", err)
+	}
+	if _, err := r.AskSelectNumeric("Color?", nil); err == nil {
+		t.Error("AskSelectNumeric() without options should return an error")
+	}
+}
@@ -0,0 +1,64 @@
+package survey
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// OutputOption 结果输出（WriteResultJSON、WriteResultEnv、WriteResultsCSV和WriteResultTOML）的选项
+type OutputOption func(*outputConfig)
+
+// outputConfig 结果输出的配置
+type outputConfig struct {
+	bools utils.BoolFormat
+}
+
+// WithBoolFormat 设置confirm等布尔答案的写法，默认为utils.BoolTrueFalse，
+// 即JSON和TOML中的原生布尔值、文本格式中的true/false
+func WithBoolFormat(f utils.BoolFormat) OutputOption {
+	return func(c *outputConfig) {
+		c.bools = f
+	}
+}
+
+func newOutputConfig(opts []OutputOption) outputConfig {
+	var cfg outputConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WriteResultJSON 把答案写为以问题名为键的JSON对象，格式与WithAnswerRecord相同
+func WriteResultJSON(w io.Writer, r Result, opts ...OutputOption) error {
+	cfg := newOutputConfig(opts)
+	answers := make(map[string]interface{}, len(r.Answers))
+	for name, value := range r.Answers {
+		answers[name] = cfg.bools.Apply(value)
+	}
+	data, err := json.MarshalIndent(answers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化结果失败: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("写入JSON失败: %w", err)
+	}
+	return nil
+}
+
+// WriteResultEnv 按Result.Ordered的顺序把答案写为export语句，每行一个，见utils.ExportLine
+func WriteResultEnv(w io.Writer, r Result, opts ...OutputOption) error {
+	cfg := newOutputConfig(opts)
+	bw := bufio.NewWriter(w)
+	for _, answer := range r.Ordered() {
+		fmt.Fprintln(bw, utils.ExportLine(answer.Name, cfg.bools.Apply(answer.Value)))
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("写入环境变量失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package survey_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+func TestBoolFormatAcrossOutputs(t *testing.T) {
+	res := survey.Result{
+		Answers: map[string]interface{}{"name": "Alice", "like": true, "spam": false},
+		Order:   []string{"name", "like", "spam"},
+	}
+	write := map[string]func(*bytes.Buffer, ...survey.OutputOption) error{
+		"json": func(b *bytes.Buffer, opts ...survey.OutputOption) error {
+			return survey.WriteResultJSON(b, res, opts...)
+		},
+		"csv": func(b *bytes.Buffer, opts ...survey.OutputOption) error {
+			return survey.WriteResultsCSV(b, []survey.Result{res}, nil, opts...)
+		},
+		"env": func(b *bytes.Buffer, opts ...survey.OutputOption) error {
+			return survey.WriteResultEnv(b, res, opts...)
+		},
+		"toml": func(b *bytes.Buffer, opts ...survey.OutputOption) error {
+			return survey.WriteResultTOML(b, res, opts...)
+		},
+	}
+
+	tests := []struct {
+		format utils.BoolFormat
+		want   map[string][]string
+	}{
+		{utils.BoolTrueFalse, map[string][]string{
+			"json": {`"like": true`, `"spam": false`},
+			"csv":  {"name,like,spam\nAlice,true,false\n"},
+			"env":  {"export LIKE='true'", "export SPAM='false'"},
+			"toml": {"like = true\n", "spam = false\n"},
+		}},
+		{utils.BoolYesNo, map[string][]string{
+			"json": {`"like": "yes"`, `"spam": "no"`},
+			"csv":  {"name,like,spam\nAlice,yes,no\n"},
+			"env":  {"export LIKE='yes'", "export SPAM='no'"},
+			"toml": {"like = \"yes\"\n", "spam = \"no\"\n"},
+		}},
+		{utils.BoolOneZero, map[string][]string{
+			"json": {`"like": 1`, `"spam": 0`},
+			"csv":  {"name,like,spam\nAlice,1,0\n"},
+			"env":  {"export LIKE='1'", "export SPAM='0'"},
+			"toml": {"like = 1\n", "spam = 0\n"},
+		}},
+	}
+	for _, tt := range tests {
+		for name, fn := range write {
+			var buf bytes.Buffer
+			if err := fn(&buf, survey.WithBoolFormat(tt.format)); err != nil {
+				t.Fatalf("%s with format %d: error = %v", name, tt.format, err)
+			}
+			for _, want := range tt.want[name] {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("%s with format %d: output missing %q:\n%s", name, tt.format, want, buf.String())
+				}
+			}
+		}
+	}
+}
+
+func TestWriteResultEnvOrder(t *testing.T) {
+	res := survey.Result{
+		Answers: map[string]interface{}{"color": "light blue", "tags": []string{"a", "b"}},
+		Order:   []string{"tags", "color"},
+	}
+	var buf bytes.Buffer
+	if err := survey.WriteResultEnv(&buf, res); err != nil {
+		t.Fatal(err)
+	}
+	if want := "export TAGS='a b'\nexport COLOR='light blue'\n"; buf.String() != want {
+		t.Errorf("WriteResultEnv() = %q, want %q", buf.String(), want)
+	}
+}
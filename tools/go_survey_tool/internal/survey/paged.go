@@ -0,0 +1,407 @@
+package survey
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// PageFunc 按需读取选项的回调：返回从offset开始的最多limit个选项，以及选项的总数
+type PageFunc func(offset, limit int) ([]string, int, error)
+
+// loadingLabel 选项所在的页尚未读取时显示的占位文本
+const loadingLabel = "Loading..."
+
+// AskSelectPaged 使用标准输入输出询问分页读取的单选，见Runner.AskSelectPaged
+func AskSelectPaged(message string, page PageFunc, opts ...SelectOption) (string, error) {
+	return NewRunner().AskSelectPaged(message, page, opts...)
+}
+
+// AskSelectPaged 询问单选，选项不一次性读入，而是在滚动到时通过page按页读取，
+// 适合选项很多或来自远程的场景。读取期间对应的行显示"Loading..."。
+// 输入文字只在已经读取的选项中过滤，不会重新调用page查询；
+// 过滤期间不再读取新的页，清空过滤文字后恢复按页浏览
+func (r *Runner) AskSelectPaged(message string, page PageFunc, opts ...SelectOption) (string, error) {
+	src := &pagedSource{fetch: page, limit: defaultPageSize, pages: map[int][]string{}}
+	if _, err := src.page(0); err != nil {
+		return "", fmt.Errorf("读取选项失败: %w", err)
+	}
+	if src.total == 0 {
+		return "", errors.New("no options to select from")
+	}
+
+	var value string
+	var err error
+	if r.interactive() {
+		err = WithTerminalMode(func() error {
+			m := newPagedMenu(src, newSelectConfig(opts))
+			if err := r.waitForSize(m.config); err != nil {
+				return err
+			}
+			value, err = r.runPaged(message, m)
+			return err
+		})
+	} else {
+		value, err = r.askPagedLine(message, src)
+	}
+	if err != nil {
+		return "", fmt.Errorf("选择失败: %w", err)
+	}
+	r.writeTranscript(Question{Type: TypeSelect, Message: message}, value)
+	return value, nil
+}
+
+// pagedSource 缓存已经读取的页，每页limit个选项，按页的起始位置保存
+type pagedSource struct {
+	fetch PageFunc
+	limit int
+	total int // 最近一次读取时回调返回的总数
+	pages map[int][]string
+}
+
+// page 返回包含第i个选项的页及其起始位置，尚未读取时调用fetch
+func (s *pagedSource) page(i int) (int, error) {
+	start := i / s.limit * s.limit
+	if _, ok := s.pages[start]; ok {
+		return start, nil
+	}
+	options, total, err := s.fetch(start, s.limit)
+	if err != nil {
+		return start, err
+	}
+	if len(options) > s.limit {
+		options = options[:s.limit]
+	}
+	s.pages[start] = options
+	s.total = total
+	return start, nil
+}
+
+// loaded 判断第i个选项所在的页是否已经读取
+func (s *pagedSource) loaded(i int) bool {
+	_, ok := s.pages[i/s.limit*s.limit]
+	return ok
+}
+
+// option 返回已读取的第i个选项，尚未读取或超出回调返回的页时ok为false
+func (s *pagedSource) option(i int) (string, bool) {
+	return utils.OptionAt(s.pages[i/s.limit*s.limit], i%s.limit)
+}
+
+// loadedIndices 按顺序返回所有已读取选项的位置
+func (s *pagedSource) loadedIndices() []int {
+	starts := make([]int, 0, len(s.pages))
+	for start := range s.pages {
+		starts = append(starts, start)
+	}
+	sort.Ints(starts)
+	var indices []int
+	for _, start := range starts {
+		for i := range s.pages[start] {
+			indices = append(indices, start+i)
+		}
+	}
+	return indices
+}
+
+// pagedMenu 分页读取的自绘选择菜单的状态
+type pagedMenu struct {
+	src      *pagedSource
+	cursor   int // 未过滤时为选项的位置，过滤时为matches中的下标
+	offset   int // 可见窗口的起始位置，含义同cursor
+	pageSize int
+	config   selectConfig
+	icon     string
+	width    int
+
+	filter  string
+	matches []int // 过滤时匹配的已读取选项的位置
+	before  int   // 开始过滤前光标所在的位置
+}
+
+// count 返回可以移动到的行数
+func (m *pagedMenu) count() int {
+	if m.filter != "" {
+		return len(m.matches)
+	}
+	return m.src.total
+}
+
+// position 返回第row行对应的选项位置
+func (m *pagedMenu) position(row int) int {
+	if m.filter != "" {
+		return m.matches[row]
+	}
+	return row
+}
+
+// window 返回可见窗口的结束位置（不含）
+func (m *pagedMenu) window() int {
+	return min(m.offset+m.pageSize, m.count())
+}
+
+// missing 返回可见窗口中尚未读取的选项位置，每页只返回一个
+func (m *pagedMenu) missing() []int {
+	if m.filter != "" {
+		return nil
+	}
+	var missing []int
+	for i := m.offset; i < m.window(); i++ {
+		if !m.src.loaded(i) && (len(missing) == 0 || missing[len(missing)-1]/m.src.limit != i/m.src.limit) {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+// move 把光标移动delta行，到达两端时回绕（与survey一致），并调整可见窗口使光标可见
+func (m *pagedMenu) move(delta int) {
+	n := m.count()
+	if n == 0 {
+		return
+	}
+	m.cursor = ((m.cursor+delta)%n + n) % n
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.pageSize {
+		m.offset = m.cursor - m.pageSize + 1
+	}
+}
+
+// newPagedMenu 返回从第一个选项开始的菜单，设置了WithInitialFilter时先按它过滤
+func newPagedMenu(src *pagedSource, cfg selectConfig) *pagedMenu {
+	m := &pagedMenu{src: src, pageSize: defaultPageSize, config: cfg}
+	if cfg.initialFilter != "" {
+		m.setFilter(cfg.initialFilter)
+	}
+	return m
+}
+
+// setFilter 修改过滤文字，在已读取的选项中重新匹配（不区分大小写）。
+// 清空过滤文字时光标回到过滤中选中的选项，没有选中时回到开始过滤前的位置
+func (m *pagedMenu) setFilter(filter string) {
+	if m.filter == "" {
+		m.before = m.cursor
+	}
+	selected, ok := m.selected()
+	if !ok {
+		selected = m.before
+	}
+	m.filter = filter
+	m.matches = nil
+	m.cursor, m.offset = 0, 0
+	if filter == "" {
+		m.move(selected)
+		return
+	}
+	needle := strings.ToLower(filter)
+	for _, i := range m.src.loadedIndices() {
+		if option, _ := m.src.option(i); strings.Contains(strings.ToLower(option), needle) {
+			m.matches = append(m.matches, i)
+		}
+	}
+}
+
+// selected 返回当前选中的选项位置；没有匹配项或所在页尚未读取时ok为false
+func (m *pagedMenu) selected() (int, bool) {
+	if m.cursor >= m.count() {
+		return -1, false
+	}
+	i := m.position(m.cursor)
+	_, ok := m.src.option(i)
+	return i, ok
+}
+
+// handleKey 处理一次按键，返回是否已确认选择
+func (m *pagedMenu) handleKey(ev terminal.KeyEvent) (done bool, err error) {
+	switch {
+	case ev.IsCtrl('c'):
+		return false, ErrInterrupted
+	case ev.Key == terminal.KeyEnter:
+		_, ok := m.selected()
+		return ok, nil
+	case ev.Key == terminal.KeyUp, ev.IsCtrl('p'):
+		m.move(-1)
+	case ev.Key == terminal.KeyDown, ev.IsCtrl('n'), ev.Key == terminal.KeyTab:
+		m.move(1)
+	case ev.Key == terminal.KeyPageUp:
+		m.move(-min(m.pageSize, m.cursor))
+	case ev.Key == terminal.KeyPageDown:
+		m.move(min(m.pageSize, m.count()-1-m.cursor))
+	case ev.Key == terminal.KeyHome:
+		m.move(-m.cursor)
+	case ev.Key == terminal.KeyEnd:
+		m.move(m.count() - 1 - m.cursor)
+	case ev.Key == terminal.KeyBackspace:
+		if m.filter != "" {
+			runes := []rune(m.filter)
+			m.setFilter(string(runes[:len(runes)-1]))
+		}
+	case ev.Key == terminal.KeyRune && !ev.Ctrl:
+		m.setFilter(m.filter + string(ev.Rune))
+	}
+	return false, nil
+}
+
+// render 返回菜单当前应显示的各行，尚未读取的选项显示为"Loading..."，
+// 最后一行为可见范围和总数
+func (m *pagedMenu) render(message string) []string {
+	symbols := utils.CurrentSymbols()
+	icon := m.icon
+	if icon == "" {
+		icon = symbols.Question
+	}
+	hintLine := m.config.hintLine(m.width-1, arrowsHint(), "enter select", "type to filter")
+	header := fmt.Sprintf("%s %s  [Use arrows to move, type to filter]", icon, message)
+	switch {
+	case m.filter != "":
+		header = fmt.Sprintf("%s %s %s", icon, message, m.filter)
+	case m.config.hideFooter || hintLine != "":
+		header = icon + " " + message
+	}
+	lines := []string{header}
+
+	for row := m.offset; row < m.window(); row++ {
+		label, ok := m.src.option(m.position(row))
+		if !ok {
+			label = loadingLabel
+		}
+		if row == m.cursor {
+			lines = append(lines, symbols.Selected+" "+label)
+		} else {
+			lines = append(lines, "  "+label)
+		}
+	}
+	if m.filter != "" {
+		lines = append(lines, fmt.Sprintf("  (%d matches in %d loaded of %d)", len(m.matches), len(m.src.loadedIndices()), m.src.total))
+	} else {
+		lines = append(lines, fmt.Sprintf("  (%d-%d of %d)", m.offset+1, m.window(), m.src.total))
+	}
+	if m.width > 0 {
+		// 留出最后一列，避免终端在行尾自动换行
+		for i, line := range lines {
+			lines[i] = utils.FitToWidth(line, m.width-1)
+		}
+	}
+	if hintLine != "" {
+		lines = append(lines, hintLine)
+	}
+	return lines
+}
+
+// runPaged 在raw模式下运行分页菜单，返回选中的选项
+func (r *Runner) runPaged(message string, m *pagedMenu) (string, error) {
+	fd := int(r.In.(*os.File).Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", fmt.Errorf("无法进入raw模式: %w", err)
+	}
+	defer term.Restore(fd, oldState)
+	return r.pagedLoop(bufio.NewReader(r.input()), r.Out, message, m)
+}
+
+// pagedLoop 读取按键并重绘分页菜单直到确认选择。
+// 可见窗口中有尚未读取的页时，先绘制"Loading..."占位，读取后再重绘
+func (r *Runner) pagedLoop(reader *bufio.Reader, out io.Writer, message string, m *pagedMenu) (string, error) {
+	fmt.Fprint(out, hideCursor)
+	defer fmt.Fprint(out, showCursor)
+
+	m.icon = r.questionMark()
+	if m.width == 0 {
+		m.width = r.Width
+	}
+	drawn := 0
+	for {
+		drawn = redraw(out, drawn, m.render(message))
+		if missing := m.missing(); len(missing) > 0 {
+			for _, i := range missing {
+				if _, err := m.src.page(i); err != nil {
+					redraw(out, drawn, nil)
+					return "", fmt.Errorf("读取选项失败: %w", err)
+				}
+			}
+			// 总数可能在读取期间变化
+			if m.cursor >= m.count() {
+				m.move(0)
+			}
+			continue
+		}
+
+		ev, err := terminal.ReadKey(reader)
+		if err != nil {
+			return "", err
+		}
+		if r.Recorder != nil {
+			r.Recorder.Record(ev)
+		}
+		done, err := m.handleKey(ev)
+		if err != nil {
+			redraw(out, drawn, nil)
+			return "", err
+		}
+		if done {
+			i, _ := m.selected()
+			value, _ := m.src.option(i)
+			redraw(out, drawn, []string{fmt.Sprintf("%s %s %s", r.questionMark(), message, value)})
+			return value, nil
+		}
+	}
+}
+
+// askPagedLine 简单模式下的分页选择：每次输出一页带编号的选项，
+// 输入n或p翻页，输入编号（对所有选项从1开始）或本页的选项文本选择
+func (r *Runner) askPagedLine(message string, src *pagedSource) (string, error) {
+	start := 0
+	for {
+		if _, err := src.page(start); err != nil {
+			return "", fmt.Errorf("读取选项失败: %w", err)
+		}
+		options := src.pages[start]
+		fmt.Fprintln(r.Out, r.fitLine(fmt.Sprintf("%s %s", r.questionMark(), message)))
+		for i, option := range options {
+			fmt.Fprintln(r.Out, r.fitLine(fmt.Sprintf("  %d. %s", start+i+1, option)))
+		}
+		fmt.Fprintf(r.Out, "  (%d-%d of %d)\n", start+1, start+len(options), src.total)
+		fmt.Fprint(r.Out, "Enter a number, n for next page, p for previous: ")
+
+		line, err := r.readLine()
+		if err != nil {
+			return "", err
+		}
+		token := strings.TrimSpace(line)
+		switch strings.ToLower(token) {
+		case "n":
+			if start+src.limit < src.total {
+				start += src.limit
+			}
+			continue
+		case "p":
+			start = max(0, start-src.limit)
+			continue
+		}
+		if n, err := strconv.Atoi(token); err == nil && n >= 1 && n <= src.total {
+			pageStart, err := src.page(n - 1)
+			if err != nil {
+				return "", fmt.Errorf("读取选项失败: %w", err)
+			}
+			if option, ok := src.option(n - 1); ok {
+				return option, nil
+			}
+			start = pageStart
+		} else if i, ok := utils.OptionIndex(options, token); ok {
+			return options[i], nil
+		}
+		fmt.Fprintf(r.Out, "X invalid choice %q\n", token)
+	}
+}
@@ -0,0 +1,192 @@
+package survey
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// memoryPages 内存中的分页数据源，记录每次读取的offset
+type memoryPages struct {
+	total   int
+	offsets []int
+	err     error // 不为nil时第二次读取返回这个错误
+}
+
+func (p *memoryPages) fetch(offset, limit int) ([]string, int, error) {
+	p.offsets = append(p.offsets, offset)
+	if p.err != nil && len(p.offsets) > 1 {
+		return nil, 0, p.err
+	}
+	var options []string
+	for i := offset; i < offset+limit && i < p.total; i++ {
+		options = append(options, fmt.Sprintf("Option %d", i+1))
+	}
+	return options, p.total, nil
+}
+
+// runPagedKeys 用keys作为按键运行分页菜单
+func runPagedKeys(t *testing.T, src *memoryPages, keys string, opts ...SelectOption) (string, string, error) {
+	t.Helper()
+	r, out := NewTestRunner(keys, 0)
+	source := &pagedSource{fetch: src.fetch, limit: defaultPageSize, pages: map[int][]string{}}
+	if _, err := source.page(0); err != nil {
+		t.Fatal(err)
+	}
+	m := newPagedMenu(source, newSelectConfig(opts))
+	value, err := r.pagedLoop(bufio.NewReader(r.In), out, "Pick one:", m)
+	return value, out.String(), err
+}
+
+func TestPagedLoopLoadsPagesOnScroll(t *testing.T) {
+	src := &memoryPages{total: 100000}
+	value, out, err := runPagedKeys(t, src, strings.Repeat("\x1b[B", 8)+"\r")
+	if err != nil {
+		t.Fatalf("pagedLoop() error = %v", err)
+	}
+	if value != "Option 9" {
+		t.Errorf("pagedLoop() = %q, want Option 9", value)
+	}
+	if fmt.Sprint(src.offsets) != "[0 7]" {
+		t.Errorf("fetched offsets %v, want only the first two pages", src.offsets)
+	}
+	if !strings.Contains(out, loadingLabel) || !strings.Contains(out, "(3-9 of 100000)") {
+		t.Errorf("output should show the loading placeholder and the position:\n%q", out)
+	}
+}
+
+func TestPagedLoopWrapsToLastPage(t *testing.T) {
+	src := &memoryPages{total: 100000}
+	value, _, err := runPagedKeys(t, src, "\x1b[A\r")
+	if err != nil {
+		t.Fatalf("pagedLoop() error = %v", err)
+	}
+	if value != "Option 100000" {
+		t.Errorf("pagedLoop() = %q, want the last option", value)
+	}
+	// 可见窗口为最后7个选项，跨越最后两页
+	if fmt.Sprint(src.offsets) != "[0 99988 99995]" {
+		t.Errorf("fetched offsets %v, want the first page and the last two", src.offsets)
+	}
+}
+
+func TestPagedLoopFiltersLoadedOptions(t *testing.T) {
+	src := &memoryPages{total: 100000}
+	// 只有第一页已读取，"5"只匹配Option 5，不会重新查询
+	value, out, err := runPagedKeys(t, src, "5\r")
+	if err != nil {
+		t.Fatalf("pagedLoop() error = %v", err)
+	}
+	if value != "Option 5" {
+		t.Errorf("pagedLoop() = %q, want Option 5", value)
+	}
+	if len(src.offsets) != 1 {
+		t.Errorf("filtering fetched %v, want no new pages", src.offsets)
+	}
+	if !strings.Contains(out, "(1 matches in 7 loaded of 100000)") {
+		t.Errorf("output should show the filter result:\n%q", out)
+	}
+}
+
+func TestPagedLoopFilterWithoutMatches(t *testing.T) {
+	src := &memoryPages{total: 20}
+	// 没有匹配时回车无效，退格清空过滤后恢复原来的光标
+	value, _, err := runPagedKeys(t, src, "\x1b[Bx\r\x7f\r")
+	if err != nil {
+		t.Fatalf("pagedLoop() error = %v", err)
+	}
+	if value != "Option 2" {
+		t.Errorf("pagedLoop() = %q, want Option 2", value)
+	}
+}
+
+func TestPagedLoopInitialFilter(t *testing.T) {
+	src := &memoryPages{total: 100000}
+	// 没有任何按键时列表已经只剩匹配项
+	value, out, err := runPagedKeys(t, src, "\r", WithInitialFilter("3"))
+	if err != nil {
+		t.Fatalf("pagedLoop() error = %v", err)
+	}
+	if value != "Option 3" {
+		t.Errorf("pagedLoop() = %q, want Option 3", value)
+	}
+	if strings.Contains(out, "Option 1") || !strings.Contains(out, "Pick one: 3") || !strings.Contains(out, "(1 matches in 7 loaded of 100000)") {
+		t.Errorf("the first frame should already be filtered:\n%q", out)
+	}
+
+	// 退格照常修改过滤文字，清空后光标留在过滤中选中的选项
+	value, out, err = runPagedKeys(t, &memoryPages{total: 100000}, "\x7f\x1b[B\r", WithInitialFilter("3"))
+	if err != nil {
+		t.Fatalf("pagedLoop() error = %v", err)
+	}
+	if value != "Option 4" {
+		t.Errorf("pagedLoop() after backspace = %q, want Option 4", value)
+	}
+	if !strings.Contains(out, "Option 1") {
+		t.Errorf("clearing the filter should show all options again:\n%q", out)
+	}
+}
+
+func TestWithInitialFilterDropsControlCharacters(t *testing.T) {
+	if cfg := newSelectConfig([]SelectOption{WithInitialFilter("ab\r\x1bc")}); cfg.initialFilter != "abc" {
+		t.Errorf("initialFilter = %q, want control characters removed", cfg.initialFilter)
+	}
+}
+
+func TestPagedLoopFetchError(t *testing.T) {
+	src := &memoryPages{total: 100, err: errors.New("backend down")}
+	_, _, err := runPagedKeys(t, src, "\x1b[6~\r")
+	if err == nil || !strings.Contains(err.Error(), "backend down") {
+		t.Errorf("pagedLoop() error = %v, want the fetch error", err)
+	}
+}
+
+func TestAskSelectPagedLine(t *testing.T) {
+	src := &memoryPages{total: 100000}
+	r, out := NewTestRunner("n\nbogus\nOption 10\n", 0)
+	value, err := r.AskSelectPaged("Pick one:", src.fetch)
+	if err != nil {
+		t.Fatalf("AskSelectPaged() error = %v", err)
+	}
+	if value != "Option 10" {
+		t.Errorf("AskSelectPaged() = %q, want Option 10", value)
+	}
+	if fmt.Sprint(src.offsets) != "[0 7]" {
+		t.Errorf("fetched offsets %v, want only the pages shown", src.offsets)
+	}
+	for _, want := range []string{"  1. Option 1\n", "  8. Option 8\n", "(8-14 of 100000)", `X invalid choice "bogus"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestAskSelectPagedLineByNumber(t *testing.T) {
+	src := &memoryPages{total: 100000}
+	r, _ := NewTestRunner("p\n50000\n", 0)
+	value, err := r.AskSelectPaged("Pick one:", src.fetch)
+	if err != nil {
+		t.Fatalf("AskSelectPaged() error = %v", err)
+	}
+	if value != "Option 50000" {
+		t.Errorf("AskSelectPaged() = %q, want Option 50000", value)
+	}
+	if len(src.offsets) != 2 {
+		t.Errorf("fetched offsets %v, want the first page and the one holding the number", src.offsets)
+	}
+}
+
+func TestAskSelectPagedErrors(t *testing.T) {
+	r, _ := NewTestRunner("1\n", 0)
+	if _, err := r.AskSelectPaged("Pick one:", (&memoryPages{}).fetch); err == nil {
+		t.Error("AskSelectPaged() with no options should fail")
+	}
+	failing := func(offset, limit int) ([]string, int, error) {
+		return nil, 0, errors.New("backend down")
+	}
+	if _, err := r.AskSelectPaged("Pick one:", failing); err == nil || !strings.Contains(err.Error(), "backend down") {
+		t.Errorf("AskSelectPaged() error = %v, want the fetch error", err)
+	}
+}
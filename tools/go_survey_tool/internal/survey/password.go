@@ -0,0 +1,46 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPasswordMismatch 两次输入的密码不一致
+var ErrPasswordMismatch = errors.New("passwords do not match")
+
+// AskPassword 询问密码，输入内容不回显
+func AskPassword(message string) (string, error) {
+	return NewRunner().AskPassword(message)
+}
+
+// AskPassword 询问密码，输入内容不回显
+func (r *Runner) AskPassword(message string) (string, error) {
+	password, err := r.Ask(Question{Type: TypePassword, Message: message})
+	if err != nil {
+		return "", fmt.Errorf("密码输入失败: %w", err)
+	}
+	return password.(string), nil
+}
+
+// AskPasswordConfirmed 询问密码并要求再次输入确认
+// validate用于验证第一次输入（例如utils.ValidatePasswordStrength），为nil时不验证
+func AskPasswordConfirmed(message string, validate func(string) error) (string, error) {
+	return NewRunner().AskPasswordConfirmed(message, validate)
+}
+
+// AskPasswordConfirmed 询问密码并要求再次输入确认
+func (r *Runner) AskPasswordConfirmed(message string, validate func(string) error) (string, error) {
+	password, err := r.Ask(Question{Type: TypePassword, Message: message, Validate: validate})
+	if err != nil {
+		return "", fmt.Errorf("密码输入失败: %w", err)
+	}
+	confirm, err := r.Ask(Question{Type: TypePassword, Message: "Confirm password"})
+	if err != nil {
+		return "", fmt.Errorf("密码输入失败: %w", err)
+	}
+
+	if password != confirm {
+		return "", ErrPasswordMismatch
+	}
+	return password.(string), nil
+}
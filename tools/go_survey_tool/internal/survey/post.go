@@ -0,0 +1,95 @@
+package survey
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// postConfig PostResults的配置
+type postConfig struct {
+	timeout time.Duration // 单次请求的超时
+	retries int           // 失败后的重试次数
+	backoff time.Duration // 第一次重试前的等待，之后每次加倍
+}
+
+// PostOption PostResults的选项
+type PostOption func(*postConfig)
+
+// WithPostTimeout 设置单次请求的超时，默认10秒
+func WithPostTimeout(d time.Duration) PostOption {
+	return func(c *postConfig) {
+		c.timeout = d
+	}
+}
+
+// WithPostRetries 设置失败后的重试次数和第一次重试前的等待时间，默认重试2次、等待500毫秒
+func WithPostRetries(retries int, backoff time.Duration) PostOption {
+	return func(c *postConfig) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// postPayload 提交的JSON内容
+type postPayload struct {
+	Answers       map[string]interface{} `json:"answers"`
+	DurationMS    int64                  `json:"duration_ms"`
+	QuestionCount int                    `json:"question_count"`
+}
+
+// maxBodySnippet 错误信息中最多包含的响应内容长度
+const maxBodySnippet = 200
+
+// PostResults 把结果编码为JSON后POST到url
+// 网络错误和5xx响应会按指数退避重试；其他非2xx响应直接返回错误，错误信息中带有响应内容的开头部分
+func PostResults(url string, r Result, opts ...PostOption) error {
+	cfg := postConfig{timeout: 10 * time.Second, retries: 2, backoff: 500 * time.Millisecond}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	body, err := json.Marshal(postPayload{
+		Answers:       r.Answers,
+		DurationMS:    r.Duration.Milliseconds(),
+		QuestionCount: r.QuestionCount,
+	})
+	if err != nil {
+		return fmt.Errorf("序列化结果失败: %w", err)
+	}
+
+	client := &http.Client{Timeout: cfg.timeout}
+	wait := cfg.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := postOnce(client, url, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= cfg.retries {
+			return fmt.Errorf("提交结果失败: %w", err)
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// postOnce 发送一次请求，返回错误以及是否值得重试
+func postOnce(client *http.Client, url string, body []byte) (bool, error) {
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodySnippet))
+	err = fmt.Errorf("server returned %s: %s", resp.Status, strings.TrimSpace(string(snippet)))
+	return resp.StatusCode >= 500, err
+}
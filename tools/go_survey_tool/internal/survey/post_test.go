@@ -0,0 +1,115 @@
+package survey_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+var postResult = survey.Result{
+	Answers:       map[string]interface{}{"name": "Alice", "tags": []string{"a", "b"}},
+	Duration:      1500 * time.Millisecond,
+	QuestionCount: 2,
+}
+
+func TestPostResults(t *testing.T) {
+	var got map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("invalid JSON body: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	if err := survey.PostResults(server.URL, postResult); err != nil {
+		t.Fatalf("PostResults() error = %v", err)
+	}
+	expected := map[string]interface{}{
+		"answers":        map[string]interface{}{"name": "Alice", "tags": []interface{}{"a", "b"}},
+		"duration_ms":    float64(1500),
+		"question_count": float64(2),
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("posted %v, want %v", got, expected)
+	}
+}
+
+func TestPostResultsRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			http.Error(w, "try later", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	if err := survey.PostResults(server.URL, postResult, survey.WithPostRetries(2, time.Millisecond)); err != nil {
+		t.Fatalf("PostResults() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("server called %d times, want 3", calls)
+	}
+}
+
+func TestPostResultsErrors(t *testing.T) {
+	t.Run("Client error is not retried", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			http.Error(w, "missing field: name", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		err := survey.PostResults(server.URL, postResult, survey.WithPostRetries(2, time.Millisecond))
+		if err == nil || !strings.Contains(err.Error(), "400 Bad Request: missing field: name") {
+			t.Errorf("PostResults() error = %v, want the status and body snippet", err)
+		}
+		if calls != 1 {
+			t.Errorf("server called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("Gives up after retries", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			http.Error(w, strings.Repeat("x", 1000), http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		err := survey.PostResults(server.URL, postResult, survey.WithPostRetries(1, time.Millisecond))
+		if err == nil || !strings.Contains(err.Error(), "500") {
+			t.Fatalf("PostResults() error = %v, want a 500 error", err)
+		}
+		if len(err.Error()) > 300 {
+			t.Errorf("error should only include a snippet of the body, got %d bytes", len(err.Error()))
+		}
+		if calls != 2 {
+			t.Errorf("server called %d times, want 2", calls)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		done := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-done
+		}))
+		defer server.Close()
+		defer close(done)
+
+		err := survey.PostResults(server.URL, postResult, survey.WithPostTimeout(20*time.Millisecond), survey.WithPostRetries(0, 0))
+		if err == nil {
+			t.Error("expected a timeout error")
+		}
+	})
+}
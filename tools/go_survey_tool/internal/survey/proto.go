@@ -0,0 +1,145 @@
+package survey
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ProtoVersion JSON协议的版本号，请求中的version为0时视为当前版本
+const ProtoVersion = 1
+
+// ProtoQuestion JSON请求中的一个问题，字段含义与Question相同
+type ProtoQuestion struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type,omitempty"`
+	Message string   `json:"message"`
+	Default string   `json:"default,omitempty"`
+	Options []string `json:"options,omitempty"`
+}
+
+// ProtoRequest 从标准输入读取的请求
+//
+//	{"version": 1,
+//	 "questions": [{"name": "color", "type": "select", "message": "Color?", "options": ["Red", "Blue"]}],
+//	 "answers": {"color": "Blue"}}
+//
+// answers中的值按问题类型解析：select可以是选项文本或从1开始的序号，
+// confirm可以是布尔值或yes/no，multiselect可以是数组或逗号分隔的字符串
+type ProtoRequest struct {
+	Version   int                    `json:"version"`
+	Questions []ProtoQuestion        `json:"questions"`
+	Answers   map[string]interface{} `json:"answers,omitempty"`
+}
+
+// ProtoResponse 写到标准输出的响应
+// 成功时error为空；有问题既没有提供答案也没有默认值时，missing列出这些问题的名称
+type ProtoResponse struct {
+	Version int                    `json:"version"`
+	Answers map[string]interface{} `json:"answers"`
+	Missing []string               `json:"missing,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// Question 转换为Question
+func (p ProtoQuestion) Question() Question {
+	return Question{Name: p.Name, Type: p.Type, Message: p.Message, Default: p.Default, Options: p.Options}
+}
+
+// ServeJSON 从in读取一个ProtoRequest，解析出所有答案后把ProtoResponse写入out
+// 不读取终端：答案来自请求中的answers，其次是问题的默认值。
+// 请求无法解析时返回错误而不写响应；答案不完整或无效时写出带error的响应并返回同样的错误
+func ServeJSON(in io.Reader, out io.Writer) error {
+	var req ProtoRequest
+	if err := json.NewDecoder(in).Decode(&req); err != nil {
+		return fmt.Errorf("解析请求失败: %w", err)
+	}
+
+	resp, resolveErr := resolveRequest(req)
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(resp); err != nil {
+		return fmt.Errorf("写入响应失败: %w", err)
+	}
+	return resolveErr
+}
+
+// resolveRequest 按顺序解析每个问题的答案，收集所有缺少答案的问题后再报告
+func resolveRequest(req ProtoRequest) (ProtoResponse, error) {
+	resp := ProtoResponse{Version: ProtoVersion, Answers: map[string]interface{}{}}
+	if req.Version != 0 && req.Version != ProtoVersion {
+		err := fmt.Errorf("unsupported protocol version %d", req.Version)
+		resp.Error = err.Error()
+		return resp, err
+	}
+
+	for _, pq := range req.Questions {
+		q := pq.Question()
+		switch q.kind() {
+		case TypeInput, TypePassword, TypeConfirm, TypeSelect, TypeMultiSelect:
+		default:
+			err := &QuestionError{Name: q.Name, Err: fmt.Errorf("unknown question type %q", q.Type)}
+			resp.Error = err.Error()
+			return resp, err
+		}
+
+		value, ok := req.Answers[q.Name]
+		if !ok {
+			if q.Default == "" {
+				resp.Missing = append(resp.Missing, q.Name)
+				continue
+			}
+			value = q.Default
+		}
+
+		answer, err := protoAnswer(q, value)
+		if err != nil {
+			err = &QuestionError{Name: q.Name, Err: err}
+			resp.Error = err.Error()
+			return resp, err
+		}
+		resp.Answers[q.Name] = answer
+	}
+
+	if len(resp.Missing) > 0 {
+		err := fmt.Errorf("no answer for %d question(s)", len(resp.Missing))
+		resp.Error = err.Error()
+		return resp, err
+	}
+	return resp, nil
+}
+
+// protoAnswer 将请求中的值转换并校验为问题类型对应的答案
+func protoAnswer(q Question, value interface{}) (interface{}, error) {
+	coerced, err := coerceAnswer(q, value)
+	if err != nil {
+		return nil, err
+	}
+	if k := q.kind(); k == TypeConfirm || k == TypeSelect {
+		// coerceAnswer已经校验过
+		return coerced, nil
+	}
+	switch v := coerced.(type) {
+	case string:
+		return parseLineAnswer(q, v)
+	case float64:
+		return parseLineAnswer(q, fmt.Sprint(v))
+	case bool:
+		return nil, fmt.Errorf("unexpected boolean answer")
+	case []string:
+		if q.kind() != TypeMultiSelect {
+			return nil, fmt.Errorf("unexpected list answer")
+		}
+		selected := make([]string, 0, len(v))
+		for _, token := range v {
+			option, ok := resolveOption(q.Options, token)
+			if !ok {
+				return nil, fmt.Errorf("invalid choice %q", token)
+			}
+			selected = append(selected, option)
+		}
+		return canonicalSelection(q.Options, selected), nil
+	default:
+		return nil, fmt.Errorf("unexpected answer type %T", value)
+	}
+}
@@ -0,0 +1,102 @@
+package survey_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestServeJSONRoundTrip(t *testing.T) {
+	req := survey.ProtoRequest{
+		Version: survey.ProtoVersion,
+		Questions: []survey.ProtoQuestion{
+			{Name: "name", Message: "Name?"},
+			{Name: "color", Type: survey.TypeSelect, Message: "Color?", Options: []string{"Red", "Blue"}, Default: "Red"},
+			{Name: "size", Type: survey.TypeSelect, Message: "Size?", Options: []string{"S", "M", "L"}},
+			{Name: "ok", Type: survey.TypeConfirm, Message: "OK?"},
+			{Name: "tags", Type: survey.TypeMultiSelect, Message: "Tags?", Options: []string{"a", "b", "c"}},
+		},
+		Answers: map[string]interface{}{
+			"name": "Alice",
+			"size": 3,
+			"ok":   "yes",
+			"tags": []string{"c", "a"},
+		},
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := &bytes.Buffer{}
+	if err := survey.ServeJSON(bytes.NewReader(data), out); err != nil {
+		t.Fatalf("ServeJSON() error = %v", err)
+	}
+
+	var resp survey.ProtoResponse
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("response is not valid JSON: %v\n%s", err, out.String())
+	}
+	expected := survey.ProtoResponse{
+		Version: survey.ProtoVersion,
+		Answers: map[string]interface{}{
+			"name":  "Alice",
+			"color": "Red",
+			"size":  "L",
+			"ok":    true,
+			"tags":  []interface{}{"a", "c"},
+		},
+	}
+	if !reflect.DeepEqual(resp, expected) {
+		t.Errorf("response = %#v, want %#v", resp, expected)
+	}
+}
+
+func TestServeJSONErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		request string
+		missing []string
+		errText string
+	}{
+		{"Missing answers", `{"questions":[{"name":"a","message":"A?"},{"name":"b","message":"B?","default":"x"},{"name":"c","message":"C?"}]}`, []string{"a", "c"}, "no answer for 2 question(s)"},
+		{"Invalid choice", `{"questions":[{"name":"a","type":"select","message":"A?","options":["x"]}],"answers":{"a":"y"}}`, nil, `invalid choice "y"`},
+		{"Unknown type", `{"questions":[{"name":"a","type":"slider","message":"A?"}]}`, nil, "unknown question type"},
+		{"Unsupported version", `{"version":2,"questions":[]}`, nil, "unsupported protocol version 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := survey.ServeJSON(strings.NewReader(tt.request), out)
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Fatalf("ServeJSON() error = %v, want %q", err, tt.errText)
+			}
+
+			var resp survey.ProtoResponse
+			if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+				t.Fatalf("response is not valid JSON: %v\n%s", err, out.String())
+			}
+			if !strings.Contains(resp.Error, tt.errText) {
+				t.Errorf("response error = %q, want %q", resp.Error, tt.errText)
+			}
+			if !reflect.DeepEqual(resp.Missing, tt.missing) {
+				t.Errorf("response missing = %v, want %v", resp.Missing, tt.missing)
+			}
+		})
+	}
+
+	t.Run("Malformed request", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		if err := survey.ServeJSON(strings.NewReader("{"), out); err == nil {
+			t.Error("expected an error for a malformed request")
+		}
+		if out.Len() != 0 {
+			t.Errorf("no response should be written, got %q", out.String())
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package survey
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// 支持的问题类型
+const (
+	TypeInput       = "input"
+	TypePassword    = "password"
+	TypeConfirm     = "confirm"
+	TypeSelect      = "select"
+	TypeMultiSelect = "multiselect"
+)
+
+// Question 描述表单中的一个问题
+type Question struct {
+	Name       string             // 答案在结果中的键名
+	Type       string             // 问题类型，为空时按input处理
+	Message    string             // 显示给用户的提示信息
+	Default    string             // 默认值：confirm使用yes/no，multiselect使用逗号分隔的选项
+	DefaultEnv string             // 环境变量名，变量非空时其值代替Default作为默认值
+	Options    []string           // select/multiselect的候选项
+	Validate   func(string) error // 可选的输入验证（仅input/password）
+
+	// SecretEnv、SecretFile 仅用于password：环境变量非空或设置了文件时直接使用其中的值而不提问，
+	// 便于同一个表单在CI中非交互地运行。环境变量优先，文件内容去掉首尾空白
+	SecretEnv  string
+	SecretFile string
+
+	// Timeout 不为0时，超过这个时间没有回答就使用默认值；没有默认值时返回ErrQuestionTimeout
+	Timeout time.Duration
+
+	// When 根据已收集的答案判断是否需要提问，返回false时跳过该问题，结果中也不会有它的答案。
+	// 为nil时总是提问
+	When func(answers map[string]interface{}) bool
+
+	aliases      map[string]int // AskSelectRich的别名到选项下标的映射
+	descriptions []string       // AskSelectRich的选项描述，与Options一一对应，都为空时为nil
+}
+
+// QuestionError 记录出错的问题，AskAll系列函数返回的提问错误都会包装为QuestionError，
+// 调用方可以用errors.As找出是哪个问题失败
+type QuestionError struct {
+	Name string
+	Err  error
+}
+
+func (e *QuestionError) Error() string {
+	return fmt.Sprintf("question %q: %v", e.Name, e.Err)
+}
+
+func (e *QuestionError) Unwrap() error {
+	return e.Err
+}
+
+// kind 返回问题类型，空类型视为input
+func (q Question) kind() string {
+	if q.Type == "" {
+		return TypeInput
+	}
+	return q.Type
+}
+
+// resolveDefault 返回问题实际使用的默认值
+// DefaultEnv指定的环境变量非空时优先使用，否则使用Default
+func resolveDefault(q Question) string {
+	if q.DefaultEnv != "" {
+		if value := os.Getenv(q.DefaultEnv); value != "" {
+			return value
+		}
+	}
+	return q.Default
+}
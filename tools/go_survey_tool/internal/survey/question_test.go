@@ -0,0 +1,33 @@
+package survey
+
+import "testing"
+
+func TestResolveDefault(t *testing.T) {
+	const env = "SURVEY_TEST_DEFAULT"
+	q := Question{Default: "fallback", DefaultEnv: env}
+
+	tests := []struct {
+		name     string
+		value    string
+		set      bool
+		expected string
+	}{
+		{"Unset", "", false, "fallback"},
+		{"Empty value ignored", "", true, "fallback"},
+		{"Set", "from-env", true, "from-env"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.set {
+				t.Setenv(env, tt.value)
+			}
+			if got := resolveDefault(q); got != tt.expected {
+				t.Errorf("resolveDefault() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if got := resolveDefault(Question{Default: "plain"}); got != "plain" {
+		t.Errorf("resolveDefault() without DefaultEnv = %q, want plain", got)
+	}
+}
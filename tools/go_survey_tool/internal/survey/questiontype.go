@@ -0,0 +1,97 @@
+package survey
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// TypeDate 内置的自定义问题类型：以YYYY-MM-DD格式输入日期，答案为time.Time（UTC零点）
+const TypeDate = "date"
+
+// TypeList 内置的自定义问题类型：按input提问，答案按逗号和空白切分为[]string，见utils.SplitList
+const TypeList = "list"
+
+// dateLayout date问题接受的日期格式
+const dateLayout = "2006-01-02"
+
+// QuestionHandler 自定义问题类型的提问函数，通过r读取输入和输出提示，返回问题的答案。
+// 调用前q.Default已按DefaultEnv解析；通过r.Ask提问时日志和记录照常生成
+type QuestionHandler func(r *Runner, q Question) (interface{}, error)
+
+var (
+	questionTypesMu sync.RWMutex
+	questionTypes   = map[string]QuestionHandler{}
+)
+
+func init() {
+	// askDate通过Ask提问，不能直接写在questionTypes的初始化中
+	RegisterQuestionType(TypeDate, askDate)
+	RegisterQuestionType(TypeList, askList)
+}
+
+// RegisterQuestionType 注册自定义问题类型，之后Ask、AskAll和ValidateQuestions都能识别Type为name的问题。
+// 与database/sql.Register一样，name为空、与内置类型或已注册的类型重名、handler为nil时panic
+func RegisterQuestionType(name string, handler QuestionHandler) {
+	if name == "" || handler == nil {
+		panic("survey: RegisterQuestionType needs a name and a handler")
+	}
+	switch name {
+	case TypeInput, TypePassword, TypeConfirm, TypeSelect, TypeMultiSelect:
+		panic(fmt.Sprintf("survey: question type %q is built in", name))
+	}
+	questionTypesMu.Lock()
+	defer questionTypesMu.Unlock()
+	if _, ok := questionTypes[name]; ok {
+		panic(fmt.Sprintf("survey: question type %q registered twice", name))
+	}
+	questionTypes[name] = handler
+}
+
+// questionHandler 返回自定义类型name的提问函数
+func questionHandler(name string) (QuestionHandler, bool) {
+	questionTypesMu.RLock()
+	defer questionTypesMu.RUnlock()
+	handler, ok := questionTypes[name]
+	return handler, ok
+}
+
+// askDate date类型的提问函数：按input提问，答案必须是YYYY-MM-DD格式的日期，
+// 再交给q.Validate（如果有）检查
+func askDate(r *Runner, q Question) (interface{}, error) {
+	if q.Default != "" {
+		if _, err := time.Parse(dateLayout, q.Default); err != nil {
+			return nil, fmt.Errorf("invalid default %q: want YYYY-MM-DD", q.Default)
+		}
+	}
+	input := q
+	input.Type = TypeInput
+	input.Validate = func(s string) error {
+		if _, err := time.Parse(dateLayout, s); err != nil {
+			return fmt.Errorf("invalid date %q: want YYYY-MM-DD", s)
+		}
+		if q.Validate != nil {
+			return q.Validate(s)
+		}
+		return nil
+	}
+	answer, err := r.Ask(input)
+	if err != nil {
+		return nil, err
+	}
+	return time.Parse(dateLayout, answer.(string))
+}
+
+// askList list类型的提问函数：按input提问，q.Validate（如果有）检查切分前的文本，
+// 再把答案切分为各项；没有输入任何项时答案为空的切片
+func askList(r *Runner, q Question) (interface{}, error) {
+	input := q
+	input.Type = TypeInput
+	answer, err := r.Ask(input)
+	if err != nil {
+		return nil, err
+	}
+	return utils.SplitList(answer.(string)), nil
+}
@@ -0,0 +1,154 @@
+package survey_test
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+// registerRating 注册测试用的rating类型：输入0到10之间的整数，只注册一次以便-count多次运行
+var registerRating sync.Once
+
+func ratingType() {
+	registerRating.Do(func() {
+		survey.RegisterQuestionType("rating", func(r *survey.Runner, q survey.Question) (interface{}, error) {
+			answer, err := r.AskInput(q.Message+" (0-10)", q.Default, nil)
+			if err != nil {
+				return nil, err
+			}
+			return strconv.Atoi(answer)
+		})
+	})
+}
+
+func TestRegisterQuestionTypeAskAll(t *testing.T) {
+	ratingType()
+	questions := []survey.Question{
+		{Name: "name", Message: "Name?"},
+		{Name: "volume", Type: "rating", Message: "Volume", Default: "5"},
+		{Name: "level", Type: "rating", Message: "Level"},
+	}
+	runner, out := newLineRunner("Alice\n\n7\n")
+	answers, err := runner.AskAll(questions)
+	if err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+	if answers["volume"] != 5 || answers["level"] != 7 {
+		t.Errorf("AskAll() = %v, want the rating answers as ints", answers)
+	}
+	if !strings.Contains(out.String(), "Volume (0-10)") {
+		t.Errorf("custom handler prompt not shown:\n%s", out.String())
+	}
+}
+
+func TestRegisterQuestionTypeValidate(t *testing.T) {
+	ratingType()
+	if err := survey.ValidateQuestions([]survey.Question{{Name: "v", Type: "rating", Message: "Volume"}}); err != nil {
+		t.Errorf("ValidateQuestions() with a registered type error = %v", err)
+	}
+	if err := survey.ValidateQuestions([]survey.Question{{Name: "v", Type: "knob", Message: "Volume"}}); err == nil {
+		t.Error("ValidateQuestions() should reject an unregistered type")
+	}
+}
+
+func TestRegisterQuestionTypePanics(t *testing.T) {
+	ratingType()
+	handler := func(r *survey.Runner, q survey.Question) (interface{}, error) { return nil, nil }
+	tests := []struct {
+		name     string
+		typeName string
+		handler  survey.QuestionHandler
+	}{
+		{"Built-in type", survey.TypeSelect, handler},
+		{"Registered twice", "rating", handler},
+		{"Date is registered", survey.TypeDate, handler},
+		{"Nil handler", "knob", nil},
+		{"Empty name", "", handler},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("RegisterQuestionType() should panic")
+				}
+			}()
+			survey.RegisterQuestionType(tt.typeName, tt.handler)
+		})
+	}
+}
+
+func TestAskDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		question survey.Question
+		input    string
+		want     time.Time
+	}{
+		{"Typed", survey.Question{Type: survey.TypeDate, Message: "Start?"}, "2024-02-29\n", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"Default", survey.Question{Type: survey.TypeDate, Message: "Start?", Default: "2023-01-15"}, "\n", time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"Retry invalid", survey.Question{Type: survey.TypeDate, Message: "Start?"}, "2023-02-30\n15/01/2023\n2023-01-15\n", time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, _ := newLineRunner(tt.input)
+			answer, err := runner.Ask(tt.question)
+			if err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+			if got, ok := answer.(time.Time); !ok || !got.Equal(tt.want) {
+				t.Errorf("Ask() = %#v, want %v", answer, tt.want)
+			}
+		})
+	}
+}
+
+func TestAskList(t *testing.T) {
+	tests := []struct {
+		name     string
+		question survey.Question
+		input    string
+		want     []string
+	}{
+		{"Mixed separators", survey.Question{Type: survey.TypeList, Message: "Tags?"}, " go,rust  python ,\n", []string{"go", "rust", "python"}},
+		{"Default", survey.Question{Type: survey.TypeList, Message: "Tags?", Default: "a, b"}, "\n", []string{"a", "b"}},
+		{"Empty", survey.Question{Type: survey.TypeList, Message: "Tags?"}, " , \n", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, _ := newLineRunner(tt.input)
+			answer, err := runner.Ask(tt.question)
+			if err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+			if !reflect.DeepEqual(answer, tt.want) {
+				t.Errorf("Ask() = %#v, want %#v", answer, tt.want)
+			}
+		})
+	}
+}
+
+func TestAskListPreset(t *testing.T) {
+	runner, _ := newLineRunner("")
+	questions := []survey.Question{{Name: "tags", Type: survey.TypeList, Message: "Tags?"}}
+	for _, preset := range []interface{}{"go rust", []interface{}{"go", "rust"}} {
+		answers, err := runner.AskAll(questions, map[string]interface{}{"tags": preset})
+		if err != nil {
+			t.Fatalf("AskAll() error = %v", err)
+		}
+		if want := []string{"go", "rust"}; !reflect.DeepEqual(answers["tags"], want) {
+			t.Errorf("AskAll() with preset %#v = %#v, want %#v", preset, answers["tags"], want)
+		}
+	}
+}
+
+func TestAskDateInvalidDefault(t *testing.T) {
+	runner, _ := newLineRunner("\n")
+	if _, err := runner.Ask(survey.Question{Type: survey.TypeDate, Message: "Start?", Default: "tomorrow"}); err == nil {
+		t.Error("Ask() with an invalid date default should fail")
+	}
+}
@@ -0,0 +1,27 @@
+package survey
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+// WithKeyRecorder 记录自绘菜单中的按键，配合ReplayMenu复现问题
+func WithKeyRecorder(rec *terminal.KeyRecorder) RunnerOption {
+	return func(r *Runner) {
+		r.Recorder = rec
+	}
+}
+
+// ReplayMenu 从keys读取按键驱动分组选择菜单，把每一帧输出到out，返回选中项的扁平序号
+// 与AskSelectGrouped的交互模式使用同一个菜单实现，但不需要终端，
+// 用于回放terminal.KeyRecorder录制的按键以确定性地复现问题
+func ReplayMenu(out io.Writer, keys io.Reader, message string, groups []Group) (int, error) {
+	m := newGroupedMenu(groups)
+	if m.selectable() == 0 {
+		return -1, errors.New("no options to select from")
+	}
+	return NewRunner().menuLoop(bufio.NewReader(keys), out, message, m)
+}
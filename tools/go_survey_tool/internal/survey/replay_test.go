@@ -0,0 +1,45 @@
+package survey
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+func TestReplayMenu(t *testing.T) {
+	var out bytes.Buffer
+	index, err := ReplayMenu(&out, strings.NewReader("\x1b[B\x1b[B\r"), "Pick one:", testGroups)
+	if err != nil {
+		t.Fatalf("ReplayMenu() error = %v", err)
+	}
+	if index != 2 {
+		t.Errorf("ReplayMenu() = %d, want 2", index)
+	}
+	if !strings.Contains(out.String(), "? Pick one: Carrot\r\n") {
+		t.Errorf("final frame missing:\n%q", out.String())
+	}
+}
+
+func TestReplayMenuEndOfInput(t *testing.T) {
+	if _, err := ReplayMenu(io.Discard, strings.NewReader("\x1b[B"), "Pick", testGroups); !errors.Is(err, io.EOF) {
+		t.Errorf("ReplayMenu() error = %v, want io.EOF", err)
+	}
+}
+
+func TestMenuLoopRecordsKeys(t *testing.T) {
+	rec := terminal.NewKeyRecorder()
+	r := NewRunner(WithKeyRecorder(rec))
+	if _, err := r.menuLoop(bufio.NewReader(strings.NewReader("\x1b[B\r")), io.Discard, "Pick", newGroupedMenu(testGroups)); err != nil {
+		t.Fatal(err)
+	}
+
+	events := rec.Events()
+	if len(events) != 2 || events[0].Key != terminal.KeyDown || events[1].Key != terminal.KeyEnter {
+		t.Errorf("recorded %+v, want Down then Enter", events)
+	}
+}
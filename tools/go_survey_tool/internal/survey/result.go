@@ -0,0 +1,116 @@
+package survey
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// Result 一次调查的结果
+type Result struct {
+	Answers map[string]interface{} // 以问题Name为键的答案
+
+	Duration      time.Duration // 从第一个问题显示到最后一个答案的用时，由AskAllResult记录
+	QuestionCount int           // 实际提问的问题数
+
+	// Order 答案的名字，按问题的顺序排列，被When跳过的问题不在其中。由AskAllResult记录
+	Order []string
+
+	SessionID string    // 所在omnish会话的ID（OMNISH_SESSION_ID），不在会话中时为空
+	Completed time.Time // 调查结束的时间，由AskAllResult记录
+}
+
+// NamedAnswer 带问题名的答案，见Result.Ordered
+type NamedAnswer struct {
+	Name  string
+	Value interface{}
+}
+
+// Ordered 按Order的顺序返回所有答案，输出和序列化时不依赖map的遍历顺序。
+// 不在Order中的答案（例如手动构造的Result）按名字排序后放在最后
+func (res Result) Ordered() []NamedAnswer {
+	ordered := make([]NamedAnswer, 0, len(res.Answers))
+	listed := make(map[string]bool, len(res.Order))
+	for _, name := range res.Order {
+		if value, ok := res.Answers[name]; ok && !listed[name] {
+			ordered = append(ordered, NamedAnswer{Name: name, Value: value})
+			listed[name] = true
+		}
+	}
+	var rest []string
+	for name := range res.Answers {
+		if !listed[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	for _, name := range rest {
+		ordered = append(ordered, NamedAnswer{Name: name, Value: res.Answers[name]})
+	}
+	return ordered
+}
+
+// names 返回Ordered中的名字
+func (res Result) names() []string {
+	ordered := res.Ordered()
+	names := make([]string, len(ordered))
+	for i, answer := range ordered {
+		names[i] = answer.Name
+	}
+	return names
+}
+
+// FormatKeyValues 按Ordered的顺序把答案格式化为两列对齐的表格，见utils.FormatKeyValues
+func (res Result) FormatKeyValues() string {
+	return utils.FormatKeyValues(res.Answers, res.names())
+}
+
+// WriteResultsCSV 把多次调查的结果写为CSV：第一行是列名，之后每个结果一行
+// 每列取对应名字的答案，按utils.FormatValue转换为文本，缺少的答案为空字段。
+// 字段中的逗号、引号和换行由encoding/csv转义。
+// columns为nil时按Result.Ordered的顺序使用所有结果中出现过的答案名，布尔值的写法见WithBoolFormat
+func WriteResultsCSV(w io.Writer, results []Result, columns []string, opts ...OutputOption) error {
+	cfg := newOutputConfig(opts)
+	if columns == nil {
+		columns = resultColumns(results)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return fmt.Errorf("写入CSV失败: %w", err)
+	}
+
+	row := make([]string, len(columns))
+	for _, result := range results {
+		for i, column := range columns {
+			row[i] = utils.FormatValue(cfg.bools.Apply(result.Answers[column]))
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("写入CSV失败: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("写入CSV失败: %w", err)
+	}
+	return nil
+}
+
+// resultColumns 按第一次出现的顺序收集所有结果的答案名
+func resultColumns(results []Result) []string {
+	columns := []string{}
+	seen := make(map[string]bool)
+	for _, result := range results {
+		for _, name := range result.names() {
+			if !seen[name] {
+				columns = append(columns, name)
+				seen[name] = true
+			}
+		}
+	}
+	return columns
+}
@@ -0,0 +1,113 @@
+package survey_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestWriteResultsCSVRoundTrip(t *testing.T) {
+	results := []survey.Result{
+		{Answers: map[string]interface{}{"name": "Smith, John", "quote": `say "hi"`, "note": "line1\nline2", "ok": true}},
+		{Answers: map[string]interface{}{"name": "Alice", "colors": []string{"Red", "Blue"}}},
+	}
+	columns := []string{"name", "quote", "note", "ok", "colors"}
+
+	var buf bytes.Buffer
+	if err := survey.WriteResultsCSV(&buf, results, columns); err != nil {
+		t.Fatalf("WriteResultsCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, buf.String())
+	}
+	expected := [][]string{
+		columns,
+		{"Smith, John", `say "hi"`, "line1\nline2", "true", ""},
+		{"Alice", "", "", "", "Red, Blue"},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("round trip = %q, want %q", records, expected)
+	}
+}
+
+func TestWriteResultsCSVMultilineAnswers(t *testing.T) {
+	results := []survey.Result{
+		{Answers: map[string]interface{}{"name": "Alice", "note": "first\n\nthird line, with comma\n"}},
+		{Answers: map[string]interface{}{"name": "Bob", "note": "\"quoted\"\nsecond"}},
+	}
+	var buf bytes.Buffer
+	if err := survey.WriteResultsCSV(&buf, results, []string{"name", "note"}); err != nil {
+		t.Fatalf("WriteResultsCSV() error = %v", err)
+	}
+
+	// 多行答案被引号括起，每个结果仍然只占一条记录
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, buf.String())
+	}
+	expected := [][]string{
+		{"name", "note"},
+		{"Alice", "first\n\nthird line, with comma\n"},
+		{"Bob", "\"quoted\"\nsecond"},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("round trip = %q, want %q", records, expected)
+	}
+}
+
+func TestWriteResultsCSVNoResults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := survey.WriteResultsCSV(&buf, nil, []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "a,b\n" {
+		t.Errorf("WriteResultsCSV() = %q, want only the header", buf.String())
+	}
+}
+
+func TestResultOrdered(t *testing.T) {
+	// 名字的字母顺序与提问顺序相反，map的遍历顺序也不稳定
+	questions := []survey.Question{
+		{Name: "zone", Message: "Zone?"},
+		{Name: "port", Message: "Port?", When: func(map[string]interface{}) bool { return false }},
+		{Name: "name", Message: "Name?"},
+		{Name: "admin", Type: survey.TypeConfirm, Message: "Admin?"},
+	}
+	runner := survey.NewRunner(survey.WithStdio(strings.NewReader("eu\nbob\ny\n"), &bytes.Buffer{}, &bytes.Buffer{}))
+	result, err := runner.AskAllResult(questions)
+	if err != nil {
+		t.Fatalf("AskAllResult() error = %v", err)
+	}
+
+	expected := []survey.NamedAnswer{{Name: "zone", Value: "eu"}, {Name: "name", Value: "bob"}, {Name: "admin", Value: true}}
+	for i := 0; i < 20; i++ {
+		if got := result.Ordered(); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Ordered() = %v, want %v", got, expected)
+		}
+	}
+	if got := result.FormatKeyValues(); got != "zone   eu\nname   bob\nadmin  true\n" {
+		t.Errorf("FormatKeyValues() = %q", got)
+	}
+
+	var buf bytes.Buffer
+	if err := survey.WriteResultsCSV(&buf, []survey.Result{result}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "zone,name,admin\neu,bob,true\n" {
+		t.Errorf("WriteResultsCSV() with nil columns = %q", buf.String())
+	}
+}
+
+func TestResultOrderedWithoutOrder(t *testing.T) {
+	result := survey.Result{Answers: map[string]interface{}{"b": 2, "c": 3, "a": 1}, Order: []string{"c"}}
+	expected := []survey.NamedAnswer{{Name: "c", Value: 3}, {Name: "a", Value: 1}, {Name: "b", Value: 2}}
+	if got := result.Ordered(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Ordered() = %v, want listed names first, then the rest sorted", got)
+	}
+}
@@ -0,0 +1,97 @@
+package survey
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// ErrQuestionsChanged 状态文件保存时的问题集与当前不同，保存的答案可能已经对不上
+var ErrQuestionsChanged = errors.New("questions changed since the state was saved")
+
+// stateHashKey 状态文件中保存QuestionsHash的键，不会与问题的答案混淆
+const stateHashKey = "_questions_hash"
+
+// AskAllResumable 使用标准输入输出运行可恢复的调查
+func AskAllResumable(questions []Question, statePath string) (map[string]interface{}, error) {
+	return NewRunner().AskAllResumable(questions, statePath)
+}
+
+// AskAllResumable 与AskAll相同，但每答完一题就把已收集的答案以JSON写入statePath
+// 启动时如果statePath已存在，会加载其中的答案并跳过对应问题；
+// 全部问完后删除状态文件。这样用户按Ctrl-C（ErrInterrupted）中断后可以从断点继续。
+// 状态文件中同时记录问题集的QuestionsHash，问题改变后拒绝恢复并返回ErrQuestionsChanged。
+// 密码不写入状态文件，恢复时重新询问
+func (r *Runner) AskAllResumable(questions []Question, statePath string) (map[string]interface{}, error) {
+	hash := QuestionsHash(questions)
+	saved, err := loadState(statePath, questions, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := r.askAll(questions, saved, func(_ string, answers map[string]interface{}) error {
+		return saveState(statePath, questions, answers, hash)
+	})
+	if err != nil {
+		return answers, err
+	}
+
+	if err := os.Remove(statePath); err != nil && !os.IsNotExist(err) {
+		return answers, fmt.Errorf("删除状态文件失败: %w", err)
+	}
+	return answers, nil
+}
+
+// loadState 读取保存的答案，只保留questions中存在的问题
+// 文件不存在时返回空结果；文件中记录的哈希与hash不同时返回ErrQuestionsChanged，
+// 没有记录哈希的旧状态文件照常加载
+func loadState(path string, questions []Question, hash string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]interface{}{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取状态文件失败: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(utils.StripBOM(string(data))), &raw); err != nil {
+		return nil, fmt.Errorf("解析状态文件失败: %w", err)
+	}
+	if saved, ok := raw[stateHashKey]; ok && saved != hash {
+		return nil, fmt.Errorf("%w: remove %s to start over", ErrQuestionsChanged, path)
+	}
+	answers, err := knownAnswers(questions, raw)
+	if err != nil {
+		return nil, fmt.Errorf("状态文件中的答案无效: %w", err)
+	}
+	// 旧版本写入的状态文件中可能有密码，同样不使用
+	for _, q := range questions {
+		if q.kind() == TypePassword {
+			delete(answers, q.Name)
+		}
+	}
+	return answers, nil
+}
+
+// saveState 原子地写入除密码以外的答案和问题集的哈希：先写临时文件再重命名，避免中断时留下半截文件
+func saveState(path string, questions []Question, answers map[string]interface{}, hash string) error {
+	state := make(map[string]interface{}, len(answers)+1)
+	for _, q := range questions {
+		if value, ok := answers[q.Name]; ok && q.kind() != TypePassword {
+			state[q.Name] = value
+		}
+	}
+	state[stateHashKey] = hash
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化状态失败: %w", err)
+	}
+	if err := utils.WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("写入状态文件失败: %w", err)
+	}
+	return nil
+}
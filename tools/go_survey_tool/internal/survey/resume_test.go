@@ -0,0 +1,164 @@
+package survey_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestAskAllResumableSkipsSavedAnswers(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(statePath, []byte(`{"name": "Alice"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	runner, out := newLineRunner("Blue\ny\n")
+	answers, err := runner.AskAllResumable(survey.CreateSurveyQuestions(), statePath)
+	if err != nil {
+		t.Fatalf("AskAllResumable() error = %v", err)
+	}
+
+	// 第一个被提问的应该是第二个问题
+	if !strings.HasPrefix(out.String(), "? Choose a color:") {
+		t.Errorf("expected the color question to be prompted first, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "What is your name?") {
+		t.Errorf("saved question was prompted again:\n%s", out.String())
+	}
+
+	if answers["name"] != "Alice" || answers["color"] != "Blue" || answers["confirm"] != true {
+		t.Errorf("unexpected answers: %v", answers)
+	}
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Errorf("state file should be removed after completion, stat error = %v", err)
+	}
+}
+
+func TestAskAllResumableStateWithBOM(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(statePath, []byte("\ufeff"+`{"name": "Alice", "color": "Red"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	runner, _ := newLineRunner("y\n")
+	answers, err := runner.AskAllResumable(survey.CreateSurveyQuestions(), statePath)
+	if err != nil {
+		t.Fatalf("AskAllResumable() error = %v", err)
+	}
+	if answers["name"] != "Alice" || answers["color"] != "Red" {
+		t.Errorf("unexpected answers: %v", answers)
+	}
+}
+
+func TestAskAllResumableKeepsStateOnFailure(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+
+	// 只回答第一题，随后输入结束
+	runner, _ := newLineRunner("Alice\n")
+	if _, err := runner.AskAllResumable(survey.CreateSurveyQuestions(), statePath); err == nil {
+		t.Fatal("expected an error when input ends early")
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("state file should be kept: %v", err)
+	}
+	var saved map[string]interface{}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("invalid state file: %v", err)
+	}
+	if saved["name"] != "Alice" || len(saved) != 2 {
+		t.Errorf("unexpected saved state: %v", saved)
+	}
+	if saved["_questions_hash"] != survey.QuestionsHash(survey.CreateSurveyQuestions()) {
+		t.Errorf("state should record the questions hash, got %v", saved["_questions_hash"])
+	}
+}
+
+func TestAskAllResumableRefusesChangedQuestions(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	runner, _ := newLineRunner("Alice\n")
+	if _, err := runner.AskAllResumable(survey.CreateSurveyQuestions(), statePath); err == nil {
+		t.Fatal("expected an error when input ends early")
+	}
+
+	changed := survey.CreateSurveyQuestions()
+	changed[0].Message = "Full name?"
+	runner, _ = newLineRunner("Bob\nBlue\ny\n")
+	if _, err := runner.AskAllResumable(changed, statePath); !errors.Is(err, survey.ErrQuestionsChanged) {
+		t.Fatalf("AskAllResumable() with changed questions error = %v, want ErrQuestionsChanged", err)
+	}
+
+	// 问题未变时照常恢复
+	runner, _ = newLineRunner("Blue\ny\n")
+	answers, err := runner.AskAllResumable(survey.CreateSurveyQuestions(), statePath)
+	if err != nil {
+		t.Fatalf("AskAllResumable() error = %v", err)
+	}
+	if answers["name"] != "Alice" {
+		t.Errorf("name = %v, want the saved answer", answers["name"])
+	}
+}
+
+func TestAskAllResumableRestoresMultiSelect(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(statePath, []byte(`{"colors": ["Red", "Green"]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	questions := []survey.Question{
+		{Name: "colors", Type: survey.TypeMultiSelect, Message: "Colors", Options: []string{"Red", "Blue", "Green"}},
+	}
+	runner, _ := newLineRunner("")
+	answers, err := runner.AskAllResumable(questions, statePath)
+	if err != nil {
+		t.Fatalf("AskAllResumable() error = %v", err)
+	}
+	colors, ok := answers["colors"].([]string)
+	if !ok || len(colors) != 2 || colors[0] != "Red" || colors[1] != "Green" {
+		t.Errorf("expected restored []string answer, got %#v", answers["colors"])
+	}
+}
+
+func TestAskAllResumableSkipsPasswords(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	questions := []survey.Question{
+		{Name: "user", Message: "User?"},
+		{Name: "secret", Type: survey.TypePassword, Message: "Password?"},
+		{Name: "host", Message: "Host?"},
+	}
+
+	// 回答前两题后输入结束，状态文件中不能有密码
+	runner, _ := newLineRunner("alice\nhunter2\n")
+	if _, err := runner.AskAllResumable(questions, statePath); err == nil {
+		t.Fatal("expected an error when input ends early")
+	}
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("state file should be kept: %v", err)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Errorf("password written to the state file:\n%s", data)
+	}
+
+	// 恢复时重新询问密码；旧状态文件中的密码同样被忽略
+	if err := os.WriteFile(statePath, []byte(`{"user": "alice", "secret": "old"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	runner, out := newLineRunner("hunter3\nexample.com\n")
+	answers, err := runner.AskAllResumable(questions, statePath)
+	if err != nil {
+		t.Fatalf("AskAllResumable() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Password?") {
+		t.Errorf("password should be asked again on resume:\n%s", out.String())
+	}
+	if answers["user"] != "alice" || answers["secret"] != "hunter3" || answers["host"] != "example.com" {
+		t.Errorf("unexpected answers: %v", answers)
+	}
+}
@@ -0,0 +1,111 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// Option AskSelectRich的选项，Aliases是可以代替完整文本输入的简写
+// Description不为空时以暗色显示在选项后面的描述列中，宽度不够时被截断或不显示
+type Option struct {
+	Label       string
+	Aliases     []string
+	Description string
+}
+
+// minDescriptionWidth 描述列至少能显示的列数，终端更窄时不显示描述
+const minDescriptionWidth = 10
+
+// AskSelectRich 使用标准输入输出询问带别名的单选
+func AskSelectRich(message string, options []Option, opts ...SelectOption) (int, error) {
+	return NewRunner().AskSelectRich(message, options, opts...)
+}
+
+// AskSelectRich 询问单选，返回选中项在options中的下标。
+// 用户输入的内容与某个别名完全相同（区分大小写）时直接对应该选项：交互模式下筛选结果只剩这一项，
+// 回车即可选中；简单模式下别名优先于序号和选项文本。不是别名时按原来的规则处理，
+// 即交互模式下按不区分大小写的子串筛选，简单模式下按序号或完整文本匹配
+func (r *Runner) AskSelectRich(message string, options []Option, opts ...SelectOption) (int, error) {
+	if len(options) == 0 {
+		return -1, errors.New("no options to select from")
+	}
+	labels := make([]string, len(options))
+	descriptions := make([]string, len(options))
+	described := false
+	aliases := make(map[string]int)
+	for i, option := range options {
+		labels[i] = option.Label
+		descriptions[i] = option.Description
+		described = described || option.Description != ""
+		for _, alias := range option.Aliases {
+			if j, ok := aliases[alias]; ok && j != i {
+				return -1, fmt.Errorf("alias %q is used by more than one option", alias)
+			}
+			aliases[alias] = i
+		}
+	}
+
+	q := Question{Type: TypeSelect, Message: message, Options: labels, aliases: aliases}
+	if described {
+		q.descriptions = descriptions
+	}
+	answer, err := r.ask(q, newSelectConfig(opts))
+	if err != nil {
+		return -1, fmt.Errorf("选择失败: %w", err)
+	}
+	for i, label := range labels {
+		if label == answer.(string) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("选择失败: unknown option %q", answer)
+}
+
+// aliasFilter 返回survey Select使用的筛选函数：筛选文本是别名时只保留对应的选项，
+// 否则按survey默认的不区分大小写子串匹配；descriptions不为nil时同时匹配选项的描述
+func aliasFilter(aliases map[string]int, descriptions []string) func(filter, value string, index int) bool {
+	return func(filter, value string, index int) bool {
+		if i, ok := aliases[filter]; ok {
+			return index == i
+		}
+		needle := strings.ToLower(filter)
+		if strings.Contains(strings.ToLower(value), needle) {
+			return true
+		}
+		description, _ := utils.OptionAt(descriptions, index)
+		return description != "" && strings.Contains(strings.ToLower(description), needle)
+	}
+}
+
+// descriptionColumn 返回每个选项后面显示的描述：先用空格把选项补齐到最宽的选项，空两格后是描述，out是终端时为暗色。
+// indent为选项文本前占用的列数；width > 0时描述截断到这一宽度以内，剩下的宽度不足minDescriptionWidth时都不显示
+func descriptionColumn(out io.Writer, labels, descriptions []string, indent, width int) []string {
+	column := make([]string, len(labels))
+	labelWidth := 0
+	for i, label := range labels {
+		if description, _ := utils.OptionAt(descriptions, i); description != "" {
+			labelWidth = max(labelWidth, utils.DisplayWidth(label))
+		}
+	}
+	// 再留一列给光标
+	available := width - indent - labelWidth - 2 - 1
+	if width > 0 && available < minDescriptionWidth {
+		return column
+	}
+	for i, label := range labels {
+		description, _ := utils.OptionAt(descriptions, i)
+		if description == "" {
+			continue
+		}
+		if width > 0 {
+			description = utils.FitToWidth(description, available)
+		}
+		padding := strings.Repeat(" ", labelWidth-utils.DisplayWidth(label)+2)
+		column[i] = padding + utils.ColorizeFor(out, description, utils.Dim)
+	}
+	return column
+}
@@ -0,0 +1,164 @@
+package survey
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	surveyv2 "github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+)
+
+var richOptions = []Option{
+	{Label: "选项 1: 红色", Aliases: []string{"r", "red"}},
+	{Label: "选项 2: 蓝色", Aliases: []string{"b"}},
+	{Label: "选项 3: 绿色", Aliases: []string{"g", "2"}},
+}
+
+func TestAskSelectRichAliasLineMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{"Alias of the first option", "r\n", 0},
+		{"Alias of the last option", "g\n", 2},
+		{"Longer alias", "red\n", 0},
+		{"Alias wins over a number", "2\n", 2},
+		{"Number", "1\n", 0},
+		{"Full text", "选项 2: 蓝色\n", 1},
+		{"Alias is case sensitive", "R\nb\n", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRunner(WithStdio(strings.NewReader(tt.input), &bytes.Buffer{}, &bytes.Buffer{}))
+			index, err := r.AskSelectRich("Color?", richOptions)
+			if err != nil {
+				t.Fatalf("AskSelectRich() error = %v", err)
+			}
+			if index != tt.expected {
+				t.Errorf("AskSelectRich() = %d, want %d", index, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAskSelectRichDuplicateAlias(t *testing.T) {
+	options := []Option{{Label: "A", Aliases: []string{"x"}}, {Label: "B", Aliases: []string{"x"}}}
+	if _, err := NewRunner().AskSelectRich("Pick", options); err == nil || !strings.Contains(err.Error(), `alias "x"`) {
+		t.Errorf("AskSelectRich() error = %v, want a duplicate alias error", err)
+	}
+}
+
+func TestAliasFilter(t *testing.T) {
+	filter := aliasFilter(map[string]int{"r": 0, "g": 2}, nil)
+	labels := []string{"选项 1: 红色", "选项 2: 蓝色", "选项 3: 绿色 (green)"}
+
+	visible := func(typed string) []int {
+		var shown []int
+		for i, label := range labels {
+			if filter(typed, label, i) {
+				shown = append(shown, i)
+			}
+		}
+		return shown
+	}
+	// 别名只留下对应的选项，即使其他选项的文本包含同样的字符
+	if got := visible("g"); len(got) != 1 || got[0] != 2 {
+		t.Errorf("filter %q shows %v, want only option 2", "g", got)
+	}
+	if got := visible("r"); len(got) != 1 || got[0] != 0 {
+		t.Errorf("filter %q shows %v, want only option 0", "r", got)
+	}
+	// 不是别名时按子串筛选
+	if got := visible("GREEN"); len(got) != 1 || got[0] != 2 {
+		t.Errorf("filter %q shows %v, want the substring match", "GREEN", got)
+	}
+	if got := visible("选项"); len(got) != 3 {
+		t.Errorf("filter %q shows %v, want all options", "选项", got)
+	}
+}
+
+// describedOptions 带描述的选项，最后一项没有描述
+var describedOptions = []Option{
+	{Label: "create", Description: "Create a new repository from a template"},
+	{Label: "ls", Description: "List repositories"},
+	{Label: "help"},
+}
+
+func TestSelectDescriptionsGolden(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	labels := []string{"create", "ls", "help"}
+	descriptions := []string{describedOptions[0].Description, describedOptions[1].Description, ""}
+	column := descriptionColumn(io.Discard, labels, descriptions, 2, 40)
+	data := surveyv2.SelectTemplateData{
+		Select:        surveyv2.Select{Message: "Command:", Options: labels},
+		PageEntries:   core.OptionAnswerList(labels),
+		SelectedIndex: 1,
+		Config:        promptConfig(),
+		Description: func(_ string, index int) string {
+			return column[index]
+		},
+	}
+	checkGolden(t, "select_descriptions_width40.golden", renderSurveyTemplate(t, newSelectConfig(nil).selectTemplate(), data))
+}
+
+func TestDescriptionColumnTooNarrow(t *testing.T) {
+	column := descriptionColumn(io.Discard, []string{"create", "ls"}, []string{"Create a repository", "List"}, 2, 20)
+	if column[0] != "" || column[1] != "" {
+		t.Errorf("descriptionColumn() at width 20 = %q, want no descriptions", column)
+	}
+	// 宽度未知时不截断
+	t.Setenv("NO_COLOR", "1")
+	column = descriptionColumn(io.Discard, []string{"create", "ls"}, []string{"Create a repository", "List"}, 2, 0)
+	if column[0] != "  Create a repository" || column[1] != "      List" {
+		t.Errorf("descriptionColumn() without a width = %q", column)
+	}
+}
+
+func TestAliasFilterDescriptions(t *testing.T) {
+	labels := []string{"create", "ls", "help"}
+	descriptions := []string{"Create a new repository", "List repositories", ""}
+	for _, tt := range []struct {
+		descriptions []string
+		want         []int
+	}{
+		{nil, []int{}},
+		{descriptions, []int{0, 1}},
+	} {
+		filter := aliasFilter(map[string]int{}, tt.descriptions)
+		shown := []int{}
+		for i, label := range labels {
+			if filter("REPO", label, i) {
+				shown = append(shown, i)
+			}
+		}
+		if !reflect.DeepEqual(shown, tt.want) {
+			t.Errorf("filter with descriptions %q shows %v, want %v", tt.descriptions, shown, tt.want)
+		}
+	}
+}
+
+func TestAskSelectRichDescriptionsLineMode(t *testing.T) {
+	// 环境允许颜色时，写到缓冲区的描述也不着色
+	enableColor(t)
+	r, out := NewTestRunner("ls\n", 40)
+	index, err := r.AskSelectRich("Command:", describedOptions)
+	if err != nil || index != 1 {
+		t.Fatalf("AskSelectRich() = %d, %v, want 1", index, err)
+	}
+	for _, line := range []string{
+		"  1. create  Create a new repository f…\n",
+		"  2. ls      List repositories\n",
+		"  3. help\n",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("output missing %q:\n%s", line, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "\x1b[") {
+		t.Errorf("descriptions written to a buffer should not be colored: %q", out.String())
+	}
+}
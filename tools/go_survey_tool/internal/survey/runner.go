@@ -0,0 +1,740 @@
+package survey
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	surveyv2 "github.com/AlecAivazis/survey/v2"
+	surveyterm "github.com/AlecAivazis/survey/v2/terminal"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// ErrInterrupted 用户按Ctrl-C中断了提问
+var ErrInterrupted = errors.New("survey interrupted")
+
+// Runner 负责向用户提问并收集答案
+// In和Out都是终端时使用survey库进行交互；否则退化为逐行读取的简单模式，
+// 这样在管道、脚本和测试中也能使用同一套问题定义。
+// 多个Runner可以在不同goroutine中使用，但survey库的单选、多选和输入提示会在进程内依次进行，见withTemplate
+type Runner struct {
+	In  io.Reader
+	Out io.Writer
+	Err io.Writer
+
+	// NormalizeAnswers 为true时，input答案在验证和保存前先用utils.CollapseSpace规范化，
+	// 验证函数看到的也是规范化后的值。密码中的空白可能是有意的，不做处理
+	NormalizeAnswers bool
+
+	// ForceInteractive 为true时即使TERM为dumb或未设置也使用survey交互，
+	// 否则这类终端上总是使用简单模式
+	ForceInteractive bool
+
+	// Width 大于0时代替终端宽度排版：提示和选项截断到这个宽度以内，Out不是终端时也生效，
+	// 使测试中的输出与运行环境无关。为0时Out是终端才按它的宽度截断
+	Width int
+
+	// MaxInputLength 简单模式下一行答案最多的字节数，超过时提示错误并重新询问，
+	// 避免误粘贴的大段内容占满内存。为0时使用terminal.MaxLineLength，小于0时不限制
+	MaxInputLength int
+
+	// PromptPrefix 不为空时替换问题前的"?"图标，例如"omnish>"，前缀和问题之间总是有一个空格。
+	// 与Symbols一起可以定制整个提示的样式
+	PromptPrefix string
+
+	// Logger 记录提问、回答和验证失败等诊断事件，默认不输出
+	Logger Logger
+
+	// Transcript 不为nil时把每个问题和答案写成"Q: .../A: ..."的文本，供用户保存为记录。
+	// 与Logger不同，它面向最终用户，密码答案显示为****
+	Transcript io.Writer
+
+	// AnswerRecord 不为nil时每次调查结束后把答案写成JSON对象，可以用LoadAnswers读回，
+	// 作为预置答案重放整个调查。与Recorder记录按键不同，它只记录答案；密码答案不写入
+	AnswerRecord io.Writer
+
+	// Recorder 不为nil时记录自绘菜单中的每次按键，用于复现问题
+	Recorder *terminal.KeyRecorder
+
+	defaults map[string]interface{} // 代替问题Default的默认值，见WithDefaults
+
+	formValidator FormValidator // 所有问题回答完后检查答案，见WithFormValidator
+
+	lines *bufio.Reader // 简单模式下的行读取器，跨问题复用以免丢失缓冲数据
+
+	// Clock 用于超时计算的时钟，为nil时使用系统时间
+	Clock Clock
+
+	idleDeadline     time.Time       // 不为零时，超过这个时间仍没有输入则读取返回ErrIdleTimeout
+	questionDeadline time.Time       // 当前问题的Timeout期限，不为零时超时读取返回errReadTimeout
+	questionCtx      context.Context // 当前问题的ctx，取消后读取返回errReadCancelled
+	timeoutControl   *TimeoutControl // 当前问题的TimeoutControl，延长会加到questionDeadline上
+
+	onShown func() // 每个问题显示前调用，AskAllResult用它开始计时
+
+	pending  chan readResult // timedReader超时后仍在进行的读取
+	leftover []byte          // timedReader读到但还没有返回的数据
+}
+
+// RunnerOption Runner的配置选项
+type RunnerOption func(*Runner)
+
+// WithStdio 设置Runner使用的输入输出
+func WithStdio(in io.Reader, out, errOut io.Writer) RunnerOption {
+	return func(r *Runner) {
+		r.In = in
+		r.Out = out
+		r.Err = errOut
+	}
+}
+
+// WithForceInteractive 在dumb终端上也使用survey交互，见Runner.ForceInteractive
+func WithForceInteractive() RunnerOption {
+	return func(r *Runner) {
+		r.ForceInteractive = true
+	}
+}
+
+// WithPromptPrefix 设置问题前的前缀，见Runner.PromptPrefix
+func WithPromptPrefix(prefix string) RunnerOption {
+	return func(r *Runner) {
+		r.PromptPrefix = prefix
+	}
+}
+
+// WithMaxInputLength 设置简单模式下一行答案最多的字节数，见Runner.MaxInputLength
+func WithMaxInputLength(n int) RunnerOption {
+	return func(r *Runner) {
+		r.MaxInputLength = n
+	}
+}
+
+// WithNormalizeAnswers 规范化input答案中的空白，见Runner.NormalizeAnswers
+func WithNormalizeAnswers() RunnerOption {
+	return func(r *Runner) {
+		r.NormalizeAnswers = true
+	}
+}
+
+// openControllingTTY 打开控制终端，测试中替换以模拟打开失败
+var openControllingTTY = terminal.OpenControllingTTY
+
+// WithControllingTTY In不是终端时改为从控制终端读取答案，使标准输入被重定向时仍能交互式提问；
+// 控制终端打不开时保留原来的In，按简单模式逐行读取。需要放在WithStdio之后
+func WithControllingTTY() RunnerOption {
+	return func(r *Runner) {
+		if in, ok := r.In.(*os.File); ok && terminal.IO(in, nil, nil).StdinTTY {
+			return
+		}
+		tty, err := openControllingTTY()
+		if err != nil {
+			return
+		}
+		r.In = tty
+	}
+}
+
+// WithClock 设置超时计算使用的时钟，测试中可以传入FakeClock
+func WithClock(c Clock) RunnerOption {
+	return func(r *Runner) {
+		r.Clock = c
+	}
+}
+
+// NewRunner 创建Runner，默认使用标准输入输出
+func NewRunner(opts ...RunnerOption) *Runner {
+	r := &Runner{
+		In:     os.Stdin,
+		Out:    os.Stdout,
+		Err:    os.Stderr,
+		Logger: nopLogger{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// interactive 判断是否可以使用survey库进行交互
+func (r *Runner) interactive() bool {
+	if r.dumbTerminal() {
+		return false
+	}
+	in, ok := r.In.(*os.File)
+	if !ok {
+		return false
+	}
+	out, ok := r.Out.(*os.File)
+	if !ok {
+		return false
+	}
+	return terminal.IO(in, out, nil).Interactive()
+}
+
+// dumbTerminal 判断是否因为终端不支持控制序列而需要使用简单模式
+func (r *Runner) dumbTerminal() bool {
+	return !r.ForceInteractive && terminal.IsDumb()
+}
+
+// Ask 询问单个问题并返回答案
+// 答案类型：input/password/select为string，confirm为bool，multiselect和list为[]string，
+// date为time.Time；用RegisterQuestionType注册的类型由其提问函数决定
+func (r *Runner) Ask(q Question) (interface{}, error) {
+	return r.ask(q, selectConfig{})
+}
+
+// ask Ask的实现，cfg为Select类问题的显示配置
+func (r *Runner) ask(q Question, cfg selectConfig) (interface{}, error) {
+	q = r.applyDefault(q)
+	switch q.kind() {
+	case TypeInput, TypePassword, TypeConfirm, TypeSelect, TypeMultiSelect:
+	default:
+		handler, ok := questionHandler(q.kind())
+		if !ok {
+			return nil, fmt.Errorf("unknown question type %q", q.Type)
+		}
+		q.Default = resolveDefault(q)
+		return handler(r, q)
+	}
+	q.Default = resolveDefault(q)
+
+	// 有重复选项时用能区分的标签提问，答案再换回原来的选项
+	options := q.Options
+	if q.kind() == TypeSelect || q.kind() == TypeMultiSelect {
+		labels, err := r.distinctOptions(q, cfg)
+		if err != nil {
+			return nil, err
+		}
+		q.Options = labels
+	}
+
+	if q.kind() == TypePassword {
+		if answer, ok, err := r.secretAnswer(q); err != nil || ok {
+			return r.secretResult(q, answer, err)
+		}
+	}
+
+	r.log(LevelDebug, "question shown", "name", q.Name, "type", q.kind())
+	if r.onShown != nil {
+		r.onShown()
+	}
+
+	if q.Timeout > 0 {
+		r.questionDeadline = r.clock().Now().Add(q.Timeout)
+		defer func() { r.questionDeadline = time.Time{} }()
+	}
+	if cfg.ctx != nil || cfg.timeoutControl != nil {
+		r.questionCtx, r.timeoutControl = cfg.ctx, cfg.timeoutControl
+		defer func() { r.questionCtx, r.timeoutControl = nil, nil }()
+	}
+
+	var answer interface{}
+	var err error
+	if r.interactive() {
+		if err := r.waitForSize(cfg); err != nil {
+			return nil, err
+		}
+		answer, err = r.askSurvey(q, cfg)
+	} else {
+		answer, err = r.askLine(q)
+	}
+	if errors.Is(err, errReadTimeout) {
+		r.log(LevelInfo, "question timed out", "name", q.Name)
+		answer, err = r.timeoutAnswer(q)
+	}
+	if errors.Is(err, errReadCancelled) {
+		r.log(LevelInfo, "question cancelled", "name", q.Name)
+		answer, err = r.cancelAnswer(q, cfg.ctx)
+	}
+	if err != nil {
+		r.log(LevelWarn, "question failed", "name", q.Name, "error", err)
+		return nil, err
+	}
+
+	if selected, ok := answer.([]string); ok {
+		answer = canonicalSelection(q.Options, selected)
+	}
+	answer = originalOptions(options, q.Options, answer)
+	r.log(LevelInfo, "answer received", "name", q.Name, "value", loggedAnswer(q, answer))
+	r.writeTranscript(q, answer)
+	return answer, nil
+}
+
+// AskAll 使用标准输入输出依次询问所有问题
+func AskAll(questions []Question, presets ...map[string]interface{}) (map[string]interface{}, error) {
+	return NewRunner().AskAll(questions, presets...)
+}
+
+// AskAll 依次询问所有问题，返回以问题Name为键的答案
+// presets按MergeAnswers的规则合并后作为预置答案，对应的问题不再提问，无法转换的预置答案不提问直接返回*QuestionError。
+// 出错时返回已经收集到的答案和*QuestionError
+func (r *Runner) AskAll(questions []Question, presets ...map[string]interface{}) (map[string]interface{}, error) {
+	answered, err := knownAnswers(questions, MergeAnswers(presets...))
+	if err != nil {
+		return map[string]interface{}{}, err
+	}
+	return r.askAll(questions, answered, nil)
+}
+
+// askAll AskAll系列函数的公共实现
+// 先用ValidateQuestions检查问题定义，有错误时不提问直接返回；answered中已有答案的问题会被跳过，onAnswer在每收集到一个答案后以问题名调用
+func (r *Runner) askAll(questions []Question, answered map[string]interface{}, onAnswer func(name string, answers map[string]interface{}) error) (map[string]interface{}, error) {
+	if err := ValidateQuestions(questions); err != nil {
+		return map[string]interface{}{}, err
+	}
+
+	answers := make(map[string]interface{}, len(questions))
+	for name, value := range answered {
+		answers[name] = value
+	}
+	// 中途出错时也记录已有的答案，askMissing直接修改answers
+	defer r.writeAnswerRecord(questions, answers)
+
+	if err := r.askMissing(questions, answers, onAnswer); err != nil {
+		return answers, err
+	}
+	for r.formValidator != nil {
+		err := r.formValidator(answers)
+		if err == nil {
+			break
+		}
+		r.log(LevelInfo, "form validation failed", "error", err)
+		fmt.Fprintf(r.Out, "X %v\n", err)
+		names := reaskNames(questions, answered, err)
+		if len(names) == 0 {
+			return answers, err
+		}
+		for _, name := range names {
+			delete(answers, name)
+		}
+		if err := r.askMissing(questions, answers, onAnswer); err != nil {
+			return answers, err
+		}
+	}
+	return answers, nil
+}
+
+// askMissing 按顺序询问answers中还没有答案的问题，把答案加入answers
+func (r *Runner) askMissing(questions []Question, answers map[string]interface{}, onAnswer func(name string, answers map[string]interface{}) error) error {
+	for _, q := range questions {
+		if _, ok := answers[q.Name]; ok {
+			continue
+		}
+		if q.When != nil && !q.When(answers) {
+			r.log(LevelDebug, "question skipped", "name", q.Name)
+			continue
+		}
+
+		value, err := r.Ask(q)
+		if err != nil {
+			return &QuestionError{Name: q.Name, Err: err}
+		}
+		answers[q.Name] = value
+
+		if onAnswer != nil {
+			if err := onAnswer(q.Name, answers); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// stringValidator 将func(string) error适配为survey的Validator
+func stringValidator(validate func(string) error) surveyv2.Validator {
+	return func(ans interface{}) error {
+		s, ok := ans.(string)
+		if !ok {
+			return fmt.Errorf("unexpected answer type %T", ans)
+		}
+		return validate(s)
+	}
+}
+
+// askSurvey 使用survey库在终端上提问
+func (r *Runner) askSurvey(q Question, cfg selectConfig) (interface{}, error) {
+	in := r.input().(surveyterm.FileReader)
+	// 提问期间进程被SIGTSTP挂起（例如kill -TSTP）时恢复终端，survey库自己不处理
+	defer terminal.HandleSuspend(int(in.Fd()))()
+	opts := []surveyv2.AskOpt{
+		surveyv2.WithStdio(in, r.Out.(*os.File), r.Err),
+		surveyv2.WithIcons(r.setIcons),
+	}
+	if q.Validate != nil && (q.kind() == TypeInput || q.kind() == TypePassword) {
+		validate := func(s string) error {
+			err := q.Validate(r.normalize(q, s))
+			if err != nil {
+				r.log(LevelInfo, "validation failed", "name", q.Name, "error", err)
+			}
+			return err
+		}
+		opts = append(opts, surveyv2.WithValidator(stringValidator(validate)))
+	}
+
+	// 提示比终端窄时survey换行后重绘会错位，先按终端宽度截断
+	message := r.fitMessage(q.Message, r.questionMark())
+
+	switch q.kind() {
+	case TypePassword:
+		var answer string
+		err := askOne(&surveyv2.Password{Message: message}, &answer, opts)
+		return answer, err
+	case TypeConfirm:
+		def, _ := utils.ParseBool(q.Default)
+		var answer bool
+		err := askOne(&surveyv2.Confirm{Message: message, Default: def}, &answer, opts)
+		return answer, err
+	case TypeSelect:
+		if cfg.initialFilter != "" {
+			// survey库不能直接设置筛选文字，把它当作提问开始时已经输入的按键
+			opts[0] = surveyv2.WithStdio(&prefixedReader{FileReader: in, prefix: strings.NewReader(cfg.initialFilter)}, r.Out.(*os.File), r.Err)
+		}
+		prompt := &surveyv2.Select{Message: message, Options: q.Options}
+		if q.Default != "" {
+			prompt.Default = q.Default
+		}
+		if q.aliases != nil {
+			var descriptions []string
+			if cfg.filterDescriptions {
+				descriptions = q.descriptions
+			}
+			prompt.Filter = aliasFilter(q.aliases, descriptions)
+		}
+		if q.descriptions != nil {
+			// survey的焦点标记和选项之间有一个空格，共占两列
+			column := descriptionColumn(r.Out, q.Options, q.descriptions, 2, r.width())
+			prompt.Description = func(_ string, index int) string {
+				description, _ := utils.OptionAt(column, index)
+				return description
+			}
+		}
+		var answer string
+		err := withTemplate(&surveyv2.SelectQuestionTemplate, cfg.selectTemplate(), func() error {
+			return askOne(prompt, &answer, opts)
+		})
+		return answer, err
+	case TypeMultiSelect:
+		prompt := &surveyv2.MultiSelect{Message: message, Options: q.Options}
+		if defaults := splitDefault(q.Default); len(defaults) > 0 {
+			prompt.Default = defaults
+		}
+		answer := []string{}
+		err := withTemplate(&surveyv2.MultiSelectQuestionTemplate, cfg.multiSelectTemplate(), func() error {
+			return askOne(prompt, &answer, opts)
+		})
+		return answer, err
+	default:
+		var answer string
+		err := withTemplate(&surveyv2.InputQuestionTemplate, inputTemplate(), func() error {
+			return askOne(&surveyv2.Input{Message: message, Default: q.Default}, &answer, opts)
+		})
+		return r.normalize(q, answer), err
+	}
+}
+
+// prefixedReader 先读出prefix中的内容，再从FileReader读取，Fd仍是原来的终端
+type prefixedReader struct {
+	surveyterm.FileReader
+	prefix io.Reader
+}
+
+func (p *prefixedReader) Read(b []byte) (int, error) {
+	if n, _ := p.prefix.Read(b); n > 0 {
+		return n, nil
+	}
+	return p.FileReader.Read(b)
+}
+
+// setIcons 把survey的问题图标和选中标记替换为Symbols中的符号，问题图标优先使用PromptPrefix
+func (r *Runner) setIcons(icons *surveyv2.IconSet) {
+	icons.Question.Text = r.questionMark()
+	icons.SelectFocus.Text = utils.CurrentSymbols().Selected
+}
+
+// fitMessage 把提示截断到终端宽度，icon为提示前的问题图标
+// Out不是文件时原样返回；无法获取终端宽度时按terminal.SafeGetSize的默认宽度截断
+func (r *Runner) fitMessage(message, icon string) string {
+	width := r.width()
+	if width <= 0 {
+		return message
+	}
+	// 图标后跟一个空格，再留一列给光标
+	return utils.FitToWidth(message, width-utils.DisplayWidth(icon)-2)
+}
+
+// width 返回排版使用的宽度：设置了Width时使用它，否则Out是文件时按terminal.SafeGetSize获取，都不是时返回0
+func (r *Runner) width() int {
+	if r.Width > 0 {
+		return r.Width
+	}
+	out, ok := r.Out.(*os.File)
+	if !ok {
+		return 0
+	}
+	width, _ := terminal.SafeGetSize(int(out.Fd()))
+	return width
+}
+
+// waitForSize 按WithMinSize等待终端足够大，期间在一行中提示实际和要求的大小，满足后清除提示。
+// 没有设置最小大小或Out不是文件时直接返回；cfg.ctx取消时返回它的错误
+func (r *Runner) waitForSize(cfg selectConfig) error {
+	out, ok := r.Out.(*os.File)
+	if !ok || (cfg.minCols <= 0 && cfg.minRows <= 0) {
+		return nil
+	}
+	ctx := cfg.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	shown := false
+	err := terminal.WaitForSize(ctx, int(out.Fd()), cfg.minCols, cfg.minRows, func(err error) {
+		fmt.Fprintf(r.Out, "\r%s%s %v, please resize the window", clearLine, utils.CurrentSymbols().Warning, err)
+		shown = true
+	})
+	if shown {
+		fmt.Fprint(r.Out, "\r"+clearLine)
+	}
+	return err
+}
+
+// fitLine 设置了Width时把简单模式下的一行输出截断到Width以内
+func (r *Runner) fitLine(line string) string {
+	if r.Width <= 0 {
+		return line
+	}
+	return utils.FitToWidth(line, r.Width)
+}
+
+// askOne 在适当的终端模式下调用survey，并把Ctrl-C转换为ErrInterrupted
+func askOne(prompt surveyv2.Prompt, response interface{}, opts []surveyv2.AskOpt) error {
+	err := WithTerminalMode(func() error {
+		return surveyv2.AskOne(prompt, response, opts...)
+	})
+	if errors.Is(err, surveyterm.InterruptErr) {
+		return ErrInterrupted
+	}
+	return err
+}
+
+// askLine 简单模式：打印提示并读取一行作为答案，输入无效时提示错误并重新询问
+func (r *Runner) askLine(q Question) (interface{}, error) {
+	for {
+		r.writeLinePrompt(q)
+
+		line, err := r.readLine()
+		if errors.Is(err, terminal.ErrInputTooLong) {
+			r.log(LevelInfo, "input too long", "name", q.Name)
+			fmt.Fprintf(r.Out, "X %v\n", err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		answer, err := parseLineAnswer(q, line)
+		if s, ok := answer.(string); ok && err == nil {
+			answer = r.normalize(q, s)
+		}
+		if err == nil && q.Validate != nil && (q.kind() == TypeInput || q.kind() == TypePassword) {
+			err = q.Validate(answer.(string))
+		}
+		if err != nil {
+			r.log(LevelInfo, "validation failed", "name", q.Name, "error", err)
+			fmt.Fprintf(r.Out, "X %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// writeLinePrompt 输出简单模式下的提示
+func (r *Runner) writeLinePrompt(q Question) {
+	switch q.kind() {
+	case TypePassword:
+		fmt.Fprintf(r.Out, "%s %s: ", r.questionMark(), q.Message)
+	case TypeConfirm:
+		hint := "y/n"
+		if def, err := utils.ParseBool(q.Default); err == nil {
+			hint = "y/N"
+			if def {
+				hint = "Y/n"
+			}
+		}
+		fmt.Fprintf(r.Out, "%s %s (%s): ", r.questionMark(), q.Message, hint)
+	case TypeSelect, TypeMultiSelect:
+		fmt.Fprintln(r.Out, r.fitLine(fmt.Sprintf("%s %s", r.questionMark(), q.Message)))
+		// 选项前是两个空格、序号和". "
+		column := descriptionColumn(r.Out, q.Options, q.descriptions, len(strconv.Itoa(len(q.Options)))+4, r.Width)
+		for i, option := range q.Options {
+			fmt.Fprintln(r.Out, r.fitLine(fmt.Sprintf("  %d. %s%s", i+1, option, column[i])))
+		}
+		label := "Enter a number"
+		if q.kind() == TypeMultiSelect {
+			label = "Enter numbers separated by commas"
+		}
+		if q.Default != "" {
+			fmt.Fprintf(r.Out, "%s (%s): ", label, q.Default)
+		} else {
+			fmt.Fprintf(r.Out, "%s: ", label)
+		}
+	default:
+		if q.Default != "" {
+			fmt.Fprintf(r.Out, "%s %s %s: ", r.questionMark(), q.Message, defaultHint(q.Default, utils.ColorEnabledFor(r.Out)))
+		} else {
+			fmt.Fprintf(r.Out, "%s %s: ", r.questionMark(), q.Message)
+		}
+	}
+}
+
+// normalize 按NormalizeAnswers的设置规范化input答案
+func (r *Runner) normalize(q Question, s string) string {
+	if !r.NormalizeAnswers || q.kind() != TypeInput {
+		return s
+	}
+	return utils.CollapseSpace(s)
+}
+
+// questionMark 返回问题前缀符号，设置了PromptPrefix时使用它
+func (r *Runner) questionMark() string {
+	if r.PromptPrefix != "" {
+		return r.PromptPrefix
+	}
+	return utils.CurrentSymbols().Question
+}
+
+// readLine 读取一行输入，去掉行尾换行符
+// 答案来自Windows上编辑的文件时第一行可能带有BOM，读取第一行时去掉它。
+// 超过MaxInputLength的行被整行丢弃，返回terminal.ErrInputTooLong
+func (r *Runner) readLine() (string, error) {
+	first := r.lines == nil
+	if first {
+		r.lines = bufio.NewReader(r.input())
+	}
+	limit := r.MaxInputLength
+	if limit == 0 {
+		limit = terminal.MaxLineLength
+	}
+	line, err := readLimitedLine(r.lines, limit)
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	if first {
+		line = utils.StripBOM(line)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readLimitedLine 从br读取一行，包括行尾的换行符。去掉换行后超过limit字节（limit > 0时）时
+// 读完并丢弃这一行，返回terminal.ErrInputTooLong，内存占用不超过limit加上br的缓冲大小
+func readLimitedLine(br *bufio.Reader, limit int) (string, error) {
+	var line []byte
+	for {
+		chunk, err := br.ReadSlice('\n')
+		line = append(line, chunk...)
+		tooLong := limit > 0 && len(bytes.TrimRight(line, "\r\n")) > limit
+		if err == bufio.ErrBufferFull && !tooLong {
+			continue
+		}
+		if tooLong {
+			for err == bufio.ErrBufferFull {
+				_, err = br.ReadSlice('\n')
+			}
+			if err != nil && err != io.EOF {
+				return "", err
+			}
+			return "", fmt.Errorf("%w: more than %d bytes", terminal.ErrInputTooLong, limit)
+		}
+		return string(line), err
+	}
+}
+
+// parseLineAnswer 将简单模式下读到的一行解析为对应类型的答案
+func parseLineAnswer(q Question, line string) (interface{}, error) {
+	switch q.kind() {
+	case TypePassword:
+		return line, nil
+	case TypeConfirm:
+		if strings.TrimSpace(line) == "" {
+			if q.Default == "" {
+				return nil, fmt.Errorf("please answer yes or no")
+			}
+			line = q.Default
+		}
+		return utils.ParseBool(line)
+	case TypeSelect:
+		token := strings.TrimSpace(line)
+		if token == "" {
+			if q.Default == "" {
+				return nil, fmt.Errorf("please choose an option")
+			}
+			token = q.Default
+		}
+		if i, ok := q.aliases[token]; ok {
+			return q.Options[i], nil
+		}
+		option, ok := resolveOption(q.Options, token)
+		if !ok {
+			return nil, fmt.Errorf("invalid choice %q", token)
+		}
+		return option, nil
+	case TypeMultiSelect:
+		tokens := splitDefault(line)
+		if strings.TrimSpace(line) == "" {
+			tokens = splitDefault(q.Default)
+		}
+		selected := []string{}
+		for _, token := range tokens {
+			option, ok := resolveOption(q.Options, token)
+			if !ok {
+				return nil, fmt.Errorf("invalid choice %q", token)
+			}
+			selected = append(selected, option)
+		}
+		return selected, nil
+	default:
+		if line == "" {
+			return q.Default, nil
+		}
+		return line, nil
+	}
+}
+
+// resolveOption 按序号（从1开始）或完整的选项文本查找选项
+func resolveOption(options []string, token string) (string, bool) {
+	index, ok := resolveOptionIndex(options, token)
+	if !ok {
+		return "", false
+	}
+	return options[index], true
+}
+
+// resolveOptionIndex 与resolveOption相同，但返回选项的下标（从0开始）
+func resolveOptionIndex(options []string, token string) (int, bool) {
+	if n, err := strconv.Atoi(token); err == nil {
+		if _, ok := utils.OptionAt(options, n-1); ok {
+			return n - 1, true
+		}
+		return -1, false
+	}
+	return utils.OptionIndex(options, token)
+}
+
+// splitDefault 拆分逗号分隔的多选默认值，忽略空项
+func splitDefault(s string) []string {
+	var parts []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
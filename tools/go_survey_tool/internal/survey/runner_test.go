@@ -0,0 +1,306 @@
+package survey_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// newLineRunner 创建使用简单模式的Runner，input为预置的输入
+func newLineRunner(input string) (*survey.Runner, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return survey.NewRunner(survey.WithStdio(strings.NewReader(input), out, out)), out
+}
+
+func TestRunnerAskLineMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		question survey.Question
+		input    string
+		expected interface{}
+	}{
+		{"Input typed", survey.Question{Message: "Name?"}, "Alice\n", "Alice"},
+		{"Input default", survey.Question{Message: "Name?", Default: "Bob"}, "\n", "Bob"},
+		{"Password", survey.Question{Type: survey.TypePassword, Message: "Password"}, "s3cret\n", "s3cret"},
+		{"Confirm yes", survey.Question{Type: survey.TypeConfirm, Message: "Go?"}, "y\n", true},
+		{"Confirm default", survey.Question{Type: survey.TypeConfirm, Message: "Go?", Default: "no"}, "\n", false},
+		{"Select by number", survey.Question{Type: survey.TypeSelect, Message: "Color", Options: []string{"Red", "Blue"}}, "2\n", "Blue"},
+		{"Select by label", survey.Question{Type: survey.TypeSelect, Message: "Color", Options: []string{"Red", "Blue"}}, "Red\n", "Red"},
+		{"Select default", survey.Question{Type: survey.TypeSelect, Message: "Color", Options: []string{"Red", "Blue"}, Default: "Blue"}, "\n", "Blue"},
+		{"MultiSelect", survey.Question{Type: survey.TypeMultiSelect, Message: "Colors", Options: []string{"Red", "Blue", "Green"}}, "1, Green\n", []string{"Red", "Green"}},
+		{"Input without trailing newline", survey.Question{Message: "Name?"}, "Carol", "Carol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, _ := newLineRunner(tt.input)
+			answer, err := runner.Ask(tt.question)
+			if err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+			if !reflect.DeepEqual(answer, tt.expected) {
+				t.Errorf("Ask() = %#v, want %#v", answer, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunnerAskAllStripsBOM(t *testing.T) {
+	// 从Windows上保存的答案文件重定向输入时，第一行以BOM开头
+	runner, _ := newLineRunner("\ufeffAlice\nBlue\n")
+	answers, err := runner.AskAll(survey.CreateSurveyQuestions()[:2])
+	if err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+	if answers["name"] != "Alice" || answers["color"] != "Blue" {
+		t.Errorf("AskAll() = %q, want the BOM stripped from the first line", answers)
+	}
+
+	// 只有输入开头的BOM会被去掉
+	runner, _ = newLineRunner("Alice\n\ufeffBob\n")
+	first, _ := runner.Ask(survey.Question{Message: "A?"})
+	second, _ := runner.Ask(survey.Question{Message: "B?"})
+	if first != "Alice" || second != "\ufeffBob" {
+		t.Errorf("answers = %q, %q, want the later BOM kept", first, second)
+	}
+}
+
+func TestRunnerRepromptsOnInvalidInput(t *testing.T) {
+	runner, out := newLineRunner("\n   \nAlice\n")
+	answer, err := runner.Ask(survey.Question{Message: "Name?", Validate: utils.ValidateNotEmpty})
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if answer != "Alice" {
+		t.Errorf("Ask() = %v, want Alice", answer)
+	}
+	if got := strings.Count(out.String(), "X value cannot be empty"); got != 2 {
+		t.Errorf("expected 2 validation errors in output, got %d:\n%s", got, out.String())
+	}
+}
+
+func TestRunnerAskEOF(t *testing.T) {
+	runner, _ := newLineRunner("")
+	if _, err := runner.Ask(survey.Question{Message: "Name?"}); !errors.Is(err, io.EOF) {
+		t.Errorf("Ask() error = %v, want io.EOF", err)
+	}
+}
+
+func TestRunnerAskUnknownType(t *testing.T) {
+	runner, _ := newLineRunner("x\n")
+	if _, err := runner.Ask(survey.Question{Type: "slider", Message: "Level"}); err == nil {
+		t.Error("expected an error for an unknown question type")
+	}
+}
+
+func TestRunnerAskAll(t *testing.T) {
+	runner, _ := newLineRunner("Alice\n3\nn\n")
+	answers, err := runner.AskAll(survey.CreateSurveyQuestions())
+	if err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+
+	expected := map[string]interface{}{"name": "Alice", "color": "Green", "confirm": false}
+	if !reflect.DeepEqual(answers, expected) {
+		t.Errorf("AskAll() = %v, want %v", answers, expected)
+	}
+}
+
+func TestAskPasswordConfirmed(t *testing.T) {
+	t.Run("Match", func(t *testing.T) {
+		runner, _ := newLineRunner("hunter2\nhunter2\n")
+		password, err := runner.AskPasswordConfirmed("Password", nil)
+		if err != nil || password != "hunter2" {
+			t.Errorf("AskPasswordConfirmed() = %q, %v", password, err)
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		runner, _ := newLineRunner("hunter2\nhunter3\n")
+		if _, err := runner.AskPasswordConfirmed("Password", nil); !errors.Is(err, survey.ErrPasswordMismatch) {
+			t.Errorf("AskPasswordConfirmed() error = %v, want ErrPasswordMismatch", err)
+		}
+	})
+
+	t.Run("Weak password is re-prompted", func(t *testing.T) {
+		validate := utils.ValidatePasswordStrength(utils.StrengthOpts{MinLength: 6})
+		runner, out := newLineRunner("abc\nabcdef\nabcdef\n")
+		password, err := runner.AskPasswordConfirmed("Password", validate)
+		if err != nil || password != "abcdef" {
+			t.Errorf("AskPasswordConfirmed() = %q, %v", password, err)
+		}
+		if !strings.Contains(out.String(), "at least 6 characters") {
+			t.Errorf("expected strength error in output:\n%s", out.String())
+		}
+	})
+}
+
+func TestAskInputWithCombinedValidators(t *testing.T) {
+	validate := utils.AllValidators(utils.ValidateNotEmpty, utils.ValidateLength(3, 0))
+	runner, out := newLineRunner("\nab\nabc\n")
+
+	answer, err := runner.AskInput("Code?", "", validate)
+	if err != nil {
+		t.Fatalf("AskInput() error = %v", err)
+	}
+	if answer != "abc" {
+		t.Errorf("AskInput() = %q, want abc", answer)
+	}
+	for _, want := range []string{"X value cannot be empty", "X value must be at least 3 characters"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunnerAskDefaultFromEnv(t *testing.T) {
+	t.Setenv("SURVEY_TEST_REGION", "eu-west")
+	runner, out := newLineRunner("\n")
+
+	answer, err := runner.Ask(survey.Question{Message: "Region?", Default: "us-east", DefaultEnv: "SURVEY_TEST_REGION"})
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if answer != "eu-west" {
+		t.Errorf("Ask() = %v, want eu-west", answer)
+	}
+	if !strings.Contains(out.String(), "(eu-west)") {
+		t.Errorf("prompt should show the env default:\n%s", out.String())
+	}
+}
+
+func TestRunnerAskAllWhen(t *testing.T) {
+	questions := []survey.Question{
+		{Name: "has_pet", Type: survey.TypeConfirm, Message: "Do you have a pet?"},
+		{Name: "pet_name", Message: "Pet's name?", When: func(answers map[string]interface{}) bool {
+			return answers["has_pet"] == true
+		}},
+		{Name: "city", Message: "City?"},
+	}
+
+	t.Run("Gate closed", func(t *testing.T) {
+		runner, out := newLineRunner("n\nParis\n")
+		answers, err := runner.AskAll(questions)
+		if err != nil {
+			t.Fatalf("AskAll() error = %v", err)
+		}
+		expected := map[string]interface{}{"has_pet": false, "city": "Paris"}
+		if !reflect.DeepEqual(answers, expected) {
+			t.Errorf("AskAll() = %v, want %v", answers, expected)
+		}
+		if strings.Contains(out.String(), "Pet's name?") {
+			t.Errorf("dependent question should not be shown:\n%s", out.String())
+		}
+	})
+
+	t.Run("Gate open", func(t *testing.T) {
+		runner, _ := newLineRunner("y\nRex\nParis\n")
+		answers, err := runner.AskAll(questions)
+		if err != nil {
+			t.Fatalf("AskAll() error = %v", err)
+		}
+		if answers["pet_name"] != "Rex" {
+			t.Errorf("pet_name = %v, want Rex", answers["pet_name"])
+		}
+	})
+}
+
+func TestRunnerNormalizeAnswers(t *testing.T) {
+	var validated []string
+	validate := func(s string) error {
+		validated = append(validated, s)
+		if s != "New York" {
+			return errors.New("unknown city")
+		}
+		return nil
+	}
+	out := &bytes.Buffer{}
+	runner := survey.NewRunner(
+		survey.WithStdio(strings.NewReader("  New \t  York  \n"), out, out),
+		survey.WithNormalizeAnswers(),
+	)
+
+	answer, err := runner.Ask(survey.Question{Message: "City?", Validate: validate})
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if answer != "New York" {
+		t.Errorf("Ask() = %q, want normalized answer", answer)
+	}
+	if !reflect.DeepEqual(validated, []string{"New York"}) {
+		t.Errorf("validator saw %q, want the normalized value", validated)
+	}
+
+	t.Run("Passwords untouched", func(t *testing.T) {
+		runner := survey.NewRunner(survey.WithStdio(strings.NewReader(" pass word \n"), out, out), survey.WithNormalizeAnswers())
+		answer, err := runner.Ask(survey.Question{Type: survey.TypePassword, Message: "Password"})
+		if err != nil || answer != " pass word " {
+			t.Errorf("Ask() = %q, %v, want the password unchanged", answer, err)
+		}
+	})
+}
+
+func TestRunnerAskAllQuestionError(t *testing.T) {
+	runner, _ := newLineRunner("Alice\n")
+	questions := []survey.Question{
+		{Name: "name", Message: "Name?"},
+		{Name: "email", Message: "Email?"},
+	}
+
+	answers, err := runner.AskAll(questions)
+	var qerr *survey.QuestionError
+	if !errors.As(err, &qerr) {
+		t.Fatalf("AskAll() error = %v, want a *QuestionError", err)
+	}
+	if qerr.Name != "email" {
+		t.Errorf("QuestionError.Name = %q, want email", qerr.Name)
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("error should unwrap to io.EOF, got %v", err)
+	}
+	if got := err.Error(); got != `question "email": EOF` {
+		t.Errorf("Error() = %q", got)
+	}
+	if answers["name"] != "Alice" {
+		t.Errorf("answers = %v, want the answers collected before the failure", answers)
+	}
+}
+
+func TestQuestionErrorMessage(t *testing.T) {
+	err := &survey.QuestionError{Name: "email", Err: errors.New("value cannot be empty")}
+	if got := err.Error(); got != `question "email": value cannot be empty` {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestAskLineRejectsHugePaste(t *testing.T) {
+	// 误粘贴的1MB单行被整行丢弃，重新询问后使用下一行
+	runner, out := newLineRunner(strings.Repeat("x", 1<<20) + "\nAlice\n")
+	answer, err := runner.Ask(survey.Question{Name: "name", Message: "Name?"})
+	if err != nil {
+		t.Fatalf("Ask() error = %v", err)
+	}
+	if answer != "Alice" {
+		t.Errorf("Ask() = %.20q, want Alice", answer)
+	}
+	if !strings.Contains(out.String(), "X input too long: more than 65536 bytes") {
+		t.Errorf("output should explain the rejected line:\n%.200s", out.String())
+	}
+
+	runner, _ = newLineRunner("abcdef\n")
+	runner.MaxInputLength = 5
+	if _, err := runner.Ask(survey.Question{Name: "name", Message: "Name?"}); !errors.Is(err, io.EOF) {
+		t.Errorf("Ask() with only an over-limit line = %v, want io.EOF after rejecting it", err)
+	}
+	runner, _ = newLineRunner("abcde\r\n")
+	runner.MaxInputLength = 5
+	if answer, err := runner.Ask(survey.Question{Name: "name", Message: "Name?"}); err != nil || answer != "abcde" {
+		t.Errorf("Ask() at the limit = %v, %v, want abcde", answer, err)
+	}
+}
@@ -0,0 +1,57 @@
+package survey
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// secretAnswer 按SecretEnv、SecretFile的顺序查找password问题的值，找到时ok为true，不再提问。
+// 环境变量的值原样使用；文件内容去掉首尾空白，文件能被其他用户读取时在Err上给出警告
+func (r *Runner) secretAnswer(q Question) (value string, ok bool, err error) {
+	if q.SecretEnv != "" {
+		if value := os.Getenv(q.SecretEnv); value != "" {
+			return value, true, nil
+		}
+	}
+	if q.SecretFile == "" {
+		return "", false, nil
+	}
+
+	f, err := os.Open(q.SecretFile)
+	if err != nil {
+		return "", false, fmt.Errorf("读取密码文件失败: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", false, fmt.Errorf("读取密码文件失败: %w", err)
+	}
+	// Windows上的权限位不反映实际的访问控制，不做检查
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o004 != 0 {
+		fmt.Fprintf(r.Err, "Warning: secret file %s is readable by other users, run chmod 600 on it\n", q.SecretFile)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", false, fmt.Errorf("读取密码文件失败: %w", err)
+	}
+	return strings.TrimSpace(utils.StripBOM(string(data))), true, nil
+}
+
+// secretResult 校验从SecretEnv或SecretFile得到的答案，没有终端可以重新输入，校验失败时直接返回错误
+func (r *Runner) secretResult(q Question, answer string, err error) (interface{}, error) {
+	if err == nil && q.Validate != nil {
+		err = q.Validate(answer)
+	}
+	if err != nil {
+		r.log(LevelWarn, "question failed", "name", q.Name, "error", err)
+		return nil, err
+	}
+	r.log(LevelInfo, "answer received", "name", q.Name, "value", loggedAnswer(q, answer))
+	r.writeTranscript(q, answer)
+	return answer, nil
+}
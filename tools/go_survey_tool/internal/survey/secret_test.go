@@ -0,0 +1,98 @@
+package survey
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeSecret 在临时目录中写入权限为perm的密码文件
+func writeSecret(t *testing.T, content string, perm os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAskSecretSources(t *testing.T) {
+	t.Setenv("TEST_SECRET", "from-env")
+	t.Setenv("TEST_SECRET_EMPTY", "")
+	file := writeSecret(t, "  from-file\n", 0600)
+
+	tests := []struct {
+		name     string
+		question Question
+		input    string
+		expected string
+	}{
+		{"Env", Question{SecretEnv: "TEST_SECRET"}, "", "from-env"},
+		{"File trimmed", Question{SecretFile: file}, "", "from-file"},
+		{"Env before file", Question{SecretEnv: "TEST_SECRET", SecretFile: file}, "", "from-env"},
+		{"Empty env falls back to file", Question{SecretEnv: "TEST_SECRET_EMPTY", SecretFile: file}, "", "from-file"},
+		{"Empty env prompts", Question{SecretEnv: "TEST_SECRET_EMPTY"}, "typed\n", "typed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.question
+			q.Name, q.Type, q.Message = "token", TypePassword, "Token?"
+			out := &bytes.Buffer{}
+			r := NewRunner(WithStdio(strings.NewReader(tt.input), out, out))
+
+			answer, err := r.Ask(q)
+			if err != nil {
+				t.Fatalf("Ask() error = %v", err)
+			}
+			if answer != tt.expected {
+				t.Errorf("Ask() = %q, want %q", answer, tt.expected)
+			}
+			if tt.input == "" && out.Len() != 0 {
+				t.Errorf("secret answers should not prompt, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestAskSecretFileErrors(t *testing.T) {
+	q := Question{Name: "token", Type: TypePassword, Message: "Token?"}
+
+	t.Run("Missing file", func(t *testing.T) {
+		q := q
+		q.SecretFile = filepath.Join(t.TempDir(), "missing")
+		if _, err := NewRunner(WithStdio(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{})).Ask(q); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Ask() error = %v, want os.ErrNotExist", err)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		q := q
+		q.SecretFile = writeSecret(t, "short", 0600)
+		q.Validate = func(s string) error { return errors.New("too short") }
+		if _, err := NewRunner(WithStdio(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{})).Ask(q); err == nil || err.Error() != "too short" {
+			t.Errorf("Ask() error = %v, want the validation error", err)
+		}
+	})
+}
+
+func TestAskSecretFileWorldReadable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not checked on Windows")
+	}
+	q := Question{Name: "token", Type: TypePassword, Message: "Token?", SecretFile: writeSecret(t, "s3cret", 0644)}
+	errOut := &bytes.Buffer{}
+	answer, err := NewRunner(WithStdio(strings.NewReader(""), &bytes.Buffer{}, errOut)).Ask(q)
+	if err != nil || answer != "s3cret" {
+		t.Fatalf("Ask() = %v, %v, want s3cret", answer, err)
+	}
+	if !strings.Contains(errOut.String(), "readable by other users") {
+		t.Errorf("expected a permission warning, got %q", errOut.String())
+	}
+}
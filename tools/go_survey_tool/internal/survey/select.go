@@ -0,0 +1,375 @@
+package survey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	surveyv2 "github.com/AlecAivazis/survey/v2"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// SelectOption Select类提问（AskSelect、AskSelectFunc、AskSelectGrouped）的显示选项
+type SelectOption func(*selectConfig)
+
+// selectConfig Select类提问的显示配置，零值为survey的默认显示
+type selectConfig struct {
+	hideFooter bool // 不显示"[Use arrows to move, ...]"操作提示
+
+	showHints bool     // 在选项下方显示操作提示行，见WithHints
+	hints     []string // 提示行的内容，为空时使用各提问类型的默认提示
+
+	ctx            context.Context // 不为nil时，取消后提问立即结束，见AskSelectTimeoutCtx
+	timeoutControl *TimeoutControl // 不为nil时可以在提问期间延长超时
+
+	reflow bool // 终端大小变化时按新宽度重绘，见WithReflowOnResize
+
+	strictOptions bool // 选项有重复时返回ErrDuplicateOptions，见WithStrictOptions
+
+	minCols, minRows int // 交互式提问前要求的最小终端大小，见WithMinSize
+
+	initialFilter string // 提问开始时已输入的筛选文字，见WithInitialFilter
+
+	filterDescriptions bool // 筛选时同时匹配选项的描述，见WithFilterDescriptions
+}
+
+// newSelectConfig 应用所有选项
+func newSelectConfig(opts []SelectOption) selectConfig {
+	var cfg selectConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithHideFooter 不显示问题后面的"[Use arrows to move, type to filter]"操作提示，
+// 适合输出需要被捕获或录屏的场景
+func WithHideFooter() SelectOption {
+	return func(c *selectConfig) {
+		c.hideFooter = true
+	}
+}
+
+// WithHints 在选项下方单独显示一行操作提示，例如"[↑/↓ move · enter select]"，代替问题后面的默认提示。
+// 不带参数时按提问类型显示默认的提示；符号和颜色跟随当前的Symbols和ColorEnabled。
+// 同时使用WithHideFooter时不显示
+func WithHints(hints ...string) SelectOption {
+	return func(c *selectConfig) {
+		c.showHints = true
+		c.hints = hints
+	}
+}
+
+// WithReflowOnResize 终端大小变化时按新的宽度重新绘制整个提示，过长的行截断为一行。
+// 只对自绘菜单（AskSelectGrouped）生效，survey库绘制的提示无法在提问期间重绘
+func WithReflowOnResize() SelectOption {
+	return func(c *selectConfig) {
+		c.reflow = true
+	}
+}
+
+// WithMinSize 交互式提问前要求终端至少有cols列、rows行（<=0表示不限制）。
+// 终端太小时提示用户调整窗口大小，等到足够大后再显示提问；简单模式下不检查
+func WithMinSize(cols, rows int) SelectOption {
+	return func(c *selectConfig) {
+		c.minCols, c.minRows = cols, rows
+	}
+}
+
+// WithInitialFilter 提问开始时筛选文字已经是s，选项列表一开始就只显示匹配的选项，
+// 之后可以照常继续输入或退格修改，适合重新打开选择时恢复上次的筛选。
+// 对AskSelect等单选和AskSelectPaged生效，s中的控制字符被忽略；简单模式下没有筛选，不受影响。
+// Windows上survey库直接读取控制台事件，AskSelect等不支持，只对AskSelectPaged生效
+func WithInitialFilter(s string) SelectOption {
+	return func(c *selectConfig) {
+		c.initialFilter = strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+}
+
+// WithFilterDescriptions 交互模式下输入的筛选文字也匹配AskSelectRich选项的Description，
+// 默认只匹配选项文本
+func WithFilterDescriptions() SelectOption {
+	return func(c *selectConfig) {
+		c.filterDescriptions = true
+	}
+}
+
+// WithStrictOptions 选项中有重复的标签时不提问，返回ErrDuplicateOptions。
+// 默认只输出警告，并给重复的选项加上编号以便区分
+func WithStrictOptions() SelectOption {
+	return func(c *selectConfig) {
+		c.strictOptions = true
+	}
+}
+
+// survey模板中的操作提示部分，WithHideFooter时从模板中删除
+const (
+	selectFooter      = `{{- "  "}}{{- color "cyan"}}[Use arrows to move, type to filter{{- if and .Help (not .ShowHelp)}}, {{ .Config.HelpInput }} for more help{{end}}]{{color "reset"}}`
+	multiSelectFooter = `{{- "  "}}{{- color "cyan"}}[Use arrows to move, space to select,{{- if not .Config.RemoveSelectAll }} <right> to all,{{end}}{{- if not .Config.RemoveSelectNone }} <left> to none,{{end}} type to filter{{- if and .Help (not .ShowHelp)}}, {{ .Config.HelpInput }} for more help{{end}}]{{color "reset"}}`
+)
+
+// surveyDescription survey模板中选项描述的部分，显示为" - 描述"；
+// Select的模板中把它换成descriptionColumn排好的描述列
+const surveyDescription = ` - {{color "cyan"}}{{ $.GetDescription .CurrentOpt }}`
+
+// selectQuestionTemplate Select使用的survey模板，选项描述直接输出descriptionColumn的结果
+var selectQuestionTemplate = strings.Replace(surveyv2.SelectQuestionTemplate, surveyDescription, `{{ $.GetDescription .CurrentOpt }}`, 1)
+
+// 去掉操作提示的survey模板
+var (
+	selectTemplateNoFooter      = strings.Replace(selectQuestionTemplate, selectFooter, "", 1)
+	multiSelectTemplateNoFooter = strings.Replace(surveyv2.MultiSelectQuestionTemplate, multiSelectFooter, "", 1)
+)
+
+// selectTemplate 返回Select使用的模板
+func (c selectConfig) selectTemplate() string {
+	if line := c.hintLine(0, arrowsHint(), "enter select", "type to filter"); line != "" {
+		return selectTemplateNoFooter + hintTemplate(line)
+	}
+	if c.hideFooter {
+		return selectTemplateNoFooter
+	}
+	return selectQuestionTemplate
+}
+
+// multiSelectTemplate 返回MultiSelect使用的模板
+func (c selectConfig) multiSelectTemplate() string {
+	if line := c.hintLine(0, arrowsHint(), "space toggle", "enter confirm", "type to filter"); line != "" {
+		return multiSelectTemplateNoFooter + hintTemplate(line)
+	}
+	if c.hideFooter {
+		return multiSelectTemplateNoFooter
+	}
+	return surveyv2.MultiSelectQuestionTemplate
+}
+
+// hintLine 返回WithHints的提示行，没有设置提示时使用defaults；未启用或WithHideFooter时返回""
+// width > 0时截断到width列
+func (c selectConfig) hintLine(width int, defaults ...string) string {
+	if !c.showHints || c.hideFooter {
+		return ""
+	}
+	hints := c.hints
+	if len(hints) == 0 {
+		hints = defaults
+	}
+	return utils.FormatHints(hints, width)
+}
+
+// arrowsHint 返回移动光标的提示，当前符号集没有设置方向键时使用ASCII
+func arrowsHint() string {
+	arrows := utils.CurrentSymbols().Arrows
+	if arrows == "" {
+		arrows = utils.ASCIISymbols.Arrows
+	}
+	return arrows + " move"
+}
+
+// hintTemplate 返回在选项下方显示line的模板片段，回答后不再显示
+func hintTemplate(line string) string {
+	return `{{- if not .ShowAnswer}}{{` + strconv.Quote(line+"\n") + `}}{{end}}`
+}
+
+// templateMu 保护对survey全局模板变量的修改
+var templateMu sync.Mutex
+
+// withTemplate 在fn运行期间把survey的全局模板*target替换为tmpl
+// survey不支持按提示设置模板，每次重绘都读取全局变量，只能在整个提问期间替换它；
+// 锁在fn返回前一直持有，因此整个进程中使用这些模板的单选、多选和输入提示同一时间只能有一个，
+// 不同终端上的并发调查会在这里排队，简单模式的提问不受影响
+func withTemplate(target *string, tmpl string, fn func() error) error {
+	templateMu.Lock()
+	defer templateMu.Unlock()
+
+	old := *target
+	*target = tmpl
+	defer func() { *target = old }()
+	return fn()
+}
+
+// AskSelect 使用标准输入输出询问单选
+func AskSelect(message string, options []string, opts ...SelectOption) (string, error) {
+	return NewRunner().AskSelect(message, options, opts...)
+}
+
+// AskSelect 询问单选，返回选中的选项
+func (r *Runner) AskSelect(message string, options []string, opts ...SelectOption) (string, error) {
+	answer, err := r.ask(Question{Type: TypeSelect, Message: message, Options: options}, newSelectConfig(opts))
+	if err != nil {
+		return "", fmt.Errorf("选择失败: %w", err)
+	}
+	return answer.(string), nil
+}
+
+// AskSelectWithChanged 使用标准输入输出询问带默认值的单选，并报告选择是否改变
+func AskSelectWithChanged(message string, options []string, def string, opts ...SelectOption) (string, bool, error) {
+	return NewRunner().AskSelectWithChanged(message, options, def, opts...)
+}
+
+// AskSelectWithChanged 以def为默认值询问单选，changed报告选中项是否与def不同，
+// 调用方可以在选择没有改变时跳过后续操作，例如修改配置的流程
+func (r *Runner) AskSelectWithChanged(message string, options []string, def string, opts ...SelectOption) (value string, changed bool, err error) {
+	answer, err := r.ask(Question{Type: TypeSelect, Message: message, Options: options, Default: def}, newSelectConfig(opts))
+	if err != nil {
+		return "", false, fmt.Errorf("选择失败: %w", err)
+	}
+	value = answer.(string)
+	return value, value != def, nil
+}
+
+// AskMultiSelect 使用标准输入输出询问多选
+func AskMultiSelect(message string, options []string, opts ...SelectOption) ([]string, error) {
+	return NewRunner().AskMultiSelect(message, options, opts...)
+}
+
+// AskMultiSelect 询问多选，返回去重后按选项原始顺序排列的选中项
+func (r *Runner) AskMultiSelect(message string, options []string, opts ...SelectOption) ([]string, error) {
+	answer, err := r.ask(Question{Type: TypeMultiSelect, Message: message, Options: options}, newSelectConfig(opts))
+	if err != nil {
+		return nil, fmt.Errorf("选择失败: %w", err)
+	}
+	return answer.([]string), nil
+}
+
+// AskMultiSelectIndices 使用标准输入输出询问多选，返回选中项的序号
+func AskMultiSelectIndices(message string, options []string, opts ...SelectOption) ([]int, error) {
+	return NewRunner().AskMultiSelectIndices(message, options, opts...)
+}
+
+// AskMultiSelectIndices 与AskMultiSelect相同，但返回选中项在options中的序号（升序）
+// 选项有重复时按序号区分，选中第二个重复项时返回它自己的序号
+func (r *Runner) AskMultiSelectIndices(message string, options []string, opts ...SelectOption) ([]int, error) {
+	q := Question{Type: TypeMultiSelect, Message: message, Options: options}
+	cfg := newSelectConfig(opts)
+	labels, err := r.distinctOptions(q, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("选择失败: %w", err)
+	}
+	q.Options = labels
+	answer, err := r.ask(q, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("选择失败: %w", err)
+	}
+	return selectedIndices(labels, answer.([]string)), nil
+}
+
+// selectedIndices 返回selected中各项在options中的序号，按升序排列且不重复
+// options中有重复的选项时只取第一个
+func selectedIndices(options, selected []string) []int {
+	chosen := make(map[string]bool, len(selected))
+	for _, s := range selected {
+		chosen[s] = true
+	}
+	indices := []int{}
+	for i, option := range options {
+		if chosen[option] {
+			indices = append(indices, i)
+			delete(chosen, option)
+		}
+	}
+	return indices
+}
+
+// ErrDuplicateOptions 使用WithStrictOptions时选项中有重复的标签
+var ErrDuplicateOptions = errors.New("duplicate options")
+
+// distinctOptions 返回提问时使用的选项标签。选项有重复时，严格模式下返回ErrDuplicateOptions，
+// 否则输出警告并给第二个及之后的重复选项加上" (2)"这样的编号，使答案能对应到唯一的序号
+func (r *Runner) distinctOptions(q Question, cfg selectConfig) ([]string, error) {
+	duplicates := duplicateOptions(q.Options)
+	if len(duplicates) == 0 {
+		return q.Options, nil
+	}
+	quoted := make([]string, len(duplicates))
+	for i, option := range duplicates {
+		quoted[i] = strconv.Quote(option)
+	}
+	list := strings.Join(quoted, ", ")
+	if cfg.strictOptions {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicateOptions, list)
+	}
+	r.log(LevelWarn, "duplicate options", "name", q.Name, "options", list)
+	fmt.Fprintf(r.Err, "Warning: %q has duplicate options %s, later copies are numbered\n", q.Message, list)
+
+	// 编号后的标签不能与任何已有选项或其他标签相同，否则答案会换回错误的选项
+	used := make(map[string]bool, len(q.Options))
+	for _, option := range q.Options {
+		used[option] = true
+	}
+	labels := make([]string, len(q.Options))
+	seen := make(map[string]bool, len(q.Options))
+	next := make(map[string]int)
+	for i, option := range q.Options {
+		labels[i] = option
+		if !seen[option] {
+			seen[option] = true
+			continue
+		}
+		n := next[option]
+		if n == 0 {
+			n = 2
+		}
+		for used[fmt.Sprintf("%s (%d)", option, n)] {
+			n++
+		}
+		labels[i] = fmt.Sprintf("%s (%d)", option, n)
+		used[labels[i]] = true
+		next[option] = n + 1
+	}
+	return labels, nil
+}
+
+// duplicateOptions 按第一次出现的顺序返回出现了不止一次的选项
+func duplicateOptions(options []string) []string {
+	count := make(map[string]int, len(options))
+	var duplicates []string
+	for _, option := range options {
+		count[option]++
+		if count[option] == 2 {
+			duplicates = append(duplicates, option)
+		}
+	}
+	return duplicates
+}
+
+// originalOptions 把用labels提问得到的答案换回options中对应序号的选项，
+// labels与options相同时答案不变
+func originalOptions(options, labels []string, answer interface{}) interface{} {
+	if len(labels) != len(options) {
+		return answer
+	}
+	switch v := answer.(type) {
+	case string:
+		if i, ok := utils.OptionIndex(labels, v); ok {
+			return options[i]
+		}
+	case []string:
+		// 答案已经按labels去重排序，labels互不相同，可以逐个换回
+		result := make([]string, len(v))
+		for i, index := range selectedIndices(labels, v) {
+			result[i] = options[index]
+		}
+		return result
+	}
+	return answer
+}
+
+// canonicalSelection 对多选答案去重并按选项的原始顺序排列，保证结果可以直接比较
+func canonicalSelection(options, selected []string) []string {
+	indices := selectedIndices(options, selected)
+	result := make([]string, len(indices))
+	for i, index := range indices {
+		result[i] = options[index]
+	}
+	return result
+}
@@ -6,12 +6,15 @@ import (
 
 	surveyv2 "github.com/AlecAivazis/survey/v2"
 	"golang.org/x/term"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
 )
 
 // withTerminalMode 临时调整终端模式以兼容survey库
 func withTerminalMode(fn func() error) error {
 	fd := int(os.Stdin.Fd())
-	if !term.IsTerminal(fd) {
+	if !terminal.IO(os.Stdin, nil, nil).StdinTTY {
 		// 不是终端，直接运行
 		return fn()
 	}
@@ -72,6 +75,18 @@ func SelectExample() error {
 		"选项 5: 退出",
 	}
 
+	// 终端无法正常进入raw模式时方向键不可用，改为输入编号
+	if terminal.IO(os.Stdin, nil, nil).StdinTTY {
+		if err := terminal.DiagnoseRawConflict(int(os.Stdin.Fd())); err != nil {
+			fmt.Printf("方向键可能无法使用（%v），改为输入编号\n", err)
+			index, err := AskSelectNumeric("请输入选项编号:", options)
+			if err != nil {
+				return err
+			}
+			return handleExampleChoice(options, index)
+		}
+	}
+
 	prompt := &surveyv2.Select{
 		Message: "请使用上下键选择一个选项:",
 		Options: options,
@@ -95,19 +110,32 @@ func SelectExample() error {
 		return fmt.Errorf("选择失败: %w", err)
 	}
 
+	index, ok := utils.OptionIndex(options, selected)
+	if !ok {
+		return fmt.Errorf("选择失败: unknown option %q", selected)
+	}
+	return handleExampleChoice(options, index)
+}
+
+// handleExampleChoice 输出并处理SelectExample中选中的选项，index为选项的下标
+func handleExampleChoice(options []string, index int) error {
+	selected, ok := utils.OptionAt(options, index)
+	if !ok {
+		return fmt.Errorf("选择失败: option %d out of range", index+1)
+	}
 	fmt.Printf("您选择了: %s\n", selected)
 
 	// 根据选择执行不同操作
-	switch selected {
-	case options[0]:
+	switch index {
+	case 0:
 		fmt.Println("执行红色相关操作...")
-	case options[1]:
+	case 1:
 		fmt.Println("执行蓝色相关操作...")
-	case options[2]:
+	case 2:
 		fmt.Println("执行绿色相关操作...")
-	case options[3]:
+	case 3:
 		fmt.Println("执行黄色相关操作...")
-	case options[4]:
+	case 4:
 		fmt.Println("退出程序")
 		return nil
 	}
@@ -118,4 +146,4 @@ func SelectExample() error {
 // RunArrowKeySelection 运行上下键选择演示
 func RunArrowKeySelection() error {
 	return SelectExample()
-}
\ No newline at end of file
+}
@@ -0,0 +1,43 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AskSelectFunc 使用标准输入输出询问选项动态加载的选择
+func AskSelectFunc(message string, source func() ([]string, error), opts ...SelectOption) (string, error) {
+	return NewRunner().AskSelectFunc(message, source, opts...)
+}
+
+// AskSelectFunc 调用source获取选项后询问选择，source运行期间显示加载动画
+// source失败时询问是否重试，选择重试则再次调用source，否则返回source的错误
+func (r *Runner) AskSelectFunc(message string, source func() ([]string, error), opts ...SelectOption) (string, error) {
+	for {
+		var options []string
+		err := r.withSpinner("Loading options", func() error {
+			var err error
+			options, err = source()
+			return err
+		})
+		if err == nil {
+			if len(options) == 0 {
+				return "", errors.New("no options to select from")
+			}
+			return r.AskSelect(message, options, opts...)
+		}
+
+		r.log(LevelWarn, "options source failed", "error", err)
+		retry, askErr := r.Ask(Question{
+			Type:    TypeConfirm,
+			Message: fmt.Sprintf("Failed to load options (%v). Retry?", err),
+			Default: "yes",
+		})
+		if askErr != nil {
+			return "", fmt.Errorf("选择失败: %w", askErr)
+		}
+		if !retry.(bool) {
+			return "", fmt.Errorf("加载选项失败: %w", err)
+		}
+	}
+}
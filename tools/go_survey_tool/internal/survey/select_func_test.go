@@ -0,0 +1,58 @@
+package survey_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// flakySource 返回前failures次调用失败、之后返回options的选项来源
+func flakySource(failures int, options []string, calls *int) func() ([]string, error) {
+	return func() ([]string, error) {
+		*calls++
+		if *calls <= failures {
+			return nil, errors.New("connection refused")
+		}
+		return options, nil
+	}
+}
+
+func TestAskSelectFuncRetriesAfterFailure(t *testing.T) {
+	calls := 0
+	runner, out := newLineRunner("\n2\n")
+
+	answer, err := runner.AskSelectFunc("Region?", flakySource(1, []string{"us-east", "eu-west"}, &calls))
+	if err != nil {
+		t.Fatalf("AskSelectFunc() error = %v", err)
+	}
+	if answer != "eu-west" {
+		t.Errorf("AskSelectFunc() = %q, want eu-west", answer)
+	}
+	if calls != 2 {
+		t.Errorf("source called %d times, want 2", calls)
+	}
+	if !strings.Contains(out.String(), "Failed to load options (connection refused). Retry?") {
+		t.Errorf("retry prompt not shown:\n%s", out.String())
+	}
+}
+
+func TestAskSelectFuncCancel(t *testing.T) {
+	calls := 0
+	runner, _ := newLineRunner("n\n")
+
+	_, err := runner.AskSelectFunc("Region?", flakySource(5, nil, &calls))
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("AskSelectFunc() error = %v, want the source error", err)
+	}
+	if calls != 1 {
+		t.Errorf("source called %d times, want 1", calls)
+	}
+}
+
+func TestAskSelectFuncNoOptions(t *testing.T) {
+	calls := 0
+	runner, _ := newLineRunner("")
+	if _, err := runner.AskSelectFunc("Region?", flakySource(0, nil, &calls)); err == nil {
+		t.Error("expected an error when the source returns no options")
+	}
+}
@@ -0,0 +1,451 @@
+package survey
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	surveyv2 "github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// goldenSelectOptions golden测试使用的选项
+var goldenSelectOptions = []string{"Red", "Blue", "Green"}
+
+// renderSurveyTemplate 渲染survey模板，返回不带颜色的布局输出，与终端宽度无关
+// survey会缓存编译后的模板，修改core.DisableColor不一定生效，所以使用布局输出
+func renderSurveyTemplate(t *testing.T, tmpl string, data interface{}) string {
+	t.Helper()
+	_, layout, err := core.RunTemplate(tmpl, data)
+	if err != nil {
+		t.Fatalf("RunTemplate() error = %v", err)
+	}
+	return layout
+}
+
+// promptConfig 与survey默认一致的图标配置
+func promptConfig() *surveyv2.PromptConfig {
+	return &surveyv2.PromptConfig{
+		PageSize:  7,
+		HelpInput: "?",
+		Icons: surveyv2.IconSet{
+			Question:       surveyv2.Icon{Text: "?"},
+			SelectFocus:    surveyv2.Icon{Text: ">"},
+			MarkedOption:   surveyv2.Icon{Text: "[x]"},
+			UnmarkedOption: surveyv2.Icon{Text: "[ ]"},
+		},
+	}
+}
+
+// checkGolden 比较输出与testdata中的golden文件，-update时重写文件
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("读取golden文件失败: %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("output does not match %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestSelectTemplateGolden(t *testing.T) {
+	data := surveyv2.SelectTemplateData{
+		Select:        surveyv2.Select{Message: "Pick a color:", Options: goldenSelectOptions},
+		PageEntries:   core.OptionAnswerList(goldenSelectOptions),
+		SelectedIndex: 1,
+		Config:        promptConfig(),
+	}
+
+	tests := []struct {
+		name   string
+		opts   []SelectOption
+		golden string
+	}{
+		{"Default", nil, "select_default.golden"},
+		{"Hide footer", []SelectOption{WithHideFooter()}, "select_hide_footer.golden"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := renderSurveyTemplate(t, newSelectConfig(tt.opts).selectTemplate(), data)
+			checkGolden(t, tt.golden, out)
+		})
+	}
+}
+
+func TestMultiSelectTemplateHideFooter(t *testing.T) {
+	data := surveyv2.MultiSelectTemplateData{
+		MultiSelect: surveyv2.MultiSelect{Message: "Pick colors:", Options: goldenSelectOptions},
+		PageEntries: core.OptionAnswerList(goldenSelectOptions),
+		Checked:     map[int]bool{0: true},
+		Config:      promptConfig(),
+	}
+
+	if out := renderSurveyTemplate(t, newSelectConfig(nil).multiSelectTemplate(), data); !strings.Contains(out, "[Use arrows") {
+		t.Fatalf("default template should contain the footer:\n%s", out)
+	}
+	out := renderSurveyTemplate(t, newSelectConfig([]SelectOption{WithHideFooter()}).multiSelectTemplate(), data)
+	if strings.Contains(out, "[Use arrows") {
+		t.Errorf("footer still rendered:\n%s", out)
+	}
+	if !strings.Contains(out, "? Pick colors:\n") || !strings.Contains(out, "[x]  Red") {
+		t.Errorf("question or options missing:\n%s", out)
+	}
+}
+
+func TestWithTemplateRestores(t *testing.T) {
+	old := surveyv2.SelectQuestionTemplate
+	var during string
+	withTemplate(&surveyv2.SelectQuestionTemplate, "custom", func() error {
+		during = surveyv2.SelectQuestionTemplate
+		return nil
+	})
+	if during != "custom" || surveyv2.SelectQuestionTemplate != old {
+		t.Errorf("template not swapped and restored (during=%q)", during)
+	}
+}
+
+func TestMenuRenderHideFooter(t *testing.T) {
+	m := newGroupedMenu([]Group{{Options: goldenSelectOptions}})
+	m.config = newSelectConfig([]SelectOption{WithHideFooter()})
+	if got := m.render("Pick a color:")[0]; got != "? Pick a color:" {
+		t.Errorf("menu header = %q, want no footer", got)
+	}
+}
+
+func TestPromptPrefixGolden(t *testing.T) {
+	config := promptConfig()
+	NewRunner(WithPromptPrefix("omnish>")).setIcons(&config.Icons)
+	data := surveyv2.SelectTemplateData{
+		Select:        surveyv2.Select{Message: "Pick a color:", Options: goldenSelectOptions},
+		PageEntries:   core.OptionAnswerList(goldenSelectOptions),
+		SelectedIndex: 1,
+		Config:        config,
+	}
+	checkGolden(t, "select_prompt_prefix.golden", renderSurveyTemplate(t, newSelectConfig(nil).selectTemplate(), data))
+}
+
+func TestPromptPrefixLineModeAndMenu(t *testing.T) {
+	out := &bytes.Buffer{}
+	r := NewRunner(WithStdio(strings.NewReader("Bob\n"), out, out), WithPromptPrefix("omnish>"))
+	if _, err := r.Ask(Question{Name: "name", Message: "Name?"}); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "omnish> Name?: " {
+		t.Errorf("line prompt = %q, want the custom prefix", out.String())
+	}
+
+	m := newGroupedMenu([]Group{{Options: goldenSelectOptions}})
+	m.config = newSelectConfig([]SelectOption{WithHideFooter()})
+	out.Reset()
+	if _, err := r.menuLoop(bufio.NewReader(strings.NewReader("\r")), out, "Pick a color:", m); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "omnish> Pick a color:\r\n") {
+		t.Errorf("menu header does not use the custom prefix:\n%q", out.String())
+	}
+}
+
+func TestCanonicalSelection(t *testing.T) {
+	options := []string{"Red", "Blue", "Green", "Blue"}
+	tests := []struct {
+		name     string
+		selected []string
+		expected []string
+		indices  []int
+	}{
+		{"Out of order", []string{"Green", "Red"}, []string{"Red", "Green"}, []int{0, 2}},
+		{"Duplicates", []string{"Blue", "Red", "Blue"}, []string{"Red", "Blue"}, []int{0, 1}},
+		{"None", nil, []string{}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canonicalSelection(options, tt.selected); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("canonicalSelection() = %q, want %q", got, tt.expected)
+			}
+			if got := selectedIndices(options, tt.selected); !reflect.DeepEqual(got, tt.indices) {
+				t.Errorf("selectedIndices() = %v, want %v", got, tt.indices)
+			}
+		})
+	}
+}
+
+func TestAskSelectWithChangedLineMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		def     string
+		value   string
+		changed bool
+	}{
+		{"Enter keeps the default", "\n", "Blue", "Blue", false},
+		{"Selecting the default", "2\n", "Blue", "Blue", false},
+		{"Selecting another option", "Green\n", "Blue", "Green", true},
+		{"No default", "1\n", "", "Red", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRunner(WithStdio(strings.NewReader(tt.input), &bytes.Buffer{}, &bytes.Buffer{}))
+			value, changed, err := r.AskSelectWithChanged("Color?", goldenSelectOptions, tt.def)
+			if err != nil {
+				t.Fatalf("AskSelectWithChanged() error = %v", err)
+			}
+			if value != tt.value || changed != tt.changed {
+				t.Errorf("AskSelectWithChanged() = (%q, %v), want (%q, %v)", value, changed, tt.value, tt.changed)
+			}
+		})
+	}
+}
+
+func TestAskMultiSelectIndicesLineMode(t *testing.T) {
+	out := &bytes.Buffer{}
+	r := NewRunner(WithStdio(strings.NewReader("3, 1, Green\n3,1\n"), out, out))
+	options := []string{"Red", "Blue", "Green"}
+
+	selected, err := r.AskMultiSelect("Colors", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(selected, []string{"Red", "Green"}) {
+		t.Errorf("AskMultiSelect() = %q, want [Red Green] in option order without duplicates", selected)
+	}
+
+	indices, err := r.AskMultiSelectIndices("Colors", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(indices, []int{0, 2}) {
+		t.Errorf("AskMultiSelectIndices() = %v, want [0 2]", indices)
+	}
+}
+
+func TestDuplicateOptions(t *testing.T) {
+	options := []string{"Red", "Blue", "Red"}
+
+	t.Run("Warning", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		r := NewRunner(WithStdio(strings.NewReader("3\n"), &out, &errOut))
+		got, err := r.AskSelect("Color?", options)
+		if err != nil {
+			t.Fatalf("AskSelect() error = %v", err)
+		}
+		if got != "Red" {
+			t.Errorf("AskSelect() = %q, want Red", got)
+		}
+		if !strings.Contains(errOut.String(), `Warning: "Color?" has duplicate options "Red"`) {
+			t.Errorf("missing duplicate warning, stderr %q", errOut.String())
+		}
+		if !strings.Contains(out.String(), "3. Red (2)") {
+			t.Errorf("the second Red should be numbered, output %q", out.String())
+		}
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		r := NewRunner(WithStdio(strings.NewReader("1\n"), &bytes.Buffer{}, &bytes.Buffer{}))
+		if _, err := r.AskSelect("Color?", options, WithStrictOptions()); !errors.Is(err, ErrDuplicateOptions) {
+			t.Errorf("AskSelect() error = %v, want ErrDuplicateOptions", err)
+		}
+	})
+
+	t.Run("Indices", func(t *testing.T) {
+		r := NewRunner(WithStdio(strings.NewReader("3\n"), &bytes.Buffer{}, &bytes.Buffer{}))
+		got, err := r.AskMultiSelectIndices("Colors?", options)
+		if err != nil {
+			t.Fatalf("AskMultiSelectIndices() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, []int{2}) {
+			t.Errorf("AskMultiSelectIndices() = %v, want [2]", got)
+		}
+	})
+
+	t.Run("Multiselect answer", func(t *testing.T) {
+		r := NewRunner(WithStdio(strings.NewReader("1,3\n"), &bytes.Buffer{}, &bytes.Buffer{}))
+		got, err := r.AskMultiSelect("Colors?", options)
+		if err != nil {
+			t.Fatalf("AskMultiSelect() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, []string{"Red", "Red"}) {
+			t.Errorf("AskMultiSelect() = %q, want both copies of Red", got)
+		}
+	})
+
+	t.Run("Numbered label already an option", func(t *testing.T) {
+		var out bytes.Buffer
+		r := NewRunner(WithStdio(strings.NewReader("3\n"), &out, &bytes.Buffer{}))
+		got, err := r.AskMultiSelectIndices("Colors?", []string{"Red", "Red", "Red (2)"})
+		if err != nil {
+			t.Fatalf("AskMultiSelectIndices() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, []int{2}) {
+			t.Errorf("AskMultiSelectIndices() = %v, want [2]", got)
+		}
+		if !strings.Contains(out.String(), "2. Red (3)") {
+			t.Errorf("the second Red should skip the existing label, output %q", out.String())
+		}
+
+		r = NewRunner(WithStdio(strings.NewReader("3\n"), &bytes.Buffer{}, &bytes.Buffer{}))
+		answer, err := r.AskSelect("Color?", []string{"Red", "Red", "Red (2)"})
+		if err != nil {
+			t.Fatalf("AskSelect() error = %v", err)
+		}
+		if answer != "Red (2)" {
+			t.Errorf("AskSelect() = %q, want Red (2)", answer)
+		}
+	})
+
+	t.Run("No duplicates", func(t *testing.T) {
+		var errOut bytes.Buffer
+		r := NewRunner(WithStdio(strings.NewReader("1\n"), &bytes.Buffer{}, &errOut))
+		if _, err := r.AskSelect("Color?", []string{"Red", "Blue"}, WithStrictOptions()); err != nil {
+			t.Fatalf("AskSelect() error = %v", err)
+		}
+		if errOut.Len() != 0 {
+			t.Errorf("unexpected warning %q", errOut.String())
+		}
+	})
+}
+
+// goldenWideOptions 比golden测试的宽度更长的选项
+var goldenWideOptions = []string{"Red", "A very long shade of blue", "绿色的很长的名字"}
+
+func TestSelectLineModeGolden(t *testing.T) {
+	r, out := NewTestRunner("2\n", 20)
+	got, err := r.AskSelect("Pick a color from the list:", goldenWideOptions)
+	if err != nil {
+		t.Fatalf("AskSelect() error = %v", err)
+	}
+	if got != goldenWideOptions[1] {
+		t.Errorf("AskSelect() = %q, want the full option %q", got, goldenWideOptions[1])
+	}
+	checkGolden(t, "select_line_width20.golden", out.String())
+}
+
+func TestMenuFrameGolden(t *testing.T) {
+	r, out := NewTestRunner("\x1b[B\r", 20)
+	m := newGroupedMenu([]Group{{Options: goldenWideOptions}})
+	if _, err := r.menuLoop(bufio.NewReader(r.In), out, "Pick a color:", m); err != nil {
+		t.Fatalf("menuLoop() error = %v", err)
+	}
+	checkGolden(t, "menu_width20.golden", out.String())
+}
+
+func TestHintLineGolden(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	old := utils.CurrentSymbols()
+	defer utils.SetSymbols(old)
+
+	data := surveyv2.SelectTemplateData{
+		Select:        surveyv2.Select{Message: "Pick a color:", Options: goldenSelectOptions},
+		PageEntries:   core.OptionAnswerList(goldenSelectOptions),
+		SelectedIndex: 1,
+		Config:        promptConfig(),
+	}
+	tests := []struct {
+		name    string
+		symbols utils.Symbols
+		suffix  string
+	}{
+		{"Unicode", utils.UnicodeSymbols, "unicode"},
+		{"ASCII", utils.ASCIISymbols, "ascii"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.SetSymbols(tt.symbols)
+			tmpl := newSelectConfig([]SelectOption{WithHints()}).selectTemplate()
+			checkGolden(t, "select_hints_"+tt.suffix+".golden", renderSurveyTemplate(t, tmpl, data))
+
+			// 固定宽度下自定义的提示被截断到一行
+			m := newGroupedMenu([]Group{{Options: goldenWideOptions}})
+			m.config = newSelectConfig([]SelectOption{WithHints(arrowsHint(), "enter select", "/ filter", "q quit")})
+			m.width = 30
+			checkGolden(t, "menu_hints_"+tt.suffix+"_width30.golden", strings.Join(m.render("Pick a color:"), "\n")+"\n")
+		})
+	}
+}
+
+func TestHintLineHideFooter(t *testing.T) {
+	cfg := newSelectConfig([]SelectOption{WithHints("q quit"), WithHideFooter()})
+	if got := cfg.hintLine(0); got != "" {
+		t.Errorf("hintLine() = %q, want nothing with WithHideFooter", got)
+	}
+	if cfg.selectTemplate() != selectTemplateNoFooter {
+		t.Error("selectTemplate() should have no footer or hint line with WithHideFooter")
+	}
+	m := newGroupedMenu([]Group{{Options: goldenSelectOptions}})
+	m.config = cfg
+	if lines := m.render("Pick a color:"); len(lines) != 1+len(goldenSelectOptions) {
+		t.Errorf("menu rendered %d lines, want no hint line:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+}
+
+func TestMultiSelectTemplateHints(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	data := surveyv2.MultiSelectTemplateData{
+		MultiSelect: surveyv2.MultiSelect{Message: "Pick colors:", Options: goldenSelectOptions},
+		PageEntries: core.OptionAnswerList(goldenSelectOptions),
+		Config:      promptConfig(),
+	}
+	out := renderSurveyTemplate(t, newSelectConfig([]SelectOption{WithHints("space toggle")}).multiSelectTemplate(), data)
+	if strings.Contains(out, "[Use arrows") {
+		t.Errorf("default footer still rendered with WithHints:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "[ ]  Green\n[space toggle]\n") {
+		t.Errorf("hint line should follow the options:\n%s", out)
+	}
+
+	data.ShowAnswer = true
+	data.Answer = "Red"
+	if out := renderSurveyTemplate(t, newSelectConfig([]SelectOption{WithHints("space toggle")}).multiSelectTemplate(), data); strings.Contains(out, "space toggle") {
+		t.Errorf("hint line shown after answering:\n%s", out)
+	}
+}
+
+func TestWaitForMinSize(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	// 管道没有终端大小，用覆盖值模拟20x5的小终端
+	terminal.SetWidthOverride(20)
+	terminal.SetHeightOverride(5)
+	defer terminal.SetWidthOverride(0)
+	defer terminal.SetHeightOverride(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cfg := newSelectConfig([]SelectOption{WithMinSize(40, 10)})
+	cfg.ctx = ctx
+	runner := NewRunner(WithStdio(strings.NewReader(""), w, w))
+	if err := runner.waitForSize(cfg); !errors.Is(err, context.Canceled) {
+		t.Errorf("waitForSize() error = %v, want context.Canceled", err)
+	}
+	w.Close()
+	var out bytes.Buffer
+	out.ReadFrom(r)
+	if !strings.Contains(out.String(), "20x5, need at least 40x10") || !strings.Contains(out.String(), "resize") {
+		t.Errorf("waitForSize() should ask to resize with both sizes, got %q", out.String())
+	}
+
+	if err := runner.waitForSize(newSelectConfig([]SelectOption{WithMinSize(20, 5)})); err != nil {
+		t.Errorf("waitForSize() error = %v for a large enough terminal", err)
+	}
+}
@@ -0,0 +1,49 @@
+package survey
+
+// QuestionsSkeleton survey-tool init输出的示例问题文件，每种问题类型各一个，
+// 可以直接用LoadQuestions读取
+const QuestionsSkeleton = `# Survey questions file, read by LoadQuestions.
+#
+# Each question has:
+#   name         key of the answer in the results (required, unique)
+#   type         input (default), password, confirm, select or multiselect
+#   message      text shown to the user (required)
+#   default      default answer; yes/no for confirm, comma separated for multiselect
+#   default_env  environment variable whose value, when set, replaces default
+#   options      choices for select and multiselect
+#   timeout      accept the default after this long without an answer, e.g. 30s
+#   secret_env   password only: use this environment variable instead of asking
+#   secret_file  password only: read the answer from this file (chmod 600) instead of asking
+
+questions:
+  - name: name
+    type: input
+    message: What is your name?
+    default_env: USER
+
+  - name: token
+    type: password
+    message: API token
+    secret_env: API_TOKEN
+
+  - name: color
+    type: select
+    message: Choose a color
+    options: [Red, Blue, Green]
+    default: Blue
+
+  - name: features
+    type: multiselect
+    message: Which features do you use?
+    options:
+      - Completion
+      - History
+      - Chat
+    default: Completion, History
+
+  - name: subscribe
+    type: confirm
+    message: Subscribe to updates?
+    default: "no"
+    timeout: 30s
+`
@@ -0,0 +1,46 @@
+package survey
+
+import (
+	"fmt"
+	"time"
+)
+
+// spinnerFrames 加载动画的各帧
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// spinnerInterval 加载动画的刷新间隔
+const spinnerInterval = 100 * time.Millisecond
+
+// clearLine 清除光标所在行
+const clearLine = "\x1b[2K"
+
+// withSpinner 运行fn，期间在终端上显示加载动画，fn返回后清除动画
+// 非交互模式下只输出一行提示
+func (r *Runner) withSpinner(message string, fn func() error) error {
+	if !r.interactive() {
+		fmt.Fprintf(r.Out, "%s...\n", message)
+		return fn()
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			fmt.Fprintf(r.Out, "\r%s %s...", spinnerFrames[i%len(spinnerFrames)], message)
+			select {
+			case <-done:
+				fmt.Fprint(r.Out, "\r"+clearLine)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	err := fn()
+	close(done)
+	<-stopped
+	return err
+}
@@ -0,0 +1,63 @@
+package survey
+
+import (
+	"sort"
+	"sync"
+)
+
+// ResultStore 按会话ID保存调查结果，可以被多个goroutine同时使用，
+// 例如服务端同时在多个终端上运行调查。零值可以直接使用。
+// 注意终端上的survey提示在进程内是依次进行的（见withTemplate），同时运行的调查会轮流提问
+type ResultStore struct {
+	mu      sync.RWMutex
+	results map[string]Result
+}
+
+// Put 保存sessionID的结果，已有结果时覆盖。保存的是副本，之后修改r不会影响已保存的结果
+func (s *ResultStore) Put(sessionID string, r Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.results == nil {
+		s.results = make(map[string]Result)
+	}
+	s.results[sessionID] = r.clone()
+}
+
+// Get 返回sessionID的结果的副本
+func (s *ResultStore) Get(sessionID string) (Result, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r, ok := s.results[sessionID]
+	if !ok {
+		return Result{}, false
+	}
+	return r.clone(), true
+}
+
+// All 返回所有结果的快照，按会话ID排序
+func (s *ResultStore) All() []Result {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.results))
+	for id := range s.results {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	all := make([]Result, len(ids))
+	for i, id := range ids {
+		all[i] = s.results[id].clone()
+	}
+	return all
+}
+
+// clone 复制Answers和Order，答案的值本身不复制
+func (res Result) clone() Result {
+	answers := make(map[string]interface{}, len(res.Answers))
+	for name, value := range res.Answers {
+		answers[name] = value
+	}
+	res.Answers = answers
+	res.Order = append([]string(nil), res.Order...)
+	return res
+}
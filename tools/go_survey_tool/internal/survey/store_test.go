@@ -0,0 +1,62 @@
+package survey_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestResultStore(t *testing.T) {
+	var store survey.ResultStore
+	if _, ok := store.Get("missing"); ok {
+		t.Error("Get() on an empty store should report false")
+	}
+
+	answers := map[string]interface{}{"name": "Alice"}
+	store.Put("b", survey.Result{Answers: answers, Order: []string{"name"}})
+	store.Put("a", survey.Result{Answers: map[string]interface{}{"name": "Bob"}})
+	answers["name"] = "changed"
+
+	got, ok := store.Get("b")
+	if !ok || got.Answers["name"] != "Alice" {
+		t.Errorf("Get() = %v, %v, want the answers as they were when Put", got, ok)
+	}
+	got.Answers["name"] = "changed again"
+	if again, _ := store.Get("b"); again.Answers["name"] != "Alice" {
+		t.Error("modifying a result from Get() changed the stored result")
+	}
+
+	all := store.All()
+	if len(all) != 2 || all[0].Answers["name"] != "Bob" || all[1].Answers["name"] != "Alice" {
+		t.Errorf("All() = %v, want both results ordered by session ID", all)
+	}
+}
+
+func TestResultStoreConcurrent(t *testing.T) {
+	var store survey.ResultStore
+	const workers, rounds = 8, 200
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				id := fmt.Sprintf("session-%d-%d", w, i%10)
+				store.Put(id, survey.Result{Answers: map[string]interface{}{"round": i}, QuestionCount: 1})
+				if r, ok := store.Get(id); !ok || r.QuestionCount != 1 {
+					t.Errorf("Get(%q) = %v, %v right after Put", id, r, ok)
+					return
+				}
+				_ = store.All()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if n := len(store.All()); n != workers*10 {
+		t.Errorf("All() returned %d results, want %d", n, workers*10)
+	}
+}
@@ -0,0 +1,24 @@
+package survey
+
+import "errors"
+
+// AskAllStreaming 使用标准输入输出运行调查，见Runner.AskAllStreaming
+func AskAllStreaming(questions []Question, out chan<- NamedAnswer) (map[string]interface{}, error) {
+	return NewRunner().AskAllStreaming(questions, out)
+}
+
+// AskAllStreaming 与AskAll相同，但每个答案通过验证后立即发送到out，
+// 界面可以随着回答逐步更新而不必等整个调查结束。被When跳过的问题不会发送。
+// 返回前（包括出错和被中断时）关闭out，且只关闭一次；发送会阻塞提问，out需要有接收方或足够的缓冲。
+// out为nil时返回错误。out的元素类型是NamedAnswer而不是匿名的struct{Name string; Value interface{}}，
+// 两者字段相同但不能互相赋值，调用方需要用make(chan NamedAnswer)创建通道
+func (r *Runner) AskAllStreaming(questions []Question, out chan<- NamedAnswer) (map[string]interface{}, error) {
+	if out == nil {
+		return nil, errors.New("AskAllStreaming: out channel is nil")
+	}
+	defer close(out)
+	return r.askAll(questions, map[string]interface{}{}, func(name string, answers map[string]interface{}) error {
+		out <- NamedAnswer{Name: name, Value: answers[name]}
+		return nil
+	})
+}
@@ -0,0 +1,84 @@
+package survey_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+var streamQuestions = []survey.Question{
+	{Name: "name", Message: "Name?"},
+	{Name: "admin", Type: survey.TypeConfirm, Message: "Admin?"},
+	{Name: "role", Message: "Role?", When: func(answers map[string]interface{}) bool {
+		return answers["admin"] == true
+	}},
+	{Name: "color", Type: survey.TypeSelect, Message: "Color?", Options: []string{"Red", "Blue"}},
+}
+
+// collect 在后台接收streamed中的所有答案，通道关闭后把结果发送到返回的通道
+func collect(streamed <-chan survey.NamedAnswer) <-chan []survey.NamedAnswer {
+	done := make(chan []survey.NamedAnswer, 1)
+	go func() {
+		var got []survey.NamedAnswer
+		for answer := range streamed {
+			got = append(got, answer)
+		}
+		done <- got
+	}()
+	return done
+}
+
+func TestAskAllStreaming(t *testing.T) {
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader("Bob\nn\n2\n"), &bytes.Buffer{}, &bytes.Buffer{}))
+	streamed := make(chan survey.NamedAnswer)
+	done := collect(streamed)
+
+	answers, err := r.AskAllStreaming(streamQuestions, streamed)
+	if err != nil {
+		t.Fatalf("AskAllStreaming() error = %v", err)
+	}
+	want := []survey.NamedAnswer{{Name: "name", Value: "Bob"}, {Name: "admin", Value: false}, {Name: "color", Value: "Blue"}}
+	if got := <-done; !reflect.DeepEqual(got, want) {
+		t.Errorf("streamed %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(answers, map[string]interface{}{"name": "Bob", "admin": false, "color": "Blue"}) {
+		t.Errorf("AskAllStreaming() = %v", answers)
+	}
+}
+
+func TestAskAllStreamingClosesOnError(t *testing.T) {
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader("Bob\n"), io.Discard, io.Discard))
+	streamed := make(chan survey.NamedAnswer, len(streamQuestions))
+
+	_, err := r.AskAllStreaming(streamQuestions, streamed)
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("AskAllStreaming() error = %v, want io.EOF", err)
+	}
+	got := <-collect(streamed)
+	if want := []survey.NamedAnswer{{Name: "name", Value: "Bob"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("streamed %v before the error, want %v", got, want)
+	}
+}
+
+func TestAskAllStreamingInvalidQuestions(t *testing.T) {
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader(""), io.Discard, io.Discard))
+	streamed := make(chan survey.NamedAnswer)
+	if _, err := r.AskAllStreaming([]survey.Question{{Message: "No name"}}, streamed); err == nil {
+		t.Fatal("AskAllStreaming() should reject invalid questions")
+	}
+	if _, ok := <-streamed; ok {
+		t.Error("channel should be closed without any answers")
+	}
+}
+
+func TestAskAllStreamingNilChannel(t *testing.T) {
+	r := survey.NewRunner(survey.WithStdio(strings.NewReader("Bob\n"), io.Discard, io.Discard))
+	if _, err := r.AskAllStreaming(streamQuestions, nil); err == nil {
+		t.Fatal("AskAllStreaming() should reject a nil channel")
+	}
+}
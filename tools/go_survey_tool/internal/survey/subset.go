@@ -0,0 +1,44 @@
+package survey
+
+import "fmt"
+
+// AskSubset 使用标准输入输出只询问names中的问题
+func AskSubset(questions []Question, names []string, presets map[string]interface{}) (map[string]interface{}, error) {
+	return NewRunner().AskSubset(questions, names, presets)
+}
+
+// AskSubset 只询问names中的问题，按它们在questions中的顺序提问，用于"只修改邮箱"这类局部编辑。
+// 其他问题的答案取自presets，不会提问；被询问的问题在presets中有答案时，这个答案作为默认值显示。
+// 返回合并后的全部答案，names中有questions里不存在的名字时不提问直接返回错误
+func (r *Runner) AskSubset(questions []Question, names []string, presets map[string]interface{}) (map[string]interface{}, error) {
+	known := make(map[string]bool, len(questions))
+	for _, q := range questions {
+		known[q.Name] = true
+	}
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !known[name] {
+			return map[string]interface{}{}, fmt.Errorf("unknown question %q", name)
+		}
+		wanted[name] = true
+	}
+
+	answered, err := knownAnswers(questions, MergeAnswers(presets))
+	if err != nil {
+		return map[string]interface{}{}, err
+	}
+	var subset []Question
+	for _, q := range questions {
+		if !wanted[q.Name] {
+			continue
+		}
+		if value, ok := answered[q.Name]; ok {
+			if def := defaultText(q, value); def != "" && q.kind() != TypePassword {
+				q.Default = def
+			}
+			delete(answered, q.Name)
+		}
+		subset = append(subset, q)
+	}
+	return r.askAll(subset, answered, nil)
+}
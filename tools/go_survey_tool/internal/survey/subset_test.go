@@ -0,0 +1,65 @@
+package survey_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestAskSubsetPromptsOnlyNamedQuestions(t *testing.T) {
+	questions := []survey.Question{
+		{Name: "name", Message: "Name?"},
+		{Name: "email", Message: "Email?"},
+		{Name: "team", Message: "Team?"},
+	}
+	presets := map[string]interface{}{"name": "Alice", "email": "old@example.com", "team": "infra"}
+
+	runner, out := newLineRunner("new@example.com\n")
+	answers, err := runner.AskSubset(questions, []string{"email"}, presets)
+	if err != nil {
+		t.Fatalf("AskSubset() error = %v", err)
+	}
+
+	want := map[string]interface{}{"name": "Alice", "email": "new@example.com", "team": "infra"}
+	if !reflect.DeepEqual(answers, want) {
+		t.Errorf("AskSubset() = %v, want %v", answers, want)
+	}
+	if strings.Contains(out.String(), "Name?") || strings.Contains(out.String(), "Team?") {
+		t.Errorf("only the email question should be prompted:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "old@example.com") {
+		t.Errorf("the preset should be offered as the default:\n%s", out.String())
+	}
+}
+
+func TestAskSubsetKeepsQuestionOrder(t *testing.T) {
+	questions := []survey.Question{
+		{Name: "a", Message: "A?"},
+		{Name: "b", Message: "B?"},
+		{Name: "c", Message: "C?"},
+	}
+	runner, out := newLineRunner("1\n3\n")
+	answers, err := runner.AskSubset(questions, []string{"c", "a"}, nil)
+	if err != nil {
+		t.Fatalf("AskSubset() error = %v", err)
+	}
+	if answers["a"] != "1" || answers["c"] != "3" || len(answers) != 2 {
+		t.Errorf("AskSubset() = %v, want a=1 and c=3", answers)
+	}
+	if strings.Index(out.String(), "A?") > strings.Index(out.String(), "C?") {
+		t.Errorf("questions should keep their order in the slice:\n%s", out.String())
+	}
+}
+
+func TestAskSubsetUnknownName(t *testing.T) {
+	runner, out := newLineRunner("x\n")
+	_, err := runner.AskSubset(survey.CreateSurveyQuestions(), []string{"name", "emial"}, nil)
+	if err == nil || !strings.Contains(err.Error(), `unknown question "emial"`) {
+		t.Errorf("AskSubset() error = %v, want an unknown question error", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("nothing should be prompted for an unknown name:\n%s", out.String())
+	}
+}
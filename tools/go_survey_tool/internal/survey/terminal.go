@@ -4,19 +4,25 @@ import (
 	"os"
 
 	"golang.org/x/term"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
 )
 
 // TerminalModeGuard 用于临时调整终端模式
+// 同时记录创建时的终端大小，用于检测运行期间是否发生了窗口大小变化
 type TerminalModeGuard struct {
 	fd       int
 	oldState *term.State
+	width    int // 创建时的终端宽度，无法获取或报告为0x0时为0
+	height   int // 创建时的终端高度，无法获取或报告为0x0时为0
+	getSize  func(fd int) (width, height int, err error)
 }
 
 // NewTerminalModeGuard 创建终端模式守卫
 // 如果检测到raw模式，会临时恢复为cooked模式
 func NewTerminalModeGuard() (*TerminalModeGuard, error) {
 	fd := int(os.Stdin.Fd())
-	if !term.IsTerminal(fd) {
+	if !terminal.IO(os.Stdin, nil, nil).StdinTTY {
 		// 不是终端，返回空的guard
 		return &TerminalModeGuard{fd: -1}, nil
 	}
@@ -44,10 +50,29 @@ func NewTerminalModeGuard() (*TerminalModeGuard, error) {
 	// 对于现在，我们不做任何改变，只是保存状态
 	// 如果发现问题，可以在这里添加模式切换逻辑
 
-	return &TerminalModeGuard{
+	g := &TerminalModeGuard{
 		fd:       fd,
 		oldState: oldState,
-	}, nil
+		getSize:  term.GetSize,
+	}
+	if width, height, err := g.getSize(fd); err == nil && width > 0 && height > 0 {
+		g.width, g.height = width, height
+	}
+	return g, nil
+}
+
+// SizeChanged 比较当前终端大小与创建guard时的大小，返回是否变化以及当前的宽和高
+// 在信号不可靠（部分CI、Windows控制台）的环境中可以代替SIGWINCH检测窗口大小变化。
+// 不是终端、无法获取大小或大小报告为0x0时返回false和创建时记录的大小
+func (g *TerminalModeGuard) SizeChanged() (bool, int, int) {
+	if g.fd == -1 || g.getSize == nil {
+		return false, g.width, g.height
+	}
+	width, height, err := g.getSize(g.fd)
+	if err != nil || width <= 0 || height <= 0 {
+		return false, g.width, g.height
+	}
+	return width != g.width || height != g.height, width, height
 }
 
 // Restore 恢复原始终端状态
@@ -71,4 +96,4 @@ func WithTerminalMode(fn func() error) error {
 
 	// 运行函数
 	return fn()
-}
\ No newline at end of file
+}
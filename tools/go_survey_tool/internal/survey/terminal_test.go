@@ -0,0 +1,82 @@
+package survey
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTerminalModeGuardSizeChanged(t *testing.T) {
+	width, height := 80, 24
+	var sizeErr error
+	g := &TerminalModeGuard{
+		fd:     0,
+		width:  80,
+		height: 24,
+		getSize: func(int) (int, int, error) {
+			return width, height, sizeErr
+		},
+	}
+
+	if changed, w, h := g.SizeChanged(); changed || w != 80 || h != 24 {
+		t.Errorf("SizeChanged() = (%v, %d, %d), want (false, 80, 24)", changed, w, h)
+	}
+
+	width, height = 120, 40
+	if changed, w, h := g.SizeChanged(); !changed || w != 120 || h != 40 {
+		t.Errorf("SizeChanged() after resize = (%v, %d, %d), want (true, 120, 40)", changed, w, h)
+	}
+
+	width, height = 0, 0
+	if changed, w, h := g.SizeChanged(); changed || w != 80 || h != 24 {
+		t.Errorf("SizeChanged() with 0x0 = (%v, %d, %d), want (false, 80, 24)", changed, w, h)
+	}
+
+	sizeErr = errors.New("not a terminal")
+	if changed, w, h := g.SizeChanged(); changed || w != 80 || h != 24 {
+		t.Errorf("SizeChanged() on error = (%v, %d, %d), want (false, 80, 24)", changed, w, h)
+	}
+}
+
+func TestTerminalModeGuardSizeChangedNotATerminal(t *testing.T) {
+	g := &TerminalModeGuard{fd: -1}
+	if changed, w, h := g.SizeChanged(); changed || w != 0 || h != 0 {
+		t.Errorf("SizeChanged() = (%v, %d, %d), want (false, 0, 0)", changed, w, h)
+	}
+}
+
+func TestWithControllingTTY(t *testing.T) {
+	old := openControllingTTY
+	defer func() { openControllingTTY = old }()
+
+	t.Run("Fallback when open fails", func(t *testing.T) {
+		openControllingTTY = func() (*os.File, error) { return nil, errors.New("no tty") }
+		in := strings.NewReader("Alice\n")
+		out := &bytes.Buffer{}
+		r := NewRunner(WithStdio(in, out, out), WithControllingTTY())
+		if r.In != in {
+			t.Fatalf("In = %v, want the original input", r.In)
+		}
+		answer, err := r.Ask(Question{Message: "Name?"})
+		if err != nil || answer != "Alice" {
+			t.Errorf("Ask() = %v, %v, want Alice from the original input", answer, err)
+		}
+	})
+
+	t.Run("Uses the controlling terminal", func(t *testing.T) {
+		tty, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tty.Close()
+		defer w.Close()
+		openControllingTTY = func() (*os.File, error) { return tty, nil }
+
+		r := NewRunner(WithStdio(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{}), WithControllingTTY())
+		if r.In != tty {
+			t.Errorf("In = %v, want the controlling terminal", r.In)
+		}
+	})
+}
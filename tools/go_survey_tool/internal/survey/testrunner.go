@@ -0,0 +1,15 @@
+package survey
+
+import (
+	"bytes"
+	"strings"
+)
+
+// NewTestRunner 创建用于测试的Runner：答案从input逐行读取，提示和错误都写入返回的缓冲区。
+// width大于0时设置Runner.Width，输出按这个宽度截断，与运行测试的终端无关，适合与golden文件比较
+func NewTestRunner(input string, width int) (*Runner, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	r := NewRunner(WithStdio(strings.NewReader(input), out, out))
+	r.Width = width
+	return r, out
+}
@@ -0,0 +1,121 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrQuestionTimeout 设置了Question.Timeout的问题超时，且没有默认值可以使用
+var ErrQuestionTimeout = errors.New("question timed out")
+
+// errReadTimeout 读取输入时超过了问题的期限，由ask转换为默认答案或ErrQuestionTimeout
+var errReadTimeout = errors.New("read timed out")
+
+// AskSelectTimeout 使用标准输入输出询问带超时的单选
+func AskSelectTimeout(message string, options []string, def string, timeout time.Duration, opts ...SelectOption) (string, error) {
+	return NewRunner().AskSelectTimeout(message, options, def, timeout, opts...)
+}
+
+// AskSelectTimeout 与AskSelect相同，但timeout内没有作出选择时返回def
+// timeout为0时不限时
+func (r *Runner) AskSelectTimeout(message string, options []string, def string, timeout time.Duration, opts ...SelectOption) (string, error) {
+	q := Question{Type: TypeSelect, Message: message, Options: options, Default: def, Timeout: timeout}
+	answer, err := r.ask(q, newSelectConfig(opts))
+	if err != nil {
+		return "", fmt.Errorf("选择失败: %w", err)
+	}
+	return answer.(string), nil
+}
+
+// AskInputTimeout 使用标准输入输出询问带超时的文本输入
+func AskInputTimeout(message, def string, timeout time.Duration, validate func(string) error) (string, error) {
+	return NewRunner().AskInputTimeout(message, def, timeout, validate)
+}
+
+// AskInputTimeout 与AskInput相同，但timeout内没有输入完成时返回def
+func (r *Runner) AskInputTimeout(message, def string, timeout time.Duration, validate func(string) error) (string, error) {
+	answer, err := r.Ask(Question{Type: TypeInput, Message: message, Default: def, Validate: validate, Timeout: timeout})
+	if err != nil {
+		return "", fmt.Errorf("输入失败: %w", err)
+	}
+	return answer.(string), nil
+}
+
+// clock 返回Runner使用的时钟
+func (r *Runner) clock() Clock {
+	if r.Clock == nil {
+		return realClock{}
+	}
+	return r.Clock
+}
+
+// timeoutAnswer 问题超时后使用的答案：有默认值时接受默认值，否则返回ErrQuestionTimeout
+func (r *Runner) timeoutAnswer(q Question) (interface{}, error) {
+	if q.Default == "" {
+		return nil, ErrQuestionTimeout
+	}
+	fmt.Fprintf(r.Out, "\n(timed out, using default: %s)\n", q.Default)
+	return parseLineAnswer(q, "")
+}
+
+// readResult 后台读取的结果
+type readResult struct {
+	data []byte
+	err  error
+}
+
+// timedReader 为不是文件的输入实现问题期限：读取在后台goroutine中进行，超时后读取继续等待，
+// 读到的数据留给下一次Read，不会丢失
+type timedReader struct {
+	src    io.Reader
+	runner *Runner
+}
+
+// Read 在问题期限内读取，超时返回errReadTimeout，问题的ctx被取消时返回errReadCancelled
+func (t timedReader) Read(p []byte) (int, error) {
+	r := t.runner
+	if len(r.leftover) > 0 {
+		n := copy(p, r.leftover)
+		r.leftover = r.leftover[n:]
+		return n, nil
+	}
+	if r.questionDeadline.IsZero() && r.questionCtx == nil && r.pending == nil {
+		return t.src.Read(p)
+	}
+
+	if r.pending == nil {
+		ch := make(chan readResult, 1)
+		buf := make([]byte, len(p))
+		go func() {
+			n, err := t.src.Read(buf)
+			ch <- readResult{data: buf[:n], err: err}
+		}()
+		r.pending = ch
+	}
+
+	for {
+		var expired <-chan time.Time
+		if !r.questionDeadline.IsZero() {
+			expired = r.clock().After(r.questionDeadline.Sub(r.clock().Now()))
+		}
+		select {
+		case res := <-r.pending:
+			r.pending = nil
+			n := copy(p, res.data)
+			if n < len(res.data) {
+				r.leftover = res.data[n:]
+				return n, nil
+			}
+			return n, res.err
+		case <-expired:
+			return 0, errReadTimeout
+		case <-ctxDone(r.questionCtx):
+			return 0, errReadCancelled
+		case <-r.timeoutControl.notified():
+			// 期限延后了，按新的期限重新等待
+			r.extendDeadline()
+		}
+	}
+}
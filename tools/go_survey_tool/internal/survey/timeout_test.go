@@ -0,0 +1,189 @@
+package survey_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+// clockRunner 创建从io.Pipe读取输入、使用FakeClock的Runner，返回管道的写端
+func clockRunner(t *testing.T) (*survey.Runner, *survey.FakeClock, *io.PipeWriter) {
+	t.Helper()
+	pr, pw := io.Pipe()
+	t.Cleanup(func() { pr.Close(); pw.Close() })
+	clock := survey.NewFakeClock(time.Unix(0, 0))
+	runner := survey.NewRunner(survey.WithStdio(pr, &bytes.Buffer{}, &bytes.Buffer{}), survey.WithClock(clock))
+	return runner, clock, pw
+}
+
+type askResult struct {
+	answer interface{}
+	err    error
+}
+
+func TestQuestionTimeoutUsesDefault(t *testing.T) {
+	runner, clock, _ := clockRunner(t)
+
+	done := make(chan askResult, 1)
+	go func() {
+		answer, err := runner.AskSelectTimeout("Color?", []string{"Red", "Blue"}, "Blue", 10*time.Second)
+		done <- askResult{answer, err}
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(9 * time.Second)
+	select {
+	case res := <-done:
+		t.Fatalf("returned before the timeout: %v", res)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+	res := <-done
+	if res.err != nil || res.answer != "Blue" {
+		t.Errorf("AskSelectTimeout() = %v, %v, want the default", res.answer, res.err)
+	}
+}
+
+func TestQuestionTimeoutWithoutDefault(t *testing.T) {
+	runner, clock, _ := clockRunner(t)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := runner.AskInputTimeout("Name?", "", time.Second, nil)
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	if err := <-done; !errors.Is(err, survey.ErrQuestionTimeout) {
+		t.Errorf("AskInputTimeout() error = %v, want ErrQuestionTimeout", err)
+	}
+}
+
+func TestQuestionTimeoutAnsweredInTime(t *testing.T) {
+	runner, _, w := clockRunner(t)
+	go w.Write([]byte("Alice\n"))
+
+	answer, err := runner.AskInputTimeout("Name?", "nobody", time.Minute, nil)
+	if err != nil || answer != "Alice" {
+		t.Errorf("AskInputTimeout() = %q, %v, want Alice", answer, err)
+	}
+}
+
+func TestQuestionTimeoutOnlyAffectsItsQuestion(t *testing.T) {
+	runner, clock, w := clockRunner(t)
+	questions := []survey.Question{
+		{Name: "region", Message: "Region?", Default: "us-east", Timeout: 5 * time.Second},
+		{Name: "name", Message: "Name?"},
+	}
+
+	done := make(chan askResult, 1)
+	go func() {
+		answers, err := runner.AskAll(questions)
+		done <- askResult{answers, err}
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(5 * time.Second)
+	// 超时后输入的内容属于下一个问题，不会被丢弃
+	w.Write([]byte("Alice\n"))
+
+	res := <-done
+	if res.err != nil {
+		t.Fatalf("AskAll() error = %v", res.err)
+	}
+	expected := map[string]interface{}{"region": "us-east", "name": "Alice"}
+	if !reflect.DeepEqual(res.answer, expected) {
+		t.Errorf("AskAll() = %v, want %v", res.answer, expected)
+	}
+}
+
+func TestAskSelectTimeoutCtxCancelled(t *testing.T) {
+	runner, clock, _ := clockRunner(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan askResult, 1)
+	go func() {
+		answer, err := runner.AskSelectTimeoutCtx(ctx, "Color?", []string{"Red", "Blue"}, "Blue", 10*time.Second)
+		done <- askResult{answer, err}
+	}()
+
+	// 倒计时进行到一半时取消
+	clock.BlockUntil(1)
+	clock.Advance(3 * time.Second)
+	cancel()
+	res := <-done
+	if res.err != nil || res.answer != "Blue" {
+		t.Errorf("AskSelectTimeoutCtx() = %v, %v, want the default", res.answer, res.err)
+	}
+}
+
+func TestAskSelectTimeoutCtxCancelledWithoutDefault(t *testing.T) {
+	runner, _, _ := clockRunner(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := runner.AskSelectTimeoutCtx(ctx, "Color?", []string{"Red", "Blue"}, "", time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("AskSelectTimeoutCtx() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestAskSelectTimeoutCtxFile(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	runner := survey.NewRunner(survey.WithStdio(r, &bytes.Buffer{}, &bytes.Buffer{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	answer, err := runner.AskSelectTimeoutCtx(ctx, "Color?", []string{"Red", "Blue"}, "Red", time.Minute)
+	if err != nil || answer != "Red" {
+		t.Errorf("AskSelectTimeoutCtx() = %q, %v, want the default", answer, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancellation took %v to be noticed", elapsed)
+	}
+}
+
+func TestTimeoutControlExtendTimeout(t *testing.T) {
+	runner, clock, _ := clockRunner(t)
+	control := survey.NewTimeoutControl()
+
+	done := make(chan askResult, 1)
+	go func() {
+		answer, err := runner.AskSelectTimeoutCtx(context.Background(), "Color?", []string{"Red", "Blue"}, "Blue", 10*time.Second,
+			survey.WithTimeoutControl(control))
+		done <- askResult{answer, err}
+	}()
+
+	clock.BlockUntil(1)
+	control.ExtendTimeout(5 * time.Second)
+	// 延长后按新的期限重新等待
+	clock.BlockUntil(2)
+	clock.Advance(10 * time.Second)
+	select {
+	case res := <-done:
+		t.Fatalf("returned at the original timeout: %v", res)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(5 * time.Second)
+	res := <-done
+	if res.err != nil || res.answer != "Blue" {
+		t.Errorf("AskSelectTimeoutCtx() = %v, %v, want the default after the extended timeout", res.answer, res.err)
+	}
+}
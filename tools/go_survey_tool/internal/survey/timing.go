@@ -0,0 +1,67 @@
+package survey
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// AskAllResult 使用标准输入输出运行调查，返回带计时的结果
+func AskAllResult(questions []Question, presets ...map[string]interface{}) (Result, error) {
+	return NewRunner().AskAllResult(questions, presets...)
+}
+
+// AskAllResult 与AskAll相同，但返回Result，其中记录答案的顺序、实际提问的问题数和用时：
+// 计时从第一个问题显示时开始，到最后一个答案收到时结束；预置答案和被When跳过的问题不计入
+func (r *Runner) AskAllResult(questions []Question, presets ...map[string]interface{}) (Result, error) {
+	var start, end time.Time
+	count := 0
+	r.onShown = func() {
+		if start.IsZero() {
+			start = r.clock().Now()
+		}
+	}
+	defer func() { r.onShown = nil }()
+
+	answered, err := knownAnswers(questions, MergeAnswers(presets...))
+	if err != nil {
+		return Result{Answers: map[string]interface{}{}}, err
+	}
+	answers, err := r.askAll(questions, answered, func(string, map[string]interface{}) error {
+		count++
+		end = r.clock().Now()
+		return nil
+	})
+
+	result := Result{
+		Answers:       answers,
+		QuestionCount: count,
+		Order:         answerOrder(questions, answers),
+		SessionID:     os.Getenv("OMNISH_SESSION_ID"),
+		Completed:     r.clock().Now(),
+	}
+	if count > 0 {
+		result.Duration = end.Sub(start)
+	}
+	return result, err
+}
+
+// answerOrder 按问题的顺序返回已有答案的问题名
+func answerOrder(questions []Question, answers map[string]interface{}) []string {
+	order := make([]string, 0, len(answers))
+	for _, q := range questions {
+		if _, ok := answers[q.Name]; ok {
+			order = append(order, q.Name)
+		}
+	}
+	return order
+}
+
+// Summary 返回"Completed N questions in Xs."形式的一行总结
+func (res Result) Summary() string {
+	noun := "questions"
+	if res.QuestionCount == 1 {
+		noun = "question"
+	}
+	return fmt.Sprintf("Completed %d %s in %.1fs.", res.QuestionCount, noun, res.Duration.Seconds())
+}
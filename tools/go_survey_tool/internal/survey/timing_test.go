@@ -0,0 +1,60 @@
+package survey_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestAskAllResultTiming(t *testing.T) {
+	clock := survey.NewFakeClock(time.Unix(0, 0))
+	// 每个答案都让虚拟时间前进2秒，模拟用户思考的时间
+	slow := func(string) error {
+		clock.Advance(2 * time.Second)
+		return nil
+	}
+	questions := []survey.Question{
+		{Name: "preset", Message: "Preset?"},
+		{Name: "skipped", Message: "Skipped?", When: func(map[string]interface{}) bool { return false }},
+		{Name: "name", Message: "Name?", Validate: slow},
+		{Name: "city", Message: "City?", Validate: slow},
+	}
+	out := &bytes.Buffer{}
+	runner := survey.NewRunner(survey.WithStdio(strings.NewReader("Alice\nParis\n"), out, out), survey.WithClock(clock))
+
+	result, err := runner.AskAllResult(questions, map[string]interface{}{"preset": "x"})
+	if err != nil {
+		t.Fatalf("AskAllResult() error = %v", err)
+	}
+	expected := map[string]interface{}{"preset": "x", "name": "Alice", "city": "Paris"}
+	if !reflect.DeepEqual(result.Answers, expected) {
+		t.Errorf("Answers = %v, want %v", result.Answers, expected)
+	}
+	if result.QuestionCount != 2 {
+		t.Errorf("QuestionCount = %d, want 2 (presets and skipped questions are not counted)", result.QuestionCount)
+	}
+	if result.Duration != 4*time.Second {
+		t.Errorf("Duration = %v, want 4s", result.Duration)
+	}
+	if got := result.Summary(); got != "Completed 2 questions in 4.0s." {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+func TestAskAllResultNothingAsked(t *testing.T) {
+	runner := survey.NewRunner(survey.WithStdio(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{}))
+	result, err := runner.AskAllResult([]survey.Question{{Name: "a", Message: "A?"}}, map[string]interface{}{"a": "x"})
+	if err != nil {
+		t.Fatalf("AskAllResult() error = %v", err)
+	}
+	if result.QuestionCount != 0 || result.Duration != 0 {
+		t.Errorf("result = %+v, want no questions and no duration", result)
+	}
+	if got := result.Summary(); got != "Completed 0 questions in 0.0s." {
+		t.Errorf("Summary() = %q", got)
+	}
+}
@@ -0,0 +1,134 @@
+package survey
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// WriteResultTOML 把结果写为TOML文档：答案按Result.Ordered的顺序作为顶层的键，
+// 之后的[meta]表记录会话ID、完成时间、用时和提问数，会话ID和完成时间为空时省略。
+// 字符串、布尔值和数字按TOML的类型输出，切片输出为数组；TOML没有空值，值为nil的答案被省略，
+// 其他类型按utils.FormatValue输出为字符串。不符合TOML裸键规则的名字加引号，布尔值的写法见WithBoolFormat
+func WriteResultTOML(w io.Writer, r Result, opts ...OutputOption) error {
+	cfg := newOutputConfig(opts)
+	bw := bufio.NewWriter(w)
+	for _, answer := range r.Ordered() {
+		if answer.Value == nil {
+			continue
+		}
+		fmt.Fprintf(bw, "%s = %s\n", tomlKey(answer.Name), tomlValue(cfg.bools.Apply(answer.Value)))
+	}
+
+	bw.WriteString("\n[meta]\n")
+	if r.SessionID != "" {
+		fmt.Fprintf(bw, "session_id = %s\n", tomlString(r.SessionID))
+	}
+	if !r.Completed.IsZero() {
+		fmt.Fprintf(bw, "timestamp = %s\n", r.Completed.Format(time.RFC3339Nano))
+	}
+	fmt.Fprintf(bw, "duration_ms = %d\n", r.Duration.Milliseconds())
+	fmt.Fprintf(bw, "question_count = %d\n", r.QuestionCount)
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("写入TOML失败: %w", err)
+	}
+	return nil
+}
+
+// tomlKey 返回TOML的键：只含字母、数字、_和-的名字直接使用，否则加引号
+func tomlKey(name string) string {
+	if name == "" {
+		return `""`
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return tomlString(name)
+		}
+	}
+	return name
+}
+
+// tomlValue 把答案转换为TOML的值
+func tomlValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return tomlString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return tomlFloat(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []string:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = tomlString(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if item != nil {
+				items = append(items, tomlValue(item))
+			}
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return tomlString(utils.FormatValue(value))
+}
+
+// tomlFloat 格式化浮点数，整数值也带上小数点，避免读回时变成整数
+func tomlFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	switch s {
+	case "+Inf":
+		return "inf"
+	case "-Inf":
+		return "-inf"
+	case "NaN":
+		return "nan"
+	}
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+// tomlString 返回TOML的基本字符串，转义引号、反斜杠和控制字符
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, c := range s {
+		switch c {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if c < 0x20 || c == 0x7f || c == utf8.RuneError {
+				fmt.Fprintf(&b, `\u%04X`, c)
+				continue
+			}
+			b.WriteRune(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package survey_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestWriteResultTOMLRoundTrip(t *testing.T) {
+	completed := time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+	res := survey.Result{
+		Answers: map[string]interface{}{
+			"name":       `Smith "J" \ Jr.`,
+			"note":       "line1\nline2\ttab",
+			"like":       true,
+			"colors":     []string{"Red", "Light Blue"},
+			"mixed":      []interface{}{"a", 2, false},
+			"score":      3,
+			"ratio":      2.0,
+			"first name": "Alice",
+			"skipped":    nil,
+		},
+		Order:         []string{"name", "note", "like", "colors", "mixed", "score", "ratio", "first name", "skipped"},
+		Duration:      1500 * time.Millisecond,
+		QuestionCount: 8,
+		SessionID:     "abc-123",
+		Completed:     completed,
+	}
+
+	var buf bytes.Buffer
+	if err := survey.WriteResultTOML(&buf, res); err != nil {
+		t.Fatalf("WriteResultTOML() error = %v", err)
+	}
+	var decoded map[string]interface{}
+	if _, err := toml.Decode(buf.String(), &decoded); err != nil {
+		t.Fatalf("output is not valid TOML: %v\n%s", err, buf.String())
+	}
+
+	want := map[string]interface{}{
+		"name":       `Smith "J" \ Jr.`,
+		"note":       "line1\nline2\ttab",
+		"like":       true,
+		"colors":     []interface{}{"Red", "Light Blue"},
+		"mixed":      []interface{}{"a", int64(2), false},
+		"score":      int64(3),
+		"ratio":      2.0,
+		"first name": "Alice",
+		"meta": map[string]interface{}{
+			"session_id":     "abc-123",
+			"timestamp":      completed,
+			"duration_ms":    int64(1500),
+			"question_count": int64(8),
+		},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("round trip = %#v\nwant %#v\n%s", decoded, want, buf.String())
+	}
+	if !strings.HasPrefix(buf.String(), "name = ") {
+		t.Errorf("answers should follow the question order:\n%s", buf.String())
+	}
+}
+
+func TestWriteResultTOMLOmitsEmptyMeta(t *testing.T) {
+	var buf bytes.Buffer
+	if err := survey.WriteResultTOML(&buf, survey.Result{Answers: map[string]interface{}{"n": "x"}}); err != nil {
+		t.Fatalf("WriteResultTOML() error = %v", err)
+	}
+	want := "n = \"x\"\n\n[meta]\nduration_ms = 0\nquestion_count = 0\n"
+	if buf.String() != want {
+		t.Errorf("WriteResultTOML() = %q, want %q", buf.String(), want)
+	}
+}
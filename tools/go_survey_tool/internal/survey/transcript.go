@@ -0,0 +1,40 @@
+package survey
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// WithTranscript 把每个问题和答案以便于阅读的格式写入w，见Runner.Transcript
+func WithTranscript(w io.Writer) RunnerOption {
+	return func(r *Runner) {
+		r.Transcript = w
+	}
+}
+
+// writeTranscript 把一问一答写入Transcript：
+//
+//	Q: What is your name?
+//	A: Alice
+//
+// confirm的答案写为yes/no，密码答案写为****。
+// 多行的问题和答案从第二行起缩进到"Q: "和"A: "之后，答案中的空行也不会被当作两组问答之间的分隔
+func (r *Runner) writeTranscript(q Question, answer interface{}) {
+	if r.Transcript == nil {
+		return
+	}
+	value := utils.FormatValue(loggedAnswer(q, answer))
+	if b, ok := answer.(bool); ok {
+		value = "no"
+		if b {
+			value = "yes"
+		}
+	}
+	message := utils.IndentMultiline(q.Message, "   ")
+	value = utils.IndentMultiline(value, "   ")
+	if _, err := fmt.Fprintf(r.Transcript, "Q: %s\nA: %s\n\n", message, value); err != nil {
+		r.log(LevelWarn, "transcript write failed", "error", err)
+	}
+}
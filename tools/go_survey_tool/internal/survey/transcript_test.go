@@ -0,0 +1,65 @@
+package survey
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTranscript(t *testing.T) {
+	questions := []Question{
+		{Name: "name", Message: "What is your name?"},
+		{Name: "password", Type: TypePassword, Message: "Password?"},
+		{Name: "admin", Type: TypeConfirm, Message: "Admin?"},
+		{Name: "colors", Type: TypeMultiSelect, Message: "Colors?", Options: []string{"Red", "Blue"}},
+	}
+	var transcript bytes.Buffer
+	r := NewRunner(WithStdio(strings.NewReader("Alice\nhunter2\ny\n2,1\n"), &bytes.Buffer{}, &bytes.Buffer{}), WithTranscript(&transcript))
+	if _, err := r.AskAll(questions); err != nil {
+		t.Fatalf("AskAll() error = %v", err)
+	}
+
+	expected := "Q: What is your name?\nA: Alice\n\n" +
+		"Q: Password?\nA: ****\n\n" +
+		"Q: Admin?\nA: yes\n\n" +
+		"Q: Colors?\nA: Red, Blue\n\n"
+	if transcript.String() != expected {
+		t.Errorf("transcript =\n%s\nwant\n%s", transcript.String(), expected)
+	}
+	if strings.Contains(transcript.String(), "hunter2") {
+		t.Error("password leaked into the transcript")
+	}
+}
+
+func TestTranscriptMultilineAnswer(t *testing.T) {
+	var transcript bytes.Buffer
+	r := NewRunner(WithStdio(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{}), WithTranscript(&transcript))
+	r.writeTranscript(Question{Name: "note", Message: "Notes?"}, "first line\r\n\nQ: not a question")
+	r.writeTranscript(Question{Name: "name", Message: "Name?"}, "Alice")
+
+	expected := "Q: Notes?\nA: first line\n   \n   Q: not a question\n\n" +
+		"Q: Name?\nA: Alice\n\n"
+	if transcript.String() != expected {
+		t.Errorf("transcript =\n%s\nwant\n%s", transcript.String(), expected)
+	}
+	// 每组问答之间只有一个空行，按空行切分仍得到两组
+	if entries := strings.Split(strings.TrimSuffix(transcript.String(), "\n\n"), "\n\n"); len(entries) != 2 {
+		t.Errorf("transcript has %d entries, want 2:\n%s", len(entries), transcript.String())
+	}
+}
+
+func TestTranscriptRedactsSecretsAndMasks(t *testing.T) {
+	t.Setenv("TEST_TRANSCRIPT_TOKEN", "tok-123")
+	var transcript bytes.Buffer
+	r := NewRunner(WithStdio(strings.NewReader("5551234\n"), &bytes.Buffer{}, &bytes.Buffer{}), WithTranscript(&transcript))
+
+	if _, err := r.Ask(Question{Name: "token", Type: TypePassword, Message: "Token?", SecretEnv: "TEST_TRANSCRIPT_TOKEN"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.AskMasked("Phone?", MaskExceptLast(4)); err != nil {
+		t.Fatal(err)
+	}
+	if got := transcript.String(); got != "Q: Token?\nA: ****\n\nQ: Phone?\nA: ***1234\n\n" {
+		t.Errorf("transcript = %q", got)
+	}
+}
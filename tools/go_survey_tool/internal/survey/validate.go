@@ -0,0 +1,85 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// ValidateQuestions 在提问前检查问题定义，返回用errors.Join合并的所有问题：
+// 缺少或重复的name、空的message、未知的类型（RegisterQuestionType注册的类型视为已知）、select/multiselect没有选项、
+// 与选项不匹配的默认值，以及非password问题设置了SecretEnv或SecretFile。
+// 有name的问题的错误包装为*QuestionError，否则标注序号（从1开始）
+func ValidateQuestions(qs []Question) error {
+	var errs []error
+	checkQuestions(qs, func(i int, err error) {
+		if qs[i].Name == "" {
+			errs = append(errs, fmt.Errorf("question %d: %w", i+1, err))
+		} else {
+			errs = append(errs, &QuestionError{Name: qs[i].Name, Err: err})
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// checkQuestions 对qs中发现的每个错误调用report，i为出错问题的下标
+func checkQuestions(qs []Question, report func(i int, err error)) {
+	seen := make(map[string]bool, len(qs))
+	for i, q := range qs {
+		for _, err := range validateQuestion(q) {
+			report(i, err)
+		}
+		if q.Name == "" {
+			continue
+		}
+		if seen[q.Name] {
+			report(i, errors.New("duplicate name"))
+		}
+		seen[q.Name] = true
+	}
+}
+
+// validateQuestion 返回单个问题定义中的所有错误
+func validateQuestion(q Question) []error {
+	var errs []error
+	if q.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if q.Message == "" {
+		errs = append(errs, errors.New("message is required"))
+	}
+
+	if (q.SecretEnv != "" || q.SecretFile != "") && q.kind() != TypePassword {
+		errs = append(errs, errors.New("secret env and file are only supported for password questions"))
+	}
+
+	switch q.kind() {
+	case TypeInput, TypePassword:
+	case TypeConfirm:
+		if q.Default != "" {
+			if _, err := utils.ParseBool(q.Default); err != nil {
+				errs = append(errs, fmt.Errorf("invalid default: %w", err))
+			}
+		}
+	case TypeSelect, TypeMultiSelect:
+		if len(q.Options) == 0 {
+			errs = append(errs, fmt.Errorf("%s needs options", q.kind()))
+			break
+		}
+		defaults := splitDefault(q.Default)
+		if q.kind() == TypeSelect && q.Default != "" {
+			defaults = []string{q.Default}
+		}
+		for _, def := range defaults {
+			if _, ok := resolveOption(q.Options, def); !ok {
+				errs = append(errs, fmt.Errorf("invalid default %q: not one of the options", def))
+			}
+		}
+	default:
+		if _, ok := questionHandler(q.kind()); !ok {
+			errs = append(errs, fmt.Errorf("unknown question type %q", q.Type))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,80 @@
+package survey_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/survey"
+)
+
+func TestValidateQuestions(t *testing.T) {
+	colors := []string{"Red", "Blue"}
+	tests := []struct {
+		name      string
+		questions []survey.Question
+		errText   string
+	}{
+		{"Missing name", []survey.Question{{Message: "A?"}}, "question 1: name is required"},
+		{"Duplicate name", []survey.Question{{Name: "a", Message: "A?"}, {Name: "a", Message: "B?"}}, `question "a": duplicate name`},
+		{"Empty message", []survey.Question{{Name: "a"}}, `question "a": message is required`},
+		{"Unknown type", []survey.Question{{Name: "a", Type: "slider", Message: "A?"}}, `unknown question type "slider"`},
+		{"Select without options", []survey.Question{{Name: "a", Type: survey.TypeSelect, Message: "A?"}}, "select needs options"},
+		{"MultiSelect without options", []survey.Question{{Name: "a", Type: survey.TypeMultiSelect, Message: "A?"}}, "multiselect needs options"},
+		{"Select default out of range", []survey.Question{{Name: "a", Type: survey.TypeSelect, Message: "A?", Options: colors, Default: "3"}}, `invalid default "3"`},
+		{"Select default not an option", []survey.Question{{Name: "a", Type: survey.TypeSelect, Message: "A?", Options: colors, Default: "Green"}}, `invalid default "Green"`},
+		{"MultiSelect default", []survey.Question{{Name: "a", Type: survey.TypeMultiSelect, Message: "A?", Options: colors, Default: "Red, Pink"}}, `invalid default "Pink"`},
+		{"Confirm default", []survey.Question{{Name: "a", Type: survey.TypeConfirm, Message: "A?", Default: "maybe"}}, "invalid default"},
+		{"Secret on input", []survey.Question{{Name: "a", Message: "A?", SecretEnv: "A"}}, "only supported for password questions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := survey.ValidateQuestions(tt.questions)
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("ValidateQuestions() error = %v, want %q", err, tt.errText)
+			}
+		})
+	}
+}
+
+func TestValidateQuestionsValid(t *testing.T) {
+	questions := append(survey.CreateSurveyQuestions(),
+		survey.Question{Name: "by_index", Type: survey.TypeSelect, Message: "Pick", Options: []string{"a", "b"}, Default: "2"},
+		survey.Question{Name: "many", Type: survey.TypeMultiSelect, Message: "Pick", Options: []string{"a", "b"}, Default: "a, 2"},
+	)
+	if err := survey.ValidateQuestions(questions); err != nil {
+		t.Errorf("ValidateQuestions() error = %v", err)
+	}
+}
+
+func TestValidateQuestionsReportsAll(t *testing.T) {
+	err := survey.ValidateQuestions([]survey.Question{
+		{Name: "a"},
+		{Name: "b", Type: survey.TypeSelect, Message: "B?"},
+	})
+	for _, want := range []string{`question "a": message is required`, `question "b": select needs options`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateQuestions() error = %v, want it to contain %q", err, want)
+		}
+	}
+
+	var qerr *survey.QuestionError
+	if !errors.As(err, &qerr) || qerr.Name != "a" {
+		t.Errorf("errors.As should find the first QuestionError, got %v", qerr)
+	}
+}
+
+func TestAskAllValidatesFirst(t *testing.T) {
+	runner, out := newLineRunner("Alice\n")
+	questions := []survey.Question{
+		{Name: "name", Message: "Name?"},
+		{Name: "color", Type: survey.TypeSelect, Message: "Color?"},
+	}
+	if _, err := runner.AskAll(questions); err == nil || !strings.Contains(err.Error(), "select needs options") {
+		t.Fatalf("AskAll() error = %v, want a validation error", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("no question should be asked before validation passes:\n%s", out.String())
+	}
+}
@@ -0,0 +1,46 @@
+package terminal
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// 切换备用屏幕缓冲区的控制序列
+const (
+	enterAltScreen = "\x1b[?1049h"
+	leaveAltScreen = "\x1b[?1049l"
+)
+
+// SupportsAltScreen 判断w是否是支持备用屏幕缓冲区的终端
+func SupportsAltScreen(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return false
+	}
+	switch os.Getenv("TERM") {
+	case "", "dumb":
+		return false
+	}
+	return true
+}
+
+// WithAltScreen 在终端的备用屏幕缓冲区中运行fn，结束后切回主屏幕
+// 这样全屏提示不会滚动用户的历史输出；w不支持时直接运行fn
+func WithAltScreen(w io.Writer, fn func() error) error {
+	if !SupportsAltScreen(w) {
+		return fn()
+	}
+	return withAltScreen(w, fn)
+}
+
+// withAltScreen 无条件切换备用屏幕，即使fn发生panic也会切回主屏幕
+func withAltScreen(w io.Writer, fn func() error) error {
+	if _, err := io.WriteString(w, enterAltScreen); err != nil {
+		return err
+	}
+	defer io.WriteString(w, leaveAltScreen)
+
+	return fn()
+}
@@ -0,0 +1,64 @@
+package terminal
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestWithAltScreenWritesSequences(t *testing.T) {
+	var buf bytes.Buffer
+	err := withAltScreen(&buf, func() error {
+		buf.WriteString("prompt")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withAltScreen() error = %v", err)
+	}
+
+	expected := enterAltScreen + "prompt" + leaveAltScreen
+	if buf.String() != expected {
+		t.Errorf("output = %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestWithAltScreenLeavesOnError(t *testing.T) {
+	var buf bytes.Buffer
+	wantErr := errors.New("boom")
+	if err := withAltScreen(&buf, func() error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("withAltScreen() error = %v, want %v", err, wantErr)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte(leaveAltScreen)) {
+		t.Errorf("leave sequence missing after error: %q", buf.String())
+	}
+}
+
+func TestWithAltScreenLeavesOnPanic(t *testing.T) {
+	var buf bytes.Buffer
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("expected the panic to propagate")
+			}
+		}()
+		withAltScreen(&buf, func() error { panic("boom") })
+	}()
+
+	if !bytes.HasSuffix(buf.Bytes(), []byte(leaveAltScreen)) {
+		t.Errorf("leave sequence missing after panic: %q", buf.String())
+	}
+}
+
+func TestWithAltScreenSkipsNonTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	called := false
+	if err := WithAltScreen(&buf, func() error { called = true; return nil }); err != nil {
+		t.Fatalf("WithAltScreen() error = %v", err)
+	}
+	if !called {
+		t.Error("fn was not called")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("non-terminal writer should not receive sequences, got %q", buf.String())
+	}
+}
@@ -0,0 +1,100 @@
+package terminal
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// ErrBackgroundUnknown 终端没有报告背景色，COLORFGBG也没有设置
+var ErrBackgroundUnknown = errors.New("terminal background color unknown")
+
+// backgroundTimeout 等待终端回复背景色查询的最长时间
+const backgroundTimeout = 200 * time.Millisecond
+
+// backgroundQuery 用OSC 11查询背景色，后面紧跟设备状态查询：终端按顺序回复，
+// 所有终端都会回复设备状态查询，不支持OSC 11的终端只回复ESC [ 0 n，不必等到超时
+const backgroundQuery = "\x1b]11;?\x07" + statusQuery
+
+// BackgroundIsDark 判断终端的默认背景是否为深色，用于选择容易看清的颜色。
+// fd是终端时在raw模式下用OSC 11查询背景色，回复会被读走，不会被当作用户输入；
+// 查询失败或fd不是终端时根据COLORFGBG环境变量判断。
+// 都无法判断时返回错误（终端超时未回复时为ErrTerminalUnresponsive，否则通常为ErrBackgroundUnknown），
+// 调用方可以使用自己的默认值
+func BackgroundIsDark(fd int) (bool, error) {
+	queryErr := ErrBackgroundUnknown
+	if term.IsTerminal(fd) {
+		reply, err := queryBackground(fd, backgroundTimeout)
+		if err != nil {
+			queryErr = err
+		} else if dark, ok := parseBackgroundReply(reply); ok {
+			return dark, nil
+		}
+	}
+	if dark, ok := BackgroundFromEnv(Environ()); ok {
+		return dark, nil
+	}
+	return false, queryErr
+}
+
+// BackgroundFromEnv 根据COLORFGBG（如"15;0"，最后一项是背景色的颜色编号）判断背景是否为深色，
+// 没有设置或无法解析时ok为false。编号0-6和8为深色，与rxvt的约定一致
+func BackgroundFromEnv(env map[string]string) (dark, ok bool) {
+	value := env["COLORFGBG"]
+	if value == "" {
+		return false, false
+	}
+	fields := strings.Split(value, ";")
+	n, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || n < 0 || n > 15 {
+		return false, false
+	}
+	return n <= 6 || n == 8, true
+}
+
+// parseBackgroundReply 从终端的回复中找出OSC 11的回复"ESC ] 11 ; rgb:RRRR/GGGG/BBBB"，
+// 以BEL或ESC \结尾，按亮度判断背景是否为深色。没有找到或格式不对时ok为false
+func parseBackgroundReply(reply []byte) (dark, ok bool) {
+	start := bytes.Index(reply, []byte("\x1b]11;"))
+	if start < 0 {
+		return false, false
+	}
+	body := reply[start+len("\x1b]11;"):]
+	end := bytes.IndexAny(body, "\x07\x1b")
+	if end < 0 {
+		return false, false
+	}
+	spec := string(body[:end])
+
+	var components string
+	switch {
+	case strings.HasPrefix(spec, "rgb:"):
+		components = spec[len("rgb:"):]
+	case strings.HasPrefix(spec, "rgba:"):
+		components = spec[len("rgba:"):]
+	default:
+		return false, false
+	}
+	parts := strings.Split(components, "/")
+	if len(parts) < 3 {
+		return false, false
+	}
+	var rgb [3]float64
+	for i := range rgb {
+		// 每个分量为1到4位十六进制数，按位数换算到0-1
+		if len(parts[i]) == 0 || len(parts[i]) > 4 {
+			return false, false
+		}
+		v, err := strconv.ParseUint(parts[i], 16, 16)
+		if err != nil {
+			return false, false
+		}
+		rgb[i] = float64(v) / float64(uint64(1)<<(4*len(parts[i]))-1)
+	}
+	luminance := 0.299*rgb[0] + 0.587*rgb[1] + 0.114*rgb[2]
+	return luminance < 0.5, true
+}
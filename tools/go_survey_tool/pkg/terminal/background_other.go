@@ -0,0 +1,10 @@
+//go:build !unix
+
+package terminal
+
+import "time"
+
+// queryBackground 不支持发送查询的平台上只能根据COLORFGBG判断
+func queryBackground(fd int, timeout time.Duration) ([]byte, error) {
+	return nil, ErrBackgroundUnknown
+}
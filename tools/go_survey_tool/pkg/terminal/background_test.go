@@ -0,0 +1,73 @@
+package terminal
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestParseBackgroundReply(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    string
+		dark, ok bool
+	}{
+		{"Black BEL", "\x1b]11;rgb:0000/0000/0000\x07\x1b[0n", true, true},
+		{"White ST", "\x1b]11;rgb:ffff/ffff/ffff\x1b\\\x1b[0n", false, true},
+		{"Solarized light", "\x1b]11;rgb:fdfd/f6f6/e3e3\x07", false, true},
+		{"Two digit components", "\x1b]11;rgb:28/2c/34\x07", true, true},
+		{"rgba", "\x1b]11;rgba:ffff/ffff/ffff/ffff\x07", false, true},
+		{"Status reply only", "\x1b[0n", false, false},
+		{"Truncated", "\x1b]11;rgb:ffff/ff", false, false},
+		{"Bad component", "\x1b]11;rgb:zz/00/00\x07", false, false},
+		{"Unknown color space", "\x1b]11;cmy:0/0/0\x07", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dark, ok := parseBackgroundReply([]byte(tt.reply))
+			if dark != tt.dark || ok != tt.ok {
+				t.Errorf("parseBackgroundReply(%q) = %v, %v, want %v, %v", tt.reply, dark, ok, tt.dark, tt.ok)
+			}
+		})
+	}
+}
+
+func TestBackgroundFromEnv(t *testing.T) {
+	tests := []struct {
+		value    string
+		dark, ok bool
+	}{
+		{"15;0", true, true},
+		{"0;15", false, true},
+		{"15;default;8", true, true},
+		{"0;7", false, true},
+		{"default;default", false, false},
+		{"15;99", false, false},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		dark, ok := BackgroundFromEnv(map[string]string{"COLORFGBG": tt.value})
+		if dark != tt.dark || ok != tt.ok {
+			t.Errorf("BackgroundFromEnv(COLORFGBG=%q) = %v, %v, want %v, %v", tt.value, dark, ok, tt.dark, tt.ok)
+		}
+	}
+}
+
+func TestBackgroundIsDarkNotTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	// 管道不是终端，不发送查询，只根据COLORFGBG判断
+	t.Setenv("COLORFGBG", "0;15")
+	if dark, err := BackgroundIsDark(int(r.Fd())); err != nil || dark {
+		t.Errorf("BackgroundIsDark() = %v, %v, want light from COLORFGBG", dark, err)
+	}
+	t.Setenv("COLORFGBG", "")
+	if _, err := BackgroundIsDark(int(r.Fd())); !errors.Is(err, ErrBackgroundUnknown) {
+		t.Errorf("BackgroundIsDark() error = %v, want ErrBackgroundUnknown", err)
+	}
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package terminal
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// queryBackground 在raw模式下发送背景色查询，读取终端的回复直到设备状态查询的回复，见readReply
+func queryBackground(fd int, timeout time.Duration) ([]byte, error) {
+	restore, err := EnterRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	if _, err := unix.Write(fd, []byte(backgroundQuery)); err != nil {
+		return nil, err
+	}
+	return readReply(fd, timeout)
+}
@@ -0,0 +1,36 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/term"
+)
+
+// ErrAlreadyRaw 终端在程序开始读取之前已经处于raw模式，通常是父进程（例如omnish）正在逐键读取，
+// 这时方向键选择可能收不到按键或者把转义序列显示为[A、[B
+var ErrAlreadyRaw = errors.New("terminal is already in raw mode")
+
+// DiagnoseRawConflict 检查fd上的终端能否正常地进入和退出raw模式，返回发现的第一个问题，没有问题时返回nil。
+// 检查结束后终端恢复为原来的状态。返回非nil时应改用不依赖方向键的提问方式，例如AskSelectNumeric
+func DiagnoseRawConflict(fd int) error {
+	before, err := term.GetState(fd)
+	if err != nil {
+		return fmt.Errorf("cannot read terminal state: %w", err)
+	}
+	if _, err := term.MakeRaw(fd); err != nil {
+		return fmt.Errorf("cannot enter raw mode: %w", err)
+	}
+	raw, err := term.GetState(fd)
+	if restoreErr := term.Restore(fd, before); restoreErr != nil {
+		return fmt.Errorf("cannot restore terminal state: %w", restoreErr)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read terminal state: %w", err)
+	}
+	// 进入raw模式没有改变任何设置，说明之前已经是raw模式
+	if *raw == *before {
+		return ErrAlreadyRaw
+	}
+	return nil
+}
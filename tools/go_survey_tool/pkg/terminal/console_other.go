@@ -0,0 +1,13 @@
+//go:build !windows
+
+package terminal
+
+// IsWindowsTerminal 在非Windows平台上总是返回false
+func IsWindowsTerminal() bool {
+	return false
+}
+
+// HasConPTY 在非Windows平台上总是返回false
+func HasConPTY() bool {
+	return false
+}
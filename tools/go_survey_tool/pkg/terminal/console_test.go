@@ -0,0 +1,19 @@
+//go:build !windows
+
+package terminal_test
+
+import (
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+func TestWindowsConsoleOnOtherPlatforms(t *testing.T) {
+	t.Setenv("WT_SESSION", "6b1c5c9e-0000-0000-0000-000000000000")
+	if terminal.IsWindowsTerminal() {
+		t.Error("IsWindowsTerminal() = true on a non-Windows platform")
+	}
+	if terminal.HasConPTY() {
+		t.Error("HasConPTY() = true on a non-Windows platform")
+	}
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package terminal
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// conPTYBuild 提供ConPTY的最低Windows版本（Windows 10 1809）
+const conPTYBuild = 17763
+
+// IsWindowsTerminal 判断是否运行在Windows Terminal中，Windows Terminal会设置WT_SESSION
+func IsWindowsTerminal() bool {
+	return os.Getenv("WT_SESSION") != ""
+}
+
+// HasConPTY 判断系统是否提供ConPTY：传统conhost对转义序列和raw模式的支持不完整，
+// 在没有ConPTY的系统上方向键和颜色可能无法正常工作
+func HasConPTY() bool {
+	v := windows.RtlGetVersion()
+	return v.MajorVersion > 10 || (v.MajorVersion == 10 && v.BuildNumber >= conPTYBuild)
+}
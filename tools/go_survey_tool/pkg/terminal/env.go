@@ -0,0 +1,178 @@
+package terminal
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ColorLevel 终端支持的颜色等级
+type ColorLevel int
+
+const (
+	ColorNone ColorLevel = iota
+	Color16
+	Color256
+	ColorTrueColor
+)
+
+// String 返回颜色等级的名称
+func (l ColorLevel) String() string {
+	switch l {
+	case Color16:
+		return "16"
+	case Color256:
+		return "256"
+	case ColorTrueColor:
+		return "truecolor"
+	default:
+		return "none"
+	}
+}
+
+// 会话类型
+const (
+	SessionLocal     = "local"
+	SessionSSH       = "ssh"
+	SessionContainer = "container"
+	SessionOmnish    = "omnish"
+)
+
+// Environ 把当前进程的环境变量转换为map，供下面的检测函数使用
+func Environ() map[string]string {
+	return EnvMap(os.Environ())
+}
+
+// EnvMap 把KEY=VALUE形式的列表转换为map
+func EnvMap(environ []string) map[string]string {
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			env[key] = value
+		}
+	}
+	return env
+}
+
+// IsDumb 判断当前终端是否无法处理光标移动等控制序列：TERM为空或为dumb时，
+// survey的重绘会输出乱码，应改用逐行提问
+func IsDumb() bool {
+	return isDumbTerm(os.Getenv("TERM"))
+}
+
+// isDumbTerm 判断TERM的值是否表示不支持控制序列的终端
+func isDumbTerm(termName string) bool {
+	return termName == "" || termName == "dumb"
+}
+
+// survey提问的两种渲染方式，见SurveyRendererMode
+const (
+	RendererRich   = "rich"   // survey的交互式提示：重绘、方向键选择和过滤
+	RendererSimple = "simple" // 逐行打印问题，读取一行作为答案
+)
+
+// SurveyRendererMode 预测在fd上提问时使用哪种渲染方式，用于测试和doctor解释"为什么这里显示得不一样"。
+// 与survey.Runner的判断相同：fd不是终端，或TERM为空或dumb时为RendererSimple，否则为RendererRich。
+// Runner还要求输入输出都是终端，WithForceInteractive时不看TERM，这两点需要调用方自己考虑
+func SurveyRendererMode(fd int) string {
+	return SurveyRendererModeFor(os.Getenv("TERM"), term.IsTerminal(fd))
+}
+
+// SurveyRendererModeFor 按给定的TERM和是否为终端判断渲染方式，见SurveyRendererMode
+func SurveyRendererModeFor(termName string, tty bool) string {
+	if !tty || isDumbTerm(termName) {
+		return RendererSimple
+	}
+	return RendererRich
+}
+
+// DetectColorLevel 根据NO_COLOR、TERM和COLORTERM推断颜色等级
+func DetectColorLevel(env map[string]string) ColorLevel {
+	if _, ok := env["NO_COLOR"]; ok {
+		return ColorNone
+	}
+	termName := env["TERM"]
+	if termName == "" || termName == "dumb" {
+		return ColorNone
+	}
+	switch strings.ToLower(env["COLORTERM"]) {
+	case "truecolor", "24bit":
+		return ColorTrueColor
+	}
+	if strings.Contains(termName, "256color") {
+		return Color256
+	}
+	return Color16
+}
+
+// DetectMultiplexer 返回所在的终端复用器（"tmux"或"screen"），不在复用器中时返回空串
+func DetectMultiplexer(env map[string]string) string {
+	switch {
+	case env["TMUX"] != "":
+		return "tmux"
+	case env["STY"] != "", strings.HasPrefix(env["TERM"], "screen"):
+		return "screen"
+	}
+	return ""
+}
+
+// 终端发送带修饰键的按键所用的协议，见DetectKeyProtocol
+const (
+	KeyProtocolCSIu            = "CSI u"
+	KeyProtocolModifyOtherKeys = "modifyOtherKeys"
+)
+
+// DetectKeyProtocol 根据TERM和TERM_PROGRAM推断终端能否用CSI u或modifyOtherKeys发送
+// Ctrl、Shift、Alt组合键，无法判断时返回空串。终端通常需要程序请求后才会启用这些协议，
+// ParseEscapeSequence能解码两种格式
+func DetectKeyProtocol(env map[string]string) string {
+	termName := env["TERM"]
+	switch {
+	case termName == "xterm-kitty", termName == "xterm-ghostty", strings.HasPrefix(termName, "foot"):
+		return KeyProtocolCSIu
+	}
+	switch env["TERM_PROGRAM"] {
+	case "WezTerm", "ghostty", "iTerm.app":
+		return KeyProtocolCSIu
+	}
+	if env["XTERM_VERSION"] != "" {
+		return KeyProtocolModifyOtherKeys
+	}
+	return ""
+}
+
+// DetectSessionKind 判断当前会话类型，同时满足多个条件时按omnish、ssh、container的顺序取第一个
+func DetectSessionKind(env map[string]string) string {
+	switch {
+	case env["OMNISH_SESSION_ID"] != "":
+		return SessionOmnish
+	case env["SSH_TTY"] != "", env["SSH_CONNECTION"] != "":
+		return SessionSSH
+	case env["KUBERNETES_SERVICE_HOST"] != "", env["CONTAINER"] != "", env["DOCKER"] != "":
+		return SessionContainer
+	}
+	return SessionLocal
+}
+
+// Locale 按POSIX的优先级（LC_ALL、LC_CTYPE、LANG）返回字符处理使用的locale
+func Locale(env map[string]string) string {
+	for _, key := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := env[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// IsWideCharLocale 判断locale是否为中文、日文或韩文，这类环境下输入的文本多为宽字符，
+// 光标位置需要按utils.DisplayWidth计算
+func IsWideCharLocale(env map[string]string) bool {
+	locale := strings.ToLower(Locale(env))
+	for _, lang := range []string{"zh", "ja", "ko"} {
+		if locale == lang || strings.HasPrefix(locale, lang+"_") || strings.HasPrefix(locale, lang+".") {
+			return true
+		}
+	}
+	return false
+}
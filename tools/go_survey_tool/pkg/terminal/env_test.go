@@ -0,0 +1,175 @@
+package terminal_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+func TestEnvMap(t *testing.T) {
+	env := terminal.EnvMap([]string{"TERM=xterm", "EMPTY=", "A=b=c", "BROKEN"})
+	expected := map[string]string{"TERM": "xterm", "EMPTY": "", "A": "b=c"}
+	if !reflect.DeepEqual(env, expected) {
+		t.Errorf("EnvMap() = %v, want %v", env, expected)
+	}
+}
+
+func TestDetectColorLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected terminal.ColorLevel
+	}{
+		{"No TERM", map[string]string{}, terminal.ColorNone},
+		{"Dumb", map[string]string{"TERM": "dumb", "COLORTERM": "truecolor"}, terminal.ColorNone},
+		{"NO_COLOR wins", map[string]string{"TERM": "xterm-256color", "NO_COLOR": ""}, terminal.ColorNone},
+		{"Basic", map[string]string{"TERM": "xterm"}, terminal.Color16},
+		{"256 colors", map[string]string{"TERM": "xterm-256color"}, terminal.Color256},
+		{"Truecolor", map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, terminal.ColorTrueColor},
+		{"24bit", map[string]string{"TERM": "xterm", "COLORTERM": "24bit"}, terminal.ColorTrueColor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminal.DetectColorLevel(tt.env); got != tt.expected {
+				t.Errorf("DetectColorLevel() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectMultiplexer(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{"None", map[string]string{"TERM": "xterm"}, ""},
+		{"Tmux", map[string]string{"TMUX": "/tmp/tmux-0/default,1,0", "TERM": "screen"}, "tmux"},
+		{"Screen by STY", map[string]string{"STY": "123.pts-0"}, "screen"},
+		{"Screen by TERM", map[string]string{"TERM": "screen-256color"}, "screen"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminal.DetectMultiplexer(tt.env); got != tt.expected {
+				t.Errorf("DetectMultiplexer() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectKeyProtocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{"Plain xterm-compatible", map[string]string{"TERM": "xterm-256color"}, ""},
+		{"Kitty", map[string]string{"TERM": "xterm-kitty"}, terminal.KeyProtocolCSIu},
+		{"Foot", map[string]string{"TERM": "foot-extra"}, terminal.KeyProtocolCSIu},
+		{"WezTerm", map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "WezTerm"}, terminal.KeyProtocolCSIu},
+		{"XTerm", map[string]string{"TERM": "xterm", "XTERM_VERSION": "XTerm(390)"}, terminal.KeyProtocolModifyOtherKeys},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminal.DetectKeyProtocol(tt.env); got != tt.expected {
+				t.Errorf("DetectKeyProtocol() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectSessionKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{"Local", map[string]string{}, terminal.SessionLocal},
+		{"SSH", map[string]string{"SSH_CONNECTION": "1.2.3.4 22 5.6.7.8 22"}, terminal.SessionSSH},
+		{"Container", map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1"}, terminal.SessionContainer},
+		{"Omnish over SSH", map[string]string{"OMNISH_SESSION_ID": "abc", "SSH_TTY": "/dev/pts/1"}, terminal.SessionOmnish},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminal.DetectSessionKind(tt.env); got != tt.expected {
+				t.Errorf("DetectSessionKind() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsWideCharLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected bool
+	}{
+		{"Unset", map[string]string{}, false},
+		{"English", map[string]string{"LANG": "en_US.UTF-8"}, false},
+		{"Chinese", map[string]string{"LANG": "zh_CN.UTF-8"}, true},
+		{"Japanese without region", map[string]string{"LANG": "ja"}, true},
+		{"Korean", map[string]string{"LANG": "ko_KR.eucKR"}, true},
+		{"LC_CTYPE overrides LANG", map[string]string{"LANG": "en_US.UTF-8", "LC_CTYPE": "zh_TW.UTF-8"}, true},
+		{"LC_ALL overrides all", map[string]string{"LC_CTYPE": "zh_CN.UTF-8", "LC_ALL": "C"}, false},
+		{"Prefix is not enough", map[string]string{"LANG": "zu_ZA.UTF-8"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminal.IsWideCharLocale(tt.env); got != tt.expected {
+				t.Errorf("IsWideCharLocale() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsDumb(t *testing.T) {
+	for _, tt := range []struct {
+		term     string
+		expected bool
+	}{
+		{"", true},
+		{"dumb", true},
+		{"xterm-256color", false},
+		{"screen", false},
+	} {
+		t.Setenv("TERM", tt.term)
+		if got := terminal.IsDumb(); got != tt.expected {
+			t.Errorf("IsDumb() with TERM=%q = %v, want %v", tt.term, got, tt.expected)
+		}
+	}
+}
+
+func TestSurveyRendererModeFor(t *testing.T) {
+	tests := []struct {
+		term string
+		tty  bool
+		want string
+	}{
+		{"xterm-256color", true, terminal.RendererRich},
+		{"screen", true, terminal.RendererRich},
+		{"linux", true, terminal.RendererRich},
+		{"dumb", true, terminal.RendererSimple},
+		{"", true, terminal.RendererSimple},
+		{"xterm-256color", false, terminal.RendererSimple},
+	}
+	for _, tt := range tests {
+		if got := terminal.SurveyRendererModeFor(tt.term, tt.tty); got != tt.want {
+			t.Errorf("SurveyRendererModeFor(%q, %v) = %q, want %q", tt.term, tt.tty, got, tt.want)
+		}
+	}
+}
+
+func TestSurveyRendererModePipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	t.Setenv("TERM", "xterm-256color")
+	if got := terminal.SurveyRendererMode(int(r.Fd())); got != terminal.RendererSimple {
+		t.Errorf("SurveyRendererMode(pipe) = %q, want simple", got)
+	}
+}
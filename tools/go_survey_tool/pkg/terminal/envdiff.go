@@ -0,0 +1,72 @@
+package terminal
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// 环境变量差异的类型
+const (
+	EnvAdded   = "added"
+	EnvRemoved = "removed"
+	EnvChanged = "changed"
+)
+
+// terminalEnvVars 影响终端检测和显示的环境变量，比较时优先显示
+var terminalEnvVars = map[string]bool{
+	"TERM": true, "COLORTERM": true, "SHELL": true, "TMUX": true,
+	"STY": true, "NO_COLOR": true, "LANG": true, "LC_ALL": true, "LC_CTYPE": true,
+	"SSH_TTY": true, "OMNISH_SESSION_ID": true,
+}
+
+// IsTerminalEnvVar 判断环境变量是否与终端行为相关
+func IsTerminalEnvVar(key string) bool {
+	return terminalEnvVars[key]
+}
+
+// EnvChange 两份环境变量之间的一处差异
+type EnvChange struct {
+	Key  string
+	Kind string // EnvAdded、EnvRemoved或EnvChanged
+	Old  string // EnvAdded时为空
+	New  string // EnvRemoved时为空
+}
+
+// ParseEnvFile 解析保存的环境变量快照
+// 支持每行一个KEY=VALUE（envtest dump的输出），也支持/proc/PID/environ那样以NUL分隔的格式；
+// 值中含有换行时只有NUL分隔的格式能准确保存
+func ParseEnvFile(data []byte) map[string]string {
+	sep := []byte("\n")
+	if bytes.IndexByte(data, 0) >= 0 {
+		sep = []byte{0}
+	}
+	var environ []string
+	for _, entry := range bytes.Split(data, sep) {
+		if line := strings.TrimSuffix(string(entry), "\r"); line != "" {
+			environ = append(environ, line)
+		}
+	}
+	return EnvMap(environ)
+}
+
+// DiffEnv 比较两份环境变量，返回按变量名排序的差异，值按字节精确比较
+func DiffEnv(old, new map[string]string) []EnvChange {
+	var changes []EnvChange
+	for key, oldValue := range old {
+		newValue, ok := new[key]
+		switch {
+		case !ok:
+			changes = append(changes, EnvChange{Key: key, Kind: EnvRemoved, Old: oldValue})
+		case newValue != oldValue:
+			changes = append(changes, EnvChange{Key: key, Kind: EnvChanged, Old: oldValue, New: newValue})
+		}
+	}
+	for key, newValue := range new {
+		if _, ok := old[key]; !ok {
+			changes = append(changes, EnvChange{Key: key, Kind: EnvAdded, New: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
@@ -0,0 +1,53 @@
+package terminal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	expected := map[string]string{"TERM": "xterm", "PS1": "a=b", "EMPTY": ""}
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"Lines", "TERM=xterm\nPS1=a=b\n\nEMPTY=\n"},
+		{"CRLF", "TERM=xterm\r\nPS1=a=b\r\nEMPTY=\r\n"},
+		{"NUL separated", "TERM=xterm\x00PS1=a=b\x00EMPTY=\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminal.ParseEnvFile([]byte(tt.data)); !reflect.DeepEqual(got, expected) {
+				t.Errorf("ParseEnvFile() = %v, want %v", got, expected)
+			}
+		})
+	}
+
+	t.Run("Multi-line value with NUL", func(t *testing.T) {
+		got := terminal.ParseEnvFile([]byte("F=line1\nline2\x00TERM=xterm"))
+		if got["F"] != "line1\nline2" || got["TERM"] != "xterm" {
+			t.Errorf("ParseEnvFile() = %q", got)
+		}
+	})
+}
+
+func TestDiffEnv(t *testing.T) {
+	old := map[string]string{"TERM": "xterm", "HOME": "/root", "TMUX": "/tmp/tmux", "PATH": "/bin"}
+	new := map[string]string{"TERM": "xterm-256color", "HOME": "/root", "PATH": "/bin ", "COLORTERM": "truecolor"}
+
+	expected := []terminal.EnvChange{
+		{Key: "COLORTERM", Kind: terminal.EnvAdded, New: "truecolor"},
+		{Key: "PATH", Kind: terminal.EnvChanged, Old: "/bin", New: "/bin "},
+		{Key: "TERM", Kind: terminal.EnvChanged, Old: "xterm", New: "xterm-256color"},
+		{Key: "TMUX", Kind: terminal.EnvRemoved, Old: "/tmp/tmux"},
+	}
+	if got := terminal.DiffEnv(old, new); !reflect.DeepEqual(got, expected) {
+		t.Errorf("DiffEnv() = %+v, want %+v", got, expected)
+	}
+
+	if got := terminal.DiffEnv(old, old); len(got) != 0 {
+		t.Errorf("DiffEnv() of identical envs = %+v, want none", got)
+	}
+}
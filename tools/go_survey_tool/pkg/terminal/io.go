@@ -0,0 +1,53 @@
+package terminal
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// IOKind 标准输入输出是否连接到终端
+type IOKind struct {
+	StdinTTY  bool
+	StdoutTTY bool
+	StderrTTY bool
+}
+
+// IO 一次性检测三个标准流是否为终端，nil视为非终端
+// 测试中可以传入os.Pipe创建的文件模拟管道
+func IO(stdin, stdout, stderr *os.File) IOKind {
+	return IOKind{
+		StdinTTY:  isTTY(stdin),
+		StdoutTTY: isTTY(stdout),
+		StderrTTY: isTTY(stderr),
+	}
+}
+
+// Interactive 输入和输出都是终端时才能进行交互式提问
+func (k IOKind) Interactive() bool {
+	return k.StdinTTY && k.StdoutTTY
+}
+
+// isTTY 判断文件是否为终端
+func isTTY(f *os.File) bool {
+	return f != nil && term.IsTerminal(int(f.Fd()))
+}
+
+// CheckIOConsistency 检查当前进程的标准流，见IOKind.Warnings
+func CheckIOConsistency() []string {
+	return IO(os.Stdin, os.Stdout, os.Stderr).Warnings()
+}
+
+// Warnings 输入和输出只有一方是终端时（例如survey-tool | tee log）返回说明情况的警告，
+// 交互式提问在这时无法正常绘制。都是终端或都不是终端（脚本中使用）时返回nil
+func (k IOKind) Warnings() []string {
+	switch {
+	case k.StdinTTY && !k.StdoutTTY && k.StderrTTY:
+		return []string{"stdout is not a terminal (redirected or piped): prompts are shown on stderr instead and stdout only gets the results"}
+	case k.StdinTTY && !k.StdoutTTY:
+		return []string{"stdout and stderr are not terminals (redirected or piped): prompts cannot be drawn and questions are asked as plain text"}
+	case !k.StdinTTY && k.StdoutTTY:
+		return []string{"stdin is not a terminal (redirected or piped): answers are read from it line by line instead of interactive prompts"}
+	}
+	return nil
+}
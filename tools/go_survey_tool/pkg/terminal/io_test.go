@@ -0,0 +1,88 @@
+package terminal_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+func TestIOWithPipes(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	kind := terminal.IO(r, w, w)
+	if kind.StdinTTY || kind.StdoutTTY || kind.StderrTTY {
+		t.Errorf("pipes reported as terminals: %+v", kind)
+	}
+	if kind.Interactive() {
+		t.Error("pipes should not be interactive")
+	}
+}
+
+func TestIOWithNilFiles(t *testing.T) {
+	if kind := terminal.IO(nil, nil, nil); kind != (terminal.IOKind{}) {
+		t.Errorf("nil files reported as terminals: %+v", kind)
+	}
+}
+
+func TestIOKindInteractive(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     terminal.IOKind
+		expected bool
+	}{
+		{"Both terminals", terminal.IOKind{StdinTTY: true, StdoutTTY: true}, true},
+		{"Stdin piped", terminal.IOKind{StdoutTTY: true, StderrTTY: true}, false},
+		{"Stdout piped", terminal.IOKind{StdinTTY: true, StderrTTY: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.kind.Interactive(); got != tt.expected {
+				t.Errorf("Interactive() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIOKindWarnings(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	if warnings := terminal.IO(r, w, w).Warnings(); warnings != nil {
+		t.Errorf("all pipes should not warn, got %q", warnings)
+	}
+
+	tests := []struct {
+		name string
+		kind terminal.IOKind
+		want string
+	}{
+		{"Stdout piped", terminal.IOKind{StdinTTY: true, StderrTTY: true}, "prompts are shown on stderr"},
+		{"Stdout and stderr piped", terminal.IOKind{StdinTTY: true}, "asked as plain text"},
+		{"Stdin piped", terminal.IOKind{StdoutTTY: true, StderrTTY: true}, "stdin is not a terminal"},
+		{"All terminals", terminal.IOKind{StdinTTY: true, StdoutTTY: true, StderrTTY: true}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := tt.kind.Warnings()
+			if tt.want == "" {
+				if warnings != nil {
+					t.Errorf("Warnings() = %q, want none", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.want) {
+				t.Errorf("Warnings() = %q, want one containing %q", warnings, tt.want)
+			}
+		})
+	}
+}
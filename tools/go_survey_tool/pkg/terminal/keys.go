@@ -0,0 +1,272 @@
+package terminal
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Key 按键类型
+type Key int
+
+const (
+	KeyUnknown   Key = iota // 无法识别的输入
+	KeyRune                 // 普通字符，见KeyEvent.Rune
+	KeyEnter                // 回车
+	KeyTab                  // Tab
+	KeyBackspace            // 退格
+	KeyEscape               // 单独的ESC
+	KeyUp                   // 上箭头
+	KeyDown                 // 下箭头
+	KeyRight                // 右箭头
+	KeyLeft                 // 左箭头
+	KeyHome                 // Home
+	KeyEnd                  // End
+	KeyInsert               // Insert
+	KeyDelete               // Delete
+	KeyPageUp               // PageUp
+	KeyPageDown             // PageDown
+)
+
+// KeyEvent 一次按键
+type KeyEvent struct {
+	Key  Key
+	Rune rune // Key为KeyRune时的字符；Ctrl组合键时为对应的小写字母
+	Ctrl bool // 是否按住了Ctrl
+	// Shift和Alt只有终端用带修饰键参数的序列（如CSI u）发送按键时才能区分
+	Shift bool
+	Alt   bool
+	Raw   []byte // 按键对应的原始字节
+
+	At time.Duration // 距录制开始的时间，只有KeyRecorder录制的按键才有
+}
+
+// IsCtrl 判断是否为Ctrl加指定字母的组合键，例如IsCtrl('c')表示Ctrl-C
+func (e KeyEvent) IsCtrl(r rune) bool {
+	return e.Key == KeyRune && e.Ctrl && e.Rune == r
+}
+
+const esc = 0x1b
+
+// ReadKey 从r读取一次按键
+// 以ESC开头的转义序列只有在同一批数据中到达时才会被合并，单独的ESC视为KeyEscape
+func ReadKey(r *bufio.Reader) (KeyEvent, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return KeyEvent{}, err
+	}
+
+	if b == esc {
+		seq := []byte{b}
+		if r.Buffered() > 0 {
+			next, _ := r.ReadByte()
+			seq = append(seq, next)
+			if next == '[' || next == 'O' {
+				seq = readSequenceTail(r, seq, next == 'O')
+			}
+		}
+		ev, n := ParseEscapeSequence(seq)
+		if n < len(seq) {
+			// 无法识别的转义序列整体作为一次未知按键，避免残留字节被当作普通输入
+			return KeyEvent{Key: KeyUnknown, Raw: seq}, nil
+		}
+		return ev, nil
+	}
+
+	if b >= utf8.RuneSelf {
+		if err := r.UnreadByte(); err != nil {
+			return KeyEvent{}, err
+		}
+		ch, size, err := r.ReadRune()
+		if err != nil {
+			return KeyEvent{}, err
+		}
+		if ch == utf8.RuneError && size == 1 {
+			// 无效的UTF-8字节单独作为未知按键，不能按RuneError重新编码
+			return KeyEvent{Key: KeyUnknown, Raw: []byte{b}}, nil
+		}
+		raw := make([]byte, size)
+		utf8.EncodeRune(raw, ch)
+		return KeyEvent{Key: KeyRune, Rune: ch, Raw: raw}, nil
+	}
+
+	return decodeByte(b), nil
+}
+
+// readSequenceTail 读取CSI（ESC [）或SS3（ESC O）序列的剩余部分直到结束字节
+func readSequenceTail(r *bufio.Reader, seq []byte, single bool) []byte {
+	for r.Buffered() > 0 {
+		c, err := r.ReadByte()
+		if err != nil {
+			break
+		}
+		seq = append(seq, c)
+		// 结束字节范围为0x40-0x7e；SS3序列只有一个字节
+		if single || (c >= 0x40 && c <= 0x7e) {
+			break
+		}
+	}
+	return seq
+}
+
+// decodeByte 解码单字节按键（ASCII字符与控制字符）
+func decodeByte(b byte) KeyEvent {
+	raw := []byte{b}
+	switch {
+	case b == '\r' || b == '\n':
+		return KeyEvent{Key: KeyEnter, Raw: raw}
+	case b == '\t':
+		return KeyEvent{Key: KeyTab, Raw: raw}
+	case b == 0x7f || b == 0x08:
+		return KeyEvent{Key: KeyBackspace, Raw: raw}
+	case b >= 1 && b <= 26:
+		return KeyEvent{Key: KeyRune, Rune: rune('a' + b - 1), Ctrl: true, Raw: raw}
+	case b < 0x20:
+		return KeyEvent{Key: KeyUnknown, Raw: raw}
+	}
+	return KeyEvent{Key: KeyRune, Rune: rune(b), Raw: raw}
+}
+
+// ParseEscapeSequence 解析b开头的转义序列，返回按键和消耗的字节数
+// b必须以ESC开头；只有ESC或无法识别时返回KeyEscape并只消耗ESC这一个字节。
+// 支持的序列：ESC [ A-D/H/F、ESC O A-D/H/F、ESC [ n ~ 形式的编辑键，
+// 以及带修饰键的ESC [ code ; mods u（CSI u）和ESC [ 27 ; mods ; code ~（xterm的modifyOtherKeys），
+// 方向键和编辑键的修饰键参数（如ESC [ 1 ; 5 A）同样解码到Ctrl、Shift和Alt
+func ParseEscapeSequence(b []byte) (KeyEvent, int) {
+	if len(b) == 0 || b[0] != esc {
+		return KeyEvent{Key: KeyUnknown}, 0
+	}
+	escape := KeyEvent{Key: KeyEscape, Raw: b[:1]}
+	if len(b) < 3 || (b[1] != '[' && b[1] != 'O') {
+		return escape, 1
+	}
+
+	if b[1] == 'O' {
+		if key, ok := finalKeys[b[2]]; ok {
+			return KeyEvent{Key: key, Raw: b[:3]}, 3
+		}
+		return escape, 1
+	}
+
+	// CSI：先读取参数字节，再看结束字节
+	end := 2
+	for end < len(b) && b[end] >= 0x30 && b[end] <= 0x3f {
+		end++
+	}
+	if end >= len(b) {
+		return escape, 1
+	}
+	params, final := strings.Split(string(b[2:end]), ";"), b[end]
+	raw := b[:end+1]
+
+	var ev KeyEvent
+	var ok bool
+	switch {
+	case final == 'u':
+		ev, ok = decodeCSIu(params)
+	case final == '~' && len(params) == 3 && params[0] == "27":
+		ev, ok = decodeCSIu([]string{params[2], params[1]})
+	case final == '~':
+		ev.Key, ok = tildeKeys[params[0]]
+		ok = ok && applyModifierParam(&ev, params)
+	default:
+		ev.Key, ok = finalKeys[final]
+		ok = ok && applyModifierParam(&ev, params)
+	}
+	if !ok {
+		return escape, 1
+	}
+	ev.Raw = raw
+	return ev, end + 1
+}
+
+// decodeCSIu 解码CSI u序列的参数：params[0]为按键的Unicode码点（kitty协议中":"后的备用码点被忽略），
+// params[1]为可选的修饰键参数
+func decodeCSIu(params []string) (KeyEvent, bool) {
+	field, _, _ := strings.Cut(params[0], ":")
+	code, err := strconv.Atoi(field)
+	if err != nil || code < 0 || !utf8.ValidRune(rune(code)) {
+		return KeyEvent{}, false
+	}
+	var ev KeyEvent
+	switch code {
+	case '\r':
+		ev.Key = KeyEnter
+	case '\t':
+		ev.Key = KeyTab
+	case 0x7f, 0x08:
+		ev.Key = KeyBackspace
+	case esc:
+		ev.Key = KeyEscape
+	default:
+		if code < 0x20 {
+			return KeyEvent{}, false
+		}
+		ev.Key, ev.Rune = KeyRune, rune(code)
+	}
+	if !applyModifierParam(&ev, params) {
+		return KeyEvent{}, false
+	}
+	// 与单字节的Ctrl组合键一致，Ctrl加字母时Rune为小写字母
+	if ev.Ctrl && ev.Key == KeyRune {
+		ev.Rune = unicode.ToLower(ev.Rune)
+	}
+	return ev, true
+}
+
+// 修饰键参数减1后的各位
+const (
+	modShift = 1 << iota
+	modAlt
+	modCtrl
+)
+
+// applyModifierParam 按CSI序列的第二个参数设置ev的Shift、Alt和Ctrl，只有一个参数时没有修饰键，
+// 参数多于两个时返回false。修饰键参数为1加上各修饰键的位，kitty协议中":"后的事件类型被忽略，
+// Super等其他修饰键不报告
+func applyModifierParam(ev *KeyEvent, params []string) bool {
+	if len(params) == 1 {
+		return true
+	}
+	if len(params) != 2 {
+		return false
+	}
+	field, _, _ := strings.Cut(params[1], ":")
+	if field == "" {
+		return true
+	}
+	mods, err := strconv.Atoi(field)
+	if err != nil || mods < 1 {
+		return false
+	}
+	mods--
+	ev.Shift = mods&modShift != 0
+	ev.Alt = mods&modAlt != 0
+	ev.Ctrl = mods&modCtrl != 0
+	return true
+}
+
+// finalKeys CSI/SS3序列结束字节对应的按键
+var finalKeys = map[byte]Key{
+	'A': KeyUp,
+	'B': KeyDown,
+	'C': KeyRight,
+	'D': KeyLeft,
+	'H': KeyHome,
+	'F': KeyEnd,
+}
+
+// tildeKeys ESC [ n ~ 形式中参数n对应的按键
+var tildeKeys = map[string]Key{
+	"1": KeyHome,
+	"2": KeyInsert,
+	"3": KeyDelete,
+	"4": KeyEnd,
+	"5": KeyPageUp,
+	"6": KeyPageDown,
+	"7": KeyHome,
+	"8": KeyEnd,
+}
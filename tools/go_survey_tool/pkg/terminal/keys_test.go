@@ -0,0 +1,141 @@
+package terminal_test
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+func TestParseEscapeSequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		key      terminal.Key
+		consumed int
+	}{
+		{"Up", "\x1b[A", terminal.KeyUp, 3},
+		{"Down", "\x1b[B", terminal.KeyDown, 3},
+		{"Right", "\x1b[C", terminal.KeyRight, 3},
+		{"Left", "\x1b[D", terminal.KeyLeft, 3},
+		{"Application mode up", "\x1bOA", terminal.KeyUp, 3},
+		{"Home", "\x1b[H", terminal.KeyHome, 3},
+		{"Delete", "\x1b[3~", terminal.KeyDelete, 4},
+		{"PageDown", "\x1b[6~", terminal.KeyPageDown, 4},
+		{"Modified arrow", "\x1b[1;5A", terminal.KeyUp, 6},
+		{"Trailing bytes not consumed", "\x1b[Bxyz", terminal.KeyDown, 3},
+		{"Lone escape", "\x1b", terminal.KeyEscape, 1},
+		{"Incomplete CSI", "\x1b[1", terminal.KeyEscape, 1},
+		{"Unknown final byte", "\x1b[Z", terminal.KeyEscape, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, n := terminal.ParseEscapeSequence([]byte(tt.input))
+			if ev.Key != tt.key || n != tt.consumed {
+				t.Errorf("ParseEscapeSequence(%q) = (%v, %d), want (%v, %d)", tt.input, ev.Key, n, tt.key, tt.consumed)
+			}
+		})
+	}
+
+	if _, n := terminal.ParseEscapeSequence([]byte("a")); n != 0 {
+		t.Errorf("non-escape input should not be consumed, got %d", n)
+	}
+}
+
+func TestParseEscapeSequenceModifiers(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  terminal.KeyEvent
+	}{
+		{"CSI u Ctrl-a", "\x1b[97;5u", terminal.KeyEvent{Key: terminal.KeyRune, Rune: 'a', Ctrl: true}},
+		{"CSI u Ctrl-Shift-A", "\x1b[65;6u", terminal.KeyEvent{Key: terminal.KeyRune, Rune: 'a', Ctrl: true, Shift: true}},
+		{"CSI u Alt-x", "\x1b[120;3u", terminal.KeyEvent{Key: terminal.KeyRune, Rune: 'x', Alt: true}},
+		{"CSI u Shift-Enter", "\x1b[13;2u", terminal.KeyEvent{Key: terminal.KeyEnter, Shift: true}},
+		{"CSI u Ctrl-Tab", "\x1b[9;5u", terminal.KeyEvent{Key: terminal.KeyTab, Ctrl: true}},
+		{"CSI u Alt-Backspace", "\x1b[127;3u", terminal.KeyEvent{Key: terminal.KeyBackspace, Alt: true}},
+		{"CSI u Escape without modifiers", "\x1b[27u", terminal.KeyEvent{Key: terminal.KeyEscape}},
+		{"CSI u non-ASCII", "\x1b[20320;3u", terminal.KeyEvent{Key: terminal.KeyRune, Rune: '你', Alt: true}},
+		{"Kitty alternate key and event type", "\x1b[97:65;5:1u", terminal.KeyEvent{Key: terminal.KeyRune, Rune: 'a', Ctrl: true}},
+		{"modifyOtherKeys Ctrl-Enter", "\x1b[27;5;13~", terminal.KeyEvent{Key: terminal.KeyEnter, Ctrl: true}},
+		{"modifyOtherKeys Ctrl-Alt-b", "\x1b[27;7;98~", terminal.KeyEvent{Key: terminal.KeyRune, Rune: 'b', Ctrl: true, Alt: true}},
+		{"Ctrl-Up", "\x1b[1;5A", terminal.KeyEvent{Key: terminal.KeyUp, Ctrl: true}},
+		{"Shift-Delete", "\x1b[3;2~", terminal.KeyEvent{Key: terminal.KeyDelete, Shift: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, n := terminal.ParseEscapeSequence([]byte(tt.input))
+			if n != len(tt.input) {
+				t.Fatalf("ParseEscapeSequence(%q) consumed %d bytes, want %d", tt.input, n, len(tt.input))
+			}
+			if ev.Key != tt.want.Key || ev.Rune != tt.want.Rune || ev.Ctrl != tt.want.Ctrl || ev.Shift != tt.want.Shift || ev.Alt != tt.want.Alt {
+				t.Errorf("ParseEscapeSequence(%q) = %+v, want %+v", tt.input, ev, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEscapeSequenceInvalidCSIu(t *testing.T) {
+	for _, input := range []string{"\x1b[u", "\x1b[x;5u", "\x1b[97;0u", "\x1b[3u", "\x1b[97;5;1u", "\x1b[1;5;2A"} {
+		if ev, n := terminal.ParseEscapeSequence([]byte(input)); ev.Key != terminal.KeyEscape || n != 1 {
+			t.Errorf("ParseEscapeSequence(%q) = (%v, %d), want a lone escape", input, ev.Key, n)
+		}
+	}
+}
+
+func TestReadKeyCSIu(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("\x1b[99;5u\x1b[13;2u"))
+	ev, err := terminal.ReadKey(r)
+	if err != nil || !ev.IsCtrl('c') {
+		t.Errorf("ReadKey() = %+v, %v, want Ctrl-C", ev, err)
+	}
+	ev, err = terminal.ReadKey(r)
+	if err != nil || ev.Key != terminal.KeyEnter || !ev.Shift {
+		t.Errorf("ReadKey() = %+v, %v, want Shift-Enter", ev, err)
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("a\x1b[B\r\x7f\x03你\x1b[Z\xe4b"))
+
+	expected := []terminal.KeyEvent{
+		{Key: terminal.KeyRune, Rune: 'a'},
+		{Key: terminal.KeyDown},
+		{Key: terminal.KeyEnter},
+		{Key: terminal.KeyBackspace},
+		{Key: terminal.KeyRune, Rune: 'c', Ctrl: true},
+		{Key: terminal.KeyRune, Rune: '你'},
+		{Key: terminal.KeyUnknown},
+		{Key: terminal.KeyUnknown},
+		{Key: terminal.KeyRune, Rune: 'b'},
+	}
+	for i, want := range expected {
+		ev, err := terminal.ReadKey(r)
+		if err != nil {
+			t.Fatalf("key %d: ReadKey() error = %v", i, err)
+		}
+		if ev.Key != want.Key || ev.Rune != want.Rune || ev.Ctrl != want.Ctrl {
+			t.Errorf("key %d: ReadKey() = %+v, want %+v", i, ev, want)
+		}
+	}
+
+	if _, err := terminal.ReadKey(r); err != io.EOF {
+		t.Errorf("ReadKey() at end = %v, want io.EOF", err)
+	}
+}
+
+func TestKeyEventIsCtrl(t *testing.T) {
+	ev := terminal.KeyEvent{Key: terminal.KeyRune, Rune: 'c', Ctrl: true}
+	if !ev.IsCtrl('c') {
+		t.Error("expected Ctrl-C")
+	}
+	if ev.IsCtrl('d') {
+		t.Error("Ctrl-C reported as Ctrl-D")
+	}
+	if (terminal.KeyEvent{Key: terminal.KeyRune, Rune: 'c'}).IsCtrl('c') {
+		t.Error("plain 'c' reported as Ctrl-C")
+	}
+}
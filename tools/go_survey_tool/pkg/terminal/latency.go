@@ -0,0 +1,26 @@
+package terminal
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// ErrNotTerminal fd不是终端，无法发送查询
+var ErrNotTerminal = errors.New("not a terminal")
+
+// MeasureLatency 测量终端输入的往返时间：在raw模式下发送samples次设备状态查询，
+// 记录从写出查询到读到终端回复的时间。回复和按键经过同一条路径（例如ssh连接），
+// 用它可以估计转义序列的各个字节最多会相隔多久到达。
+// 每次最多等待timeout，超时返回ErrTerminalUnresponsive；fd不是终端时返回ErrNotTerminal。
+// 返回前恢复终端原来的状态，测量期间的按键被读走丢弃
+func MeasureLatency(fd, samples int, timeout time.Duration) ([]time.Duration, error) {
+	if !term.IsTerminal(fd) {
+		return nil, ErrNotTerminal
+	}
+	if samples <= 0 {
+		return nil, nil
+	}
+	return measureLatency(fd, samples, timeout)
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package terminal
+
+import (
+	"errors"
+	"time"
+)
+
+// measureLatency 在不支持发送查询的平台上总是返回错误
+func measureLatency(fd, samples int, timeout time.Duration) ([]time.Duration, error) {
+	return nil, errors.New("measuring input latency is not supported on this platform")
+}
@@ -0,0 +1,36 @@
+//go:build unix
+
+package terminal
+
+import (
+	"bytes"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// measureLatency 进入raw模式后逐次发送设备状态查询并等待回复
+func measureLatency(fd, samples int, timeout time.Duration) ([]time.Duration, error) {
+	restore, err := EnterRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+
+	durations := make([]time.Duration, 0, samples)
+	for i := 0; i < samples; i++ {
+		start := time.Now()
+		if _, err := unix.Write(fd, []byte(statusQuery)); err != nil {
+			return nil, err
+		}
+		reply, err := readReply(fd, timeout)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Contains(reply, []byte(statusReply)) {
+			return nil, ErrTerminalUnresponsive
+		}
+		durations = append(durations, time.Since(start))
+	}
+	return durations, nil
+}
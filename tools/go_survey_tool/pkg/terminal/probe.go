@@ -0,0 +1,35 @@
+package terminal
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// ErrTerminalUnresponsive 在限定时间内没有从输入读到任何内容，继续读取很可能会一直挂起
+var ErrTerminalUnresponsive = errors.New("terminal is not responding")
+
+// statusQuery 设备状态查询（DSR），正常工作的终端会回复ESC [ 0 n
+const statusQuery = "\x1b[5n"
+
+// statusReply 设备状态查询的回复，终端按顺序回复查询，读到它说明之前查询的回复也已经读完
+const statusReply = "\x1b[0n"
+
+// ProbeReadable 在开始提问前检查输入是否可用，超过timeout仍无响应时返回ErrTerminalUnresponsive，
+// 调用方可以给出明确的提示并退出，而不是在读取时一直挂起。
+// fd是终端时发送设备状态查询并等待终端回复，回复会被读走，不会被当作用户输入；
+// 不支持查询的平台上总是认为终端可用。fd不是终端时等待其中有数据或到达EOF
+func ProbeReadable(fd int, timeout time.Duration) error {
+	if term.IsTerminal(fd) {
+		return probeTerminal(fd, timeout)
+	}
+	ready, err := InputReady(fd, timeout)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		return ErrTerminalUnresponsive
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package terminal
+
+import "time"
+
+// probeTerminal 不支持发送查询的平台上总是认为终端可用
+func probeTerminal(fd int, timeout time.Duration) error {
+	return nil
+}
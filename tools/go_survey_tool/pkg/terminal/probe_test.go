@@ -0,0 +1,41 @@
+//go:build unix
+
+package terminal_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+func TestProbeReadablePipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	// 从不写入的管道：读取会一直挂起
+	start := time.Now()
+	if err := terminal.ProbeReadable(int(r.Fd()), 50*time.Millisecond); !errors.Is(err, terminal.ErrTerminalUnresponsive) {
+		t.Fatalf("ProbeReadable() on a silent pipe = %v, want ErrTerminalUnresponsive", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("ProbeReadable() took %v, want it to return after the timeout", elapsed)
+	}
+
+	if _, err := w.Write([]byte("y\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := terminal.ProbeReadable(int(r.Fd()), 50*time.Millisecond); err != nil {
+		t.Errorf("ProbeReadable() with pending input = %v, want nil", err)
+	}
+	var buf [2]byte
+	if n, _ := r.Read(buf[:]); string(buf[:n]) != "y\n" {
+		t.Errorf("ProbeReadable() consumed pipe input, read %q", buf[:n])
+	}
+}
@@ -0,0 +1,64 @@
+//go:build unix
+
+package terminal
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// probeTerminal 在raw模式下发送设备状态查询，等待终端的回复
+// raw模式下回复不会被回显，也不需要等到换行才能读到
+func probeTerminal(fd int, timeout time.Duration) error {
+	restore, err := EnterRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	if _, err := unix.Write(fd, []byte(statusQuery)); err != nil {
+		return err
+	}
+	// 读到完整的回复为止，避免把回复的后半段留给后续的读取
+	_, err = readReply(fd, timeout)
+	return err
+}
+
+// maxReply readReply读取的上限，超过时不再等待设备状态查询的回复
+const maxReply = 256
+
+// readReply 读取终端的回复直到设备状态查询的回复，最多等待timeout，fd需要已经处于raw模式。
+// 只读到部分回复时返回已读到的内容，什么都没读到时返回ErrTerminalUnresponsive
+func readReply(fd int, timeout time.Duration) ([]byte, error) {
+	deadline := time.Now().Add(timeout)
+	var reply []byte
+	var buf [64]byte
+	for !bytes.Contains(reply, []byte(statusReply)) && len(reply) < maxReply {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		ready, err := InputReady(fd, remaining)
+		if err != nil {
+			return nil, err
+		}
+		if !ready {
+			break
+		}
+		n, err := unix.Read(fd, buf[:])
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, io.EOF
+		}
+		reply = append(reply, buf[:n]...)
+	}
+	if len(reply) == 0 {
+		return nil, ErrTerminalUnresponsive
+	}
+	return reply, nil
+}
@@ -0,0 +1,35 @@
+package terminal
+
+import (
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// EnterRaw 把fd切换到raw模式，返回恢复原状态的函数
+//
+//	restore, err := terminal.EnterRaw(fd)
+//	if err != nil {
+//		return err
+//	}
+//	defer restore()
+//
+// restore可以安全地多次调用，只有第一次会真正恢复，之后返回第一次的结果。
+// 在raw模式期间进程被挂起时终端会临时恢复原状态，见HandleSuspend
+func EnterRaw(fd int) (restore func() error, err error) {
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	unhandle := handleSuspend(fd, oldState)
+
+	var once sync.Once
+	var restoreErr error
+	return func() error {
+		once.Do(func() {
+			unhandle()
+			restoreErr = term.Restore(fd, oldState)
+		})
+		return restoreErr
+	}, nil
+}
@@ -0,0 +1,179 @@
+//go:build linux
+
+package terminal_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/ptytest"
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+func TestEnterRawDoubleRestore(t *testing.T) {
+	_, pty := ptytest.Open(t)
+	fd := int(pty.Fd())
+
+	before, err := term.GetState(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restore, err := terminal.EnterRaw(fd)
+	if err != nil {
+		t.Fatalf("EnterRaw() error = %v", err)
+	}
+	if err := restore(); err != nil {
+		t.Fatalf("first restore() error = %v", err)
+	}
+	if err := restore(); err != nil {
+		t.Errorf("second restore() error = %v, want nil", err)
+	}
+
+	after, err := term.GetState(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *after != *before {
+		t.Error("terminal state not restored")
+	}
+}
+
+func TestReadLineRaw(t *testing.T) {
+	master, pty := ptytest.Open(t)
+	fd := int(pty.Fd())
+	// 先进入raw模式再写入按键，否则行规程会在规范模式下按字节处理退格
+	outer, err := terminal.EnterRaw(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outer()
+	before, err := term.GetState(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := master.Write([]byte("你好\x7f!\r")); err != nil {
+		t.Fatal(err)
+	}
+	line, err := terminal.ReadLineRaw(fd, "> ")
+	if err != nil {
+		t.Fatalf("ReadLineRaw() error = %v", err)
+	}
+	if line != "你!" {
+		t.Errorf("ReadLineRaw() = %q, want 你!", line)
+	}
+
+	after, err := term.GetState(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *after != *before {
+		t.Error("terminal state not restored")
+	}
+	if _, err := pty.Stat(); err != nil {
+		t.Errorf("caller's fd should stay open: %v", err)
+	}
+}
+
+func TestProbeReadableTerminal(t *testing.T) {
+	master, pty := ptytest.Open(t)
+	fd := int(pty.Fd())
+
+	// 没有终端程序回复查询，相当于挂住的pty
+	if err := terminal.ProbeReadable(fd, 50*time.Millisecond); !errors.Is(err, terminal.ErrTerminalUnresponsive) {
+		t.Fatalf("ProbeReadable() without a reply = %v, want ErrTerminalUnresponsive", err)
+	}
+	drain := make([]byte, 16)
+	master.Read(drain)
+
+	// 模拟终端程序：读到状态查询后回复ESC [ 0 n
+	go func() {
+		buf := make([]byte, 16)
+		if n, err := master.Read(buf); err == nil && string(buf[:n]) == "\x1b[5n" {
+			master.Write([]byte("\x1b[0n"))
+		}
+	}()
+	if err := terminal.ProbeReadable(fd, 2*time.Second); err != nil {
+		t.Errorf("ProbeReadable() with a reply = %v, want nil", err)
+	}
+	if ready, _ := terminal.InputReady(fd, 0); ready {
+		t.Error("the terminal's reply should be consumed by ProbeReadable")
+	}
+
+	// 回复分两次到达时也要读到结尾，不能把后半段留给下一次读取；
+	// 保持raw模式，否则规范模式下没有换行的残留内容不会变为可读
+	outer, err := terminal.EnterRaw(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outer()
+	go func() {
+		buf := make([]byte, 16)
+		if n, err := master.Read(buf); err == nil && string(buf[:n]) == "\x1b[5n" {
+			master.Write([]byte("\x1b["))
+			time.Sleep(50 * time.Millisecond)
+			master.Write([]byte("0n"))
+		}
+	}()
+	if err := terminal.ProbeReadable(fd, 2*time.Second); err != nil {
+		t.Errorf("ProbeReadable() with a split reply = %v, want nil", err)
+	}
+	if ready, _ := terminal.InputReady(fd, 100*time.Millisecond); ready {
+		t.Error("the rest of a split reply should be consumed by ProbeReadable")
+	}
+}
+
+func TestEnterRawNotATerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	if restore, err := terminal.EnterRaw(int(r.Fd())); err == nil || restore != nil {
+		t.Errorf("EnterRaw() on a pipe = (%v, %v), want an error", restore != nil, err)
+	}
+}
+
+func TestDiagnoseRawConflict(t *testing.T) {
+	_, pty := ptytest.Open(t)
+	fd := int(pty.Fd())
+	before, err := term.GetState(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := terminal.DiagnoseRawConflict(fd); err != nil {
+		t.Errorf("DiagnoseRawConflict() on a cooked terminal = %v, want nil", err)
+	}
+	if after, _ := term.GetState(fd); *after != *before {
+		t.Error("DiagnoseRawConflict() should leave the terminal state unchanged")
+	}
+
+	restore, err := terminal.EnterRaw(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restore()
+	if err := terminal.DiagnoseRawConflict(fd); !errors.Is(err, terminal.ErrAlreadyRaw) {
+		t.Errorf("DiagnoseRawConflict() on a raw terminal = %v, want ErrAlreadyRaw", err)
+	}
+}
+
+func TestDiagnoseRawConflictNotATerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	if err := terminal.DiagnoseRawConflict(int(r.Fd())); err == nil {
+		t.Error("DiagnoseRawConflict() on a pipe should report an error")
+	}
+}
@@ -0,0 +1,184 @@
+package terminal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInterrupted 用户在ReadLineRaw中按了Ctrl-C
+var ErrInterrupted = errors.New("input interrupted")
+
+// ErrInputTooLong 输入的一行超过MaxLineLength，通常是误粘贴了大段内容
+var ErrInputTooLong = errors.New("input too long")
+
+// MaxLineLength ReadLineRaw和survey简单模式下一行输入最多的字节数，不大于0时不限制
+var MaxLineLength = 64 << 10
+
+// defaultHistorySize NewHistory的max不大于0时保留的条数
+const defaultHistorySize = 100
+
+// History 行输入的历史记录，只保存在内存中，最多保留max条，超出时丢弃最旧的。
+// 同一个History传给多次ReadLineRawHistory，就可以用上下箭头找回之前的输入
+type History struct {
+	max     int
+	entries []string
+}
+
+// NewHistory 创建最多保留max条的历史记录，max不大于0时为100条
+func NewHistory(max int) *History {
+	if max <= 0 {
+		max = defaultHistorySize
+	}
+	return &History{max: max}
+}
+
+// Add 添加一条记录，空行和与最近一条相同的行不会记录
+func (h *History) Add(line string) {
+	if line == "" || len(h.entries) > 0 && h.entries[len(h.entries)-1] == line {
+		return
+	}
+	h.entries = append(h.entries, line)
+	if len(h.entries) > h.max {
+		h.entries = h.entries[len(h.entries)-h.max:]
+	}
+}
+
+// Entries 返回记录的副本，从旧到新排列
+func (h *History) Entries() []string {
+	return append([]string(nil), h.entries...)
+}
+
+// editLine 从r逐键读取一行并在w上回显，支持基本的行编辑：
+// 退格删除最后一个字符，Ctrl-U清空，Ctrl-W删除前一个单词。编辑按rune进行，不会切开多字节字符。
+// 回车结束输入；Ctrl-C返回ErrInterrupted；输入为空时Ctrl-D返回io.EOF；
+// Ctrl-Z调用suspend挂起进程，继续运行后重新显示当前行。
+// history不为nil时上下箭头在历史记录中切换，回到最新处时恢复正在输入的内容，回车后的行加入history。
+// 行超过maxLen字节（maxLen > 0时）时丢弃这一行直到回车的其余输入并返回ErrInputTooLong；
+// 粘贴的内容在r中还有缓冲时不逐键重绘，避免长行的输出量随长度平方增长
+func editLine(r *bufio.Reader, w io.Writer, prompt string, suspend func() error, history *History, maxLen int) (string, error) {
+	var line []rune
+	// pos 当前显示的历史记录位置，等于len(entries)时显示的是正在输入的draft
+	var entries []string
+	var draft []rune
+	if history != nil {
+		entries = history.entries
+	}
+	pos := len(entries)
+	recall := func(to int) {
+		if pos == len(entries) {
+			draft = line
+		}
+		pos = to
+		if pos == len(entries) {
+			line = draft
+		} else {
+			line = []rune(entries[pos])
+		}
+	}
+	draw := func() {
+		fmt.Fprintf(w, "\r\x1b[2K%s%s", prompt, string(line))
+	}
+
+	draw()
+	size := 0      // line的字节数
+	stale := false // 有没有重绘的修改
+	for {
+		ev, err := ReadKey(r)
+		if err != nil {
+			return "", err
+		}
+
+		redraw := false
+		switch {
+		case ev.Key == KeyEnter:
+			if stale {
+				draw()
+			}
+			fmt.Fprint(w, "\r\n")
+			if history != nil {
+				history.Add(string(line))
+			}
+			return string(line), nil
+		case ev.IsCtrl('c'):
+			fmt.Fprint(w, "\r\n")
+			return "", ErrInterrupted
+		case ev.IsCtrl('z') && suspend != nil:
+			fmt.Fprint(w, "\r\n")
+			if err := suspend(); err != nil {
+				return "", err
+			}
+			redraw = true
+		case ev.IsCtrl('d'):
+			if len(line) == 0 {
+				fmt.Fprint(w, "\r\n")
+				return "", io.EOF
+			}
+			continue
+		case ev.IsCtrl('u'):
+			line = line[:0]
+		case ev.IsCtrl('w'):
+			line = deleteWord(line)
+		case ev.Key == KeyBackspace:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+			}
+		case ev.Key == KeyUp && pos > 0:
+			recall(pos - 1)
+		case ev.Key == KeyDown && pos < len(entries):
+			recall(pos + 1)
+		case ev.Key == KeyRune && !ev.Ctrl:
+			if maxLen > 0 && size+utf8.RuneLen(ev.Rune) > maxLen {
+				err := discardLine(r)
+				fmt.Fprint(w, "\r\n")
+				if err != nil && err != io.EOF {
+					return "", err
+				}
+				return "", fmt.Errorf("%w: more than %d bytes", ErrInputTooLong, maxLen)
+			}
+			line = append(line, ev.Rune)
+			size += utf8.RuneLen(ev.Rune)
+		default:
+			continue
+		}
+		if ev.Key != KeyRune || ev.Ctrl {
+			size = len(string(line))
+		}
+		stale = !redraw && r.Buffered() > 0
+		if !stale {
+			draw()
+		}
+	}
+}
+
+// discardLine 丢弃r中直到回车（含）的输入，大段粘贴中还在内核缓冲里的部分也一并读出丢弃，
+// 不会留给下一次读取；期间按Ctrl-C返回ErrInterrupted
+func discardLine(r *bufio.Reader) error {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch b {
+		case '\r', '\n':
+			return nil
+		case 0x03:
+			return ErrInterrupted
+		}
+	}
+}
+
+// deleteWord 删除行尾的空白以及它前面的一个单词
+func deleteWord(line []rune) []rune {
+	end := len(line)
+	for end > 0 && unicode.IsSpace(line[end-1]) {
+		end--
+	}
+	for end > 0 && !unicode.IsSpace(line[end-1]) {
+		end--
+	}
+	return line[:end]
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package terminal
+
+import "errors"
+
+// ReadLineRaw 在不支持的平台上总是返回错误
+func ReadLineRaw(fd int, prompt string) (string, error) {
+	return "", errors.New("ReadLineRaw is not supported on this platform")
+}
+
+// ReadLineRawHistory 在不支持的平台上总是返回错误
+func ReadLineRawHistory(fd int, prompt string, history *History) (string, error) {
+	return ReadLineRaw(fd, prompt)
+}
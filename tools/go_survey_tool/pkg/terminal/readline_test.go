@@ -0,0 +1,170 @@
+package terminal
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestEditLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     string
+		expected string
+	}{
+		{"Plain", "hello\r", "hello"},
+		{"Backspace removes a whole CJK rune", "你好\x7f\r", "你"},
+		{"Backspace on empty line", "\x7f\x7fab\r", "ab"},
+		{"Ctrl-H", "abc\x08\r", "ab"},
+		{"Ctrl-U clears", "hello\x15bye\r", "bye"},
+		{"Ctrl-W deletes a word", "git commit  \x17push\r", "git push"},
+		{"Ctrl-W with CJK", "你好 世界\x17\r", "你好 "},
+		{"Arrow keys ignored", "a\x1b[Db\r", "ab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			line, err := editLine(bufio.NewReader(strings.NewReader(tt.keys)), out, "> ", nil, nil, 0)
+			if err != nil {
+				t.Fatalf("editLine() error = %v", err)
+			}
+			if line != tt.expected {
+				t.Errorf("editLine() = %q, want %q", line, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEditLineRedraw(t *testing.T) {
+	out := &bytes.Buffer{}
+	if _, err := editLine(bufio.NewReader(strings.NewReader("你好\x7f\r")), out, "> ", nil, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	// 退格后整行重绘，只剩下第一个字
+	if !strings.HasSuffix(out.String(), "\r\x1b[2K> 你\r\n") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
+func TestEditLineControlKeys(t *testing.T) {
+	t.Run("Ctrl-C", func(t *testing.T) {
+		_, err := editLine(bufio.NewReader(strings.NewReader("ab\x03")), &bytes.Buffer{}, "", nil, nil, 0)
+		if !errors.Is(err, ErrInterrupted) {
+			t.Errorf("error = %v, want ErrInterrupted", err)
+		}
+	})
+
+	t.Run("Ctrl-D on empty line", func(t *testing.T) {
+		_, err := editLine(bufio.NewReader(strings.NewReader("\x04")), &bytes.Buffer{}, "", nil, nil, 0)
+		if !errors.Is(err, io.EOF) {
+			t.Errorf("error = %v, want io.EOF", err)
+		}
+	})
+
+	t.Run("Ctrl-Z suspends and redraws", func(t *testing.T) {
+		suspended := 0
+		out := &bytes.Buffer{}
+		line, err := editLine(bufio.NewReader(strings.NewReader("ab\x1ac\r")), out, "> ", func() error {
+			suspended++
+			return nil
+		}, nil, 0)
+		if err != nil || line != "abc" || suspended != 1 {
+			t.Errorf("editLine() = %q, %v after %d suspends, want abc after 1", line, err, suspended)
+		}
+		if !strings.Contains(out.String(), "\r\n\r\x1b[2K> ab") {
+			t.Errorf("line should be redrawn after resuming, output %q", out.String())
+		}
+	})
+
+	t.Run("Ctrl-Z ignored without suspend", func(t *testing.T) {
+		line, err := editLine(bufio.NewReader(strings.NewReader("a\x1ab\r")), &bytes.Buffer{}, "", nil, nil, 0)
+		if err != nil || line != "ab" {
+			t.Errorf("editLine() = %q, %v, want ab", line, err)
+		}
+	})
+
+	t.Run("Ctrl-D ignored after input", func(t *testing.T) {
+		line, err := editLine(bufio.NewReader(strings.NewReader("a\x04b\r")), &bytes.Buffer{}, "", nil, nil, 0)
+		if err != nil || line != "ab" {
+			t.Errorf("editLine() = %q, %v, want ab", line, err)
+		}
+	})
+}
+
+func TestEditLineHistory(t *testing.T) {
+	h := NewHistory(0)
+	read := func(keys string) string {
+		t.Helper()
+		line, err := editLine(bufio.NewReader(strings.NewReader(keys)), &bytes.Buffer{}, "> ", nil, h, 0)
+		if err != nil {
+			t.Fatalf("editLine() error = %v", err)
+		}
+		return line
+	}
+
+	read("first\r")
+	read("second\r")
+	if got := read("\x1b[A\r"); got != "second" {
+		t.Errorf("Up = %q, want the previous line", got)
+	}
+	if got := read("\x1b[A\x1b[A!\r"); got != "first!" {
+		t.Errorf("Up Up then typing = %q, want first!", got)
+	}
+	// 记录的顶端继续按上箭头不再移动，下箭头回到最新处恢复正在输入的内容
+	if got := read("dra\x1b[A\x1b[A\x1b[A\x1b[A\x1b[B\x1b[B\x1b[B\x1b[Bft\r"); got != "draft" {
+		t.Errorf("Down back to the draft = %q, want draft", got)
+	}
+
+	want := []string{"first", "second", "first!", "draft"}
+	if got := h.Entries(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Entries() = %q, want %q", got, want)
+	}
+}
+
+func TestHistoryAdd(t *testing.T) {
+	h := NewHistory(2)
+	for _, line := range []string{"a", "", "b", "b", "c"} {
+		h.Add(line)
+	}
+	if got := h.Entries(); strings.Join(got, ",") != "b,c" {
+		t.Errorf("Entries() = %q, want the two newest without blanks or repeats", got)
+	}
+}
+
+func TestEditLineTooLong(t *testing.T) {
+	// 一次粘贴的超长行：返回错误并丢弃缓冲中的其余部分，不会逐键重绘
+	r := bufio.NewReader(strings.NewReader(strings.Repeat("x", 100) + "\r"))
+	out := &bytes.Buffer{}
+	_, err := editLine(r, out, "> ", nil, nil, 64)
+	if !errors.Is(err, ErrInputTooLong) {
+		t.Fatalf("editLine() error = %v, want ErrInputTooLong", err)
+	}
+	if r.Buffered() != 0 {
+		t.Errorf("the rest of the paste should be discarded, %d bytes left", r.Buffered())
+	}
+	if strings.Count(out.String(), "\x1b[2K") != 1 {
+		t.Errorf("pasted keys should not redraw the line one by one, output %q", out.String())
+	}
+
+	// 超出bufio缓冲区的长行：还没读进缓冲的部分也要丢弃，下一次读取从下一行开始
+	r = bufio.NewReader(strings.NewReader(strings.Repeat("x", 10000) + "\rnext\r"))
+	if _, err := editLine(r, &bytes.Buffer{}, "> ", nil, nil, 64); !errors.Is(err, ErrInputTooLong) {
+		t.Fatalf("editLine() of 10000 bytes error = %v, want ErrInputTooLong", err)
+	}
+	if line, err := editLine(r, &bytes.Buffer{}, "> ", nil, nil, 64); err != nil || line != "next" {
+		t.Errorf("editLine() after a long paste = %q, %v, want next", line, err)
+	}
+
+	// 正好在限制以内的行照常返回，多字节字符按字节计算
+	line, err := editLine(bufio.NewReader(strings.NewReader(strings.Repeat("你", 21)+"\r")), &bytes.Buffer{}, "", nil, nil, 64)
+	if err != nil || line != strings.Repeat("你", 21) {
+		t.Errorf("editLine() of 63 bytes = %q, %v, want the line", line, err)
+	}
+	if _, err := editLine(bufio.NewReader(strings.NewReader(strings.Repeat("你", 22)+"\r")), &bytes.Buffer{}, "", nil, nil, 64); !errors.Is(err, ErrInputTooLong) {
+		t.Errorf("editLine() of 66 bytes error = %v, want ErrInputTooLong", err)
+	}
+}
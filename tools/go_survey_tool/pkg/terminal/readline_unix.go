@@ -0,0 +1,36 @@
+//go:build unix
+
+package terminal
+
+import (
+	"bufio"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// ReadLineRaw 在raw模式下从终端fd读取一行，显示prompt并支持基本的行编辑，
+// 按键说明见editLine。超过MaxLineLength的行返回ErrInputTooLong。返回前恢复终端原来的模式
+func ReadLineRaw(fd int, prompt string) (string, error) {
+	return ReadLineRawHistory(fd, prompt, nil)
+}
+
+// ReadLineRawHistory 同ReadLineRaw，history不为nil时可以用上下箭头找回之前的输入，
+// 输入的行也会加入history
+func ReadLineRawHistory(fd int, prompt string, history *History) (string, error) {
+	restore, err := EnterRaw(fd)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	// 复制一份fd用于读写，关闭时不会影响调用方的fd
+	dup, err := unix.Dup(fd)
+	if err != nil {
+		return "", err
+	}
+	f := os.NewFile(uintptr(dup), "tty")
+	defer f.Close()
+
+	return editLine(bufio.NewReader(f), f, prompt, Suspend, history, MaxLineLength)
+}
@@ -0,0 +1,47 @@
+//go:build darwin
+
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// InputReady 等待fd可读，最多等待timeout
+// 可读时返回true，超时返回false；timeout为0时立即返回，小于0时一直等待
+// macOS的poll(2)对tty设备返回POLLNVAL，这里使用select(2)
+func InputReady(fd int, timeout time.Duration) (bool, error) {
+	if fd < 0 || fd >= unix.FD_SETSIZE {
+		return false, fmt.Errorf("fd %d out of range for select", fd)
+	}
+	var deadline time.Time
+	if timeout >= 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		var tv *unix.Timeval
+		if timeout >= 0 {
+			remaining := time.Until(deadline)
+			if remaining < 0 {
+				remaining = 0
+			}
+			t := unix.NsecToTimeval(remaining.Nanoseconds())
+			tv = &t
+		}
+		var fds unix.FdSet
+		fds.Set(fd)
+		n, err := unix.Select(fd+1, &fds, nil, nil, tv)
+		if errors.Is(err, unix.EINTR) {
+			// 被信号中断（如SIGWINCH），按剩余时间重新等待
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		// 写端关闭时select同样报告可读，读取会立即返回EOF
+		return n > 0 && fds.IsSet(fd), nil
+	}
+}
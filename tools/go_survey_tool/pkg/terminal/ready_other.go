@@ -0,0 +1,13 @@
+//go:build !unix && !windows
+
+package terminal
+
+import (
+	"errors"
+	"time"
+)
+
+// InputReady 在不支持的平台上总是返回错误
+func InputReady(fd int, timeout time.Duration) (bool, error) {
+	return false, errors.New("InputReady is not supported on this platform")
+}
@@ -0,0 +1,43 @@
+//go:build unix
+
+package terminal_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+func TestInputReady(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	fd := int(r.Fd())
+
+	start := time.Now()
+	ready, err := terminal.InputReady(fd, 50*time.Millisecond)
+	if err != nil || ready {
+		t.Fatalf("InputReady() on empty pipe = %v, %v, want false", ready, err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("InputReady() returned after %v, expected to wait for the timeout", elapsed)
+	}
+
+	if _, err := w.Write([]byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	ready, err = terminal.InputReady(fd, time.Second)
+	if err != nil || !ready {
+		t.Errorf("InputReady() with pending data = %v, %v, want true", ready, err)
+	}
+
+	if ready, err := terminal.InputReady(fd, 0); err != nil || !ready {
+		t.Errorf("InputReady() with zero timeout = %v, %v, want true", ready, err)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build unix && !darwin
+
+package terminal
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// InputReady 等待fd可读，最多等待timeout
+// 可读时返回true，超时返回false；timeout为0时立即返回，小于0时一直等待
+// macOS的poll(2)不支持tty设备，darwin上改用select实现，见ready_darwin.go
+func InputReady(fd int, timeout time.Duration) (bool, error) {
+	ms := -1
+	if timeout >= 0 {
+		ms = int(timeout / time.Millisecond)
+	}
+	fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+	for {
+		n, err := unix.Poll(fds, ms)
+		if errors.Is(err, unix.EINTR) {
+			// 被信号中断（如SIGWINCH），重新等待
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		// 写端关闭时为POLLHUP，此时读取会立即返回EOF，也视为可读
+		return n > 0 && fds[0].Revents&(unix.POLLIN|unix.POLLHUP) != 0, nil
+	}
+}
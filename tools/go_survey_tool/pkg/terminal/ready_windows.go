@@ -0,0 +1,88 @@
+//go:build windows
+
+package terminal
+
+import (
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procPeekConsoleInputW = windows.NewLazySystemDLL("kernel32.dll").NewProc("PeekConsoleInputW")
+	procReadConsoleInputW = windows.NewLazySystemDLL("kernel32.dll").NewProc("ReadConsoleInputW")
+)
+
+// keyEvent 是INPUT_RECORD.EventType中的KEY_EVENT
+const keyEvent = 0x0001
+
+// inputRecord 对应Windows的INPUT_RECORD，这里只关心键盘事件的按下标志
+type inputRecord struct {
+	EventType uint16
+	_         uint16
+	KeyDown   int32
+	_         [12]byte
+}
+
+// InputReady 等待fd可读，最多等待timeout
+// 可读时返回true，超时返回false；timeout为0时立即返回，小于0时一直等待
+// 控制台句柄在鼠标、焦点、按键抬起等事件上也会变为有信号，这些事件不产生可读数据，
+// 因此这里会丢弃它们并继续等待，直到有按键按下或超时；
+// 单独按下Shift等修饰键同样算作按键事件，此时随后的读取可能仍会阻塞
+func InputReady(fd int, timeout time.Duration) (bool, error) {
+	h := windows.Handle(fd)
+	var deadline time.Time
+	if timeout >= 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		ms := uint32(windows.INFINITE)
+		if timeout >= 0 {
+			remaining := time.Until(deadline)
+			if remaining < 0 {
+				remaining = 0
+			}
+			ms = uint32(remaining / time.Millisecond)
+		}
+		event, err := windows.WaitForSingleObject(h, ms)
+		if err != nil {
+			return false, err
+		}
+		if event != windows.WAIT_OBJECT_0 {
+			return false, nil
+		}
+		keys, err := pendingKeyInput(h)
+		if err != nil {
+			// 不是控制台（如管道），有信号即表示可读
+			return true, nil
+		}
+		if keys {
+			return true, nil
+		}
+	}
+}
+
+// pendingKeyInput 检查控制台输入缓冲区中是否有按键按下事件；
+// 没有时读出并丢弃其余事件，以免句柄一直保持有信号
+func pendingKeyInput(h windows.Handle) (bool, error) {
+	var records [16]inputRecord
+	var n uint32
+	r, _, err := procPeekConsoleInputW.Call(uintptr(h), uintptr(unsafe.Pointer(&records[0])), uintptr(len(records)), uintptr(unsafe.Pointer(&n)))
+	if r == 0 {
+		return false, err
+	}
+	for _, rec := range records[:n] {
+		if rec.EventType == keyEvent && rec.KeyDown != 0 {
+			return true, nil
+		}
+	}
+	if n == 0 {
+		return false, nil
+	}
+	r, _, err = procReadConsoleInputW.Call(uintptr(h), uintptr(unsafe.Pointer(&records[0])), uintptr(n), uintptr(unsafe.Pointer(&n)))
+	if r == 0 {
+		return false, err
+	}
+	return false, nil
+}
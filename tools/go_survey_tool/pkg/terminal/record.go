@@ -0,0 +1,103 @@
+package terminal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// KeyRecorder 记录按键及其时间，用于把用户报告的问题录制下来再用ReplayKeys回放
+//
+// 录制文件为JSON格式，raw是按键的原始字节（JSON字符串，控制字符按\u转义），
+// at_ms是距录制开始的毫秒数：
+//
+//	{"events": [{"raw": "\u001b[B", "at_ms": 350}, {"raw": "\r", "at_ms": 900}]}
+type KeyRecorder struct {
+	mu     sync.Mutex
+	start  time.Time
+	events []KeyEvent
+	now    func() time.Time
+}
+
+// NewKeyRecorder 创建录制器，从创建时开始计时
+func NewKeyRecorder() *KeyRecorder {
+	return &KeyRecorder{start: time.Now(), now: time.Now}
+}
+
+// Record 记录一次按键，At字段设置为当前距录制开始的时间
+func (r *KeyRecorder) Record(ev KeyEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ev.At = r.now().Sub(r.start)
+	ev.Raw = append([]byte(nil), ev.Raw...)
+	r.events = append(r.events, ev)
+}
+
+// Events 返回已录制按键的副本
+func (r *KeyRecorder) Events() []KeyEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]KeyEvent(nil), r.events...)
+}
+
+// recordedKey 录制文件中的一次按键
+type recordedKey struct {
+	Raw string `json:"raw"`
+	At  int64  `json:"at_ms"`
+}
+
+// recording 录制文件的结构
+type recording struct {
+	Events []recordedKey `json:"events"`
+}
+
+// WriteJSON 把录制的按键写为JSON
+func (r *KeyRecorder) WriteJSON(w io.Writer) error {
+	var rec recording
+	for _, ev := range r.Events() {
+		rec.Events = append(rec.Events, recordedKey{Raw: string(ev.Raw), At: ev.At.Milliseconds()})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rec)
+}
+
+// ReadRecording 读取WriteJSON写出的录制文件，按原始字节重新解析出按键
+func ReadRecording(r io.Reader) ([]KeyEvent, error) {
+	var rec recording
+	if err := json.NewDecoder(r).Decode(&rec); err != nil {
+		return nil, fmt.Errorf("解析录制文件失败: %w", err)
+	}
+
+	events := make([]KeyEvent, 0, len(rec.Events))
+	for i, key := range rec.Events {
+		ev, err := ReadKey(bufio.NewReader(bytes.NewReader([]byte(key.Raw))))
+		if err != nil {
+			return nil, fmt.Errorf("第%d个按键无效: %w", i+1, err)
+		}
+		ev.Raw = []byte(key.Raw)
+		ev.At = time.Duration(key.At) * time.Millisecond
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+// ReplayKeys 把按键的原始字节依次写入w
+// realtime为true时按录制时的间隔等待，以重现输入的时序（例如ESC序列被拆开到达的情况）
+func ReplayKeys(w io.Writer, events []KeyEvent, realtime bool) error {
+	var last time.Duration
+	for _, ev := range events {
+		if realtime && ev.At > last {
+			time.Sleep(ev.At - last)
+			last = ev.At
+		}
+		if _, err := w.Write(ev.Raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package terminal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKeyRecorderRoundTrip(t *testing.T) {
+	clock := time.Unix(0, 0)
+	rec := &KeyRecorder{start: clock, now: func() time.Time { return clock }}
+
+	keys := []KeyEvent{
+		{Key: KeyDown, Raw: []byte("\x1b[B")},
+		{Key: KeyRune, Rune: '你', Raw: []byte("你")},
+		{Key: KeyEnter, Raw: []byte("\r")},
+	}
+	for i, ev := range keys {
+		clock = clock.Add(time.Duration(i+1) * 100 * time.Millisecond)
+		rec.Record(ev)
+	}
+
+	var buf bytes.Buffer
+	if err := rec.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"raw": "\u001b[B"`) || !strings.Contains(buf.String(), `"at_ms": 600`) {
+		t.Errorf("unexpected recording format:\n%s", buf.String())
+	}
+
+	events, err := ReadRecording(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecording() error = %v", err)
+	}
+	wantAt := []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 600 * time.Millisecond}
+	if len(events) != len(keys) {
+		t.Fatalf("ReadRecording() returned %d events, want %d", len(events), len(keys))
+	}
+	for i, ev := range events {
+		if ev.Key != keys[i].Key || ev.Rune != keys[i].Rune || ev.At != wantAt[i] {
+			t.Errorf("event %d = %+v, want key %v rune %q at %v", i, ev, keys[i].Key, keys[i].Rune, wantAt[i])
+		}
+	}
+}
+
+func TestReadRecordingInvalid(t *testing.T) {
+	if _, err := ReadRecording(strings.NewReader("not json")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestReplayKeys(t *testing.T) {
+	events := []KeyEvent{
+		{Raw: []byte("\x1b[B"), At: 0},
+		{Raw: []byte("\r"), At: 30 * time.Millisecond},
+	}
+
+	var out bytes.Buffer
+	if err := ReplayKeys(&out, events, false); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "\x1b[B\r" {
+		t.Errorf("ReplayKeys() wrote %q", out.String())
+	}
+
+	out.Reset()
+	start := time.Now()
+	if err := ReplayKeys(&out, events, true); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("realtime replay took %v, want at least the recorded gap", elapsed)
+	}
+}
@@ -0,0 +1,47 @@
+package terminal
+
+import "sync"
+
+// Size 终端的宽和高（列数和行数）
+type Size struct {
+	Width, Height int
+}
+
+// WatchResize 监视fd的终端大小，大小变化后把新的大小（按SafeGetSize获取）发送到返回的通道。
+// Unix上由SIGWINCH触发，其他平台定期检查。通道只缓冲一个值，来不及处理时只保留最新的大小。
+// 调用stop停止监视，之后通道会被关闭；stop可以调用多次
+func WatchResize(fd int) (sizes <-chan Size, stop func()) {
+	events, stopEvents := resizeEvents()
+	ch := make(chan Size, 1)
+	done := make(chan struct{})
+
+	// 在启动goroutine之前记录初始大小，之后的任何变化都会被报告
+	width, height := SafeGetSize(fd)
+	last := Size{width, height}
+	go func() {
+		defer close(ch)
+		defer stopEvents()
+		for {
+			select {
+			case <-done:
+				return
+			case <-events:
+			}
+			width, height := SafeGetSize(fd)
+			size := Size{width, height}
+			if size == last {
+				continue
+			}
+			last = size
+			// 丢弃还没被取走的旧大小
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- size
+		}
+	}()
+
+	var once sync.Once
+	return ch, func() { once.Do(func() { close(done) }) }
+}
@@ -0,0 +1,32 @@
+//go:build !unix
+
+package terminal
+
+import "time"
+
+// resizePollInterval 没有SIGWINCH的平台上检查终端大小的间隔
+const resizePollInterval = 250 * time.Millisecond
+
+// resizeEvents 没有SIGWINCH时定期发出事件，由WatchResize比较大小是否变化
+func resizeEvents() (<-chan struct{}, func()) {
+	ticker := time.NewTicker(resizePollInterval)
+	events := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+			select {
+			case events <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return events, func() {
+		ticker.Stop()
+		close(done)
+	}
+}
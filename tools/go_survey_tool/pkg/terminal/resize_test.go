@@ -0,0 +1,95 @@
+//go:build unix
+
+package terminal_test
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/terminal"
+)
+
+func TestWatchResize(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	defer terminal.SetWidthOverride(0)
+
+	sizes, stop := terminal.WatchResize(int(w.Fd()))
+	defer stop()
+
+	// 管道没有终端大小，SafeGetSize返回默认值；改变覆盖的宽度模拟窗口变窄
+	terminal.SetWidthOverride(42)
+	deadline := time.After(2 * time.Second)
+	for {
+		// 信号可能在Notify生效之前到达，没收到时再发一次
+		if err := syscall.Kill(os.Getpid(), syscall.SIGWINCH); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case size := <-sizes:
+			if size != (terminal.Size{Width: 42, Height: terminal.DefaultHeight}) {
+				t.Fatalf("WatchResize() sent %+v, want width 42", size)
+			}
+			stop()
+			if _, ok := <-sizes; ok {
+				t.Error("sizes should be closed after stop")
+			}
+			return
+		case <-time.After(20 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("no size received after SIGWINCH")
+		}
+	}
+}
+
+func TestWatchResizeIgnoresSameSize(t *testing.T) {
+	sizes, stop := terminal.WatchResize(-1)
+	defer stop()
+
+	syscall.Kill(os.Getpid(), syscall.SIGWINCH)
+	select {
+	case size := <-sizes:
+		t.Errorf("WatchResize() sent %+v although the size did not change", size)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWaitForSizeProceedsAfterResize(t *testing.T) {
+	defer terminal.SetWidthOverride(0)
+	terminal.SetWidthOverride(20)
+
+	done := make(chan error, 1)
+	reported := make(chan struct{}, 1)
+	go func() {
+		done <- terminal.WaitForSize(context.Background(), -1, 40, 0, func(error) {
+			select {
+			case reported <- struct{}{}:
+			default:
+			}
+		})
+	}()
+	<-reported
+
+	terminal.SetWidthOverride(60)
+	deadline := time.After(2 * time.Second)
+	for {
+		syscall.Kill(os.Getpid(), syscall.SIGWINCH)
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("WaitForSize() error = %v", err)
+			}
+			return
+		case <-time.After(20 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("WaitForSize() did not return after the terminal grew")
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"golang.org/x/term"
+)
+
+// 无法获取终端大小时使用的默认值
+const (
+	DefaultWidth  = 80
+	DefaultHeight = 24
+)
+
+// getSize 获取终端大小，测试中替换以模拟各种返回值
+var getSize = term.GetSize
+
+// 通过SetWidthOverride和SetHeightOverride设置的大小，0表示不覆盖
+var widthOverride, heightOverride atomic.Int32
+
+// SetWidthOverride 让SafeGetSize总是返回width作为宽度，width<=0时取消覆盖
+func SetWidthOverride(width int) {
+	widthOverride.Store(int32(max(width, 0)))
+}
+
+// SetHeightOverride 让SafeGetSize总是返回height作为高度，height<=0时取消覆盖
+func SetHeightOverride(height int) {
+	heightOverride.Store(int32(max(height, 0)))
+}
+
+// SafeGetSize 返回fd的终端大小，调用方不需要再处理错误。
+// 部分CI和pty环境中term.GetSize会返回0x0而不是错误，宽或高为0以及出错时
+// 都视为未知，分别使用DefaultWidth和DefaultHeight。设置了覆盖值时优先使用覆盖值
+func SafeGetSize(fd int) (width, height int) {
+	width, height, err := getSize(fd)
+	if err != nil {
+		width, height = 0, 0
+	}
+	if width <= 0 {
+		width = DefaultWidth
+	}
+	if height <= 0 {
+		height = DefaultHeight
+	}
+	if w := widthOverride.Load(); w > 0 {
+		width = int(w)
+	}
+	if h := heightOverride.Load(); h > 0 {
+		height = int(h)
+	}
+	return width, height
+}
+
+// ErrTerminalTooSmall 终端小于要求的大小，见RequireSize
+var ErrTerminalTooSmall = errors.New("terminal too small")
+
+// RequireSize 检查fd的终端（按SafeGetSize获取）至少有minCols列、minRows行，<=0表示不限制。
+// 不满足时返回包装了ErrTerminalTooSmall的错误，信息中带有实际大小和要求的大小
+func RequireSize(fd, minCols, minRows int) error {
+	width, height := SafeGetSize(fd)
+	if width >= minCols && height >= minRows {
+		return nil
+	}
+	var need string
+	switch {
+	case minRows <= 0:
+		need = fmt.Sprintf("%d columns", minCols)
+	case minCols <= 0:
+		need = fmt.Sprintf("%d rows", minRows)
+	default:
+		need = fmt.Sprintf("%dx%d", minCols, minRows)
+	}
+	return fmt.Errorf("%w: %dx%d, need at least %s", ErrTerminalTooSmall, width, height, need)
+}
+
+// WaitForSize 等待fd的终端变为至少minCols列、minRows行，每次检查到大小不够时用RequireSize的错误调用tooSmall，
+// 通常用来提示用户调整窗口。通过WatchResize监视大小变化，ctx取消时返回ctx.Err()
+func WaitForSize(ctx context.Context, fd, minCols, minRows int, tooSmall func(error)) error {
+	err := RequireSize(fd, minCols, minRows)
+	if err == nil {
+		return nil
+	}
+	sizes, stop := WatchResize(fd)
+	defer stop()
+	for {
+		tooSmall(err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-sizes:
+			if !ok {
+				return err
+			}
+		}
+		if err = RequireSize(fd, minCols, minRows); err == nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSafeGetSize(t *testing.T) {
+	old := getSize
+	defer func() { getSize = old }()
+
+	tests := []struct {
+		name           string
+		width, height  int
+		err            error
+		wantW, wantH   int
+		overrideW      int
+		overrideHeight int
+	}{
+		{"Normal", 120, 40, nil, 120, 40, 0, 0},
+		{"Reported 0x0", 0, 0, nil, DefaultWidth, DefaultHeight, 0, 0},
+		{"Zero width only", 0, 50, nil, DefaultWidth, 50, 0, 0},
+		{"Error", 100, 30, errors.New("not a terminal"), DefaultWidth, DefaultHeight, 0, 0},
+		{"Overrides", 0, 0, nil, 132, 60, 132, 60},
+		{"Width override only", 120, 40, nil, 100, 40, 100, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getSize = func(int) (int, int, error) { return tt.width, tt.height, tt.err }
+			SetWidthOverride(tt.overrideW)
+			SetHeightOverride(tt.overrideHeight)
+			defer SetWidthOverride(0)
+			defer SetHeightOverride(0)
+
+			if w, h := SafeGetSize(1); w != tt.wantW || h != tt.wantH {
+				t.Errorf("SafeGetSize() = %dx%d, want %dx%d", w, h, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestRequireSize(t *testing.T) {
+	old := getSize
+	defer func() { getSize = old }()
+	getSize = func(int) (int, int, error) { return 30, 8, nil }
+
+	tests := []struct {
+		name             string
+		minCols, minRows int
+		want             string
+	}{
+		{"Large enough", 30, 8, ""},
+		{"No requirement", 0, 0, ""},
+		{"Too narrow and short", 40, 10, "terminal too small: 30x8, need at least 40x10"},
+		{"Too narrow", 80, 0, "terminal too small: 30x8, need at least 80 columns"},
+		{"Too short", 0, 24, "terminal too small: 30x8, need at least 24 rows"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RequireSize(1, tt.minCols, tt.minRows)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("RequireSize() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrTerminalTooSmall) || err.Error() != tt.want {
+				t.Errorf("RequireSize() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitForSizeCancelled(t *testing.T) {
+	old := getSize
+	defer func() { getSize = old }()
+	getSize = func(int) (int, int, error) { return 30, 8, nil }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var reported []string
+	err := WaitForSize(ctx, 1, 40, 10, func(err error) {
+		reported = append(reported, err.Error())
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitForSize() error = %v, want context.Canceled", err)
+	}
+	if len(reported) != 1 || !strings.Contains(reported[0], "30x8") || !strings.Contains(reported[0], "40x10") {
+		t.Errorf("tooSmall called with %q, want the actual and required sizes", reported)
+	}
+}
+
+func TestWaitForSizeLargeEnough(t *testing.T) {
+	old := getSize
+	defer func() { getSize = old }()
+	getSize = func(int) (int, int, error) { return 100, 30, nil }
+
+	err := WaitForSize(context.Background(), 1, 80, 24, func(err error) {
+		t.Errorf("tooSmall called with %v for a large enough terminal", err)
+	})
+	if err != nil {
+		t.Errorf("WaitForSize() error = %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+package terminal
+
+import "golang.org/x/term"
+
+// HandleSuspend 让fd上的终端在进程被挂起（Ctrl-Z，SIGTSTP）时恢复为调用时的状态，
+// 继续运行（SIGCONT）后再切换回挂起前的状态，避免挂起期间shell面对一个raw模式的终端。
+// 应在进入raw模式之前调用；返回的函数取消处理，可以多次调用。
+// fd不是终端或平台没有作业控制（非Unix）时什么也不做
+func HandleSuspend(fd int) func() {
+	cooked, err := term.GetState(fd)
+	if err != nil {
+		return func() {}
+	}
+	return handleSuspend(fd, cooked)
+}
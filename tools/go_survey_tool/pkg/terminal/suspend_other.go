@@ -0,0 +1,15 @@
+//go:build !unix
+
+package terminal
+
+import "golang.org/x/term"
+
+// Suspend 没有作业控制的平台上不能挂起进程，什么也不做
+func Suspend() error {
+	return nil
+}
+
+// handleSuspend 没有作业控制的平台上不需要处理挂起
+func handleSuspend(fd int, cooked *term.State) func() {
+	return func() {}
+}
@@ -0,0 +1,89 @@
+//go:build linux
+
+package terminal
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"golang.org/x/term"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/internal/ptytest"
+)
+
+func TestEnterRawRestoresOnSuspend(t *testing.T) {
+	_, pty := ptytest.Open(t)
+	fd := int(pty.Fd())
+	cooked, err := term.GetState(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 不真正停止测试进程：记录停止时的终端状态，然后发送SIGCONT模拟继续运行
+	stopped := make(chan *term.State, 1)
+	defer func(orig func() error) { stopProcess = orig }(stopProcess)
+	stopProcess = func() error {
+		state, _ := term.GetState(fd)
+		stopped <- state
+		return unix.Kill(os.Getpid(), unix.SIGCONT)
+	}
+
+	restore, err := EnterRaw(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restore()
+	raw, err := term.GetState(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := unix.Kill(os.Getpid(), unix.SIGTSTP); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case state := <-stopped:
+		if *state != *cooked {
+			t.Error("terminal should be back in cooked mode while the process is stopped")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("SIGTSTP was not handled")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		state, err := term.GetState(fd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *state == *raw {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("raw mode was not restored after SIGCONT")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := restore(); err != nil {
+		t.Fatal(err)
+	}
+	if state, _ := term.GetState(fd); *state != *cooked {
+		t.Error("restore() should leave the terminal in cooked mode")
+	}
+}
+
+func TestHandleSuspendNotATerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	// 不是终端时返回的函数什么也不做，可以多次调用
+	uninstall := HandleSuspend(int(r.Fd()))
+	uninstall()
+	uninstall()
+}
@@ -0,0 +1,77 @@
+//go:build unix
+
+package terminal
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+
+	"golang.org/x/sys/unix"
+	"golang.org/x/term"
+)
+
+// stopProcess 以SIGTSTP的默认动作停止当前进程，测试中替换以免测试进程被停止
+var stopProcess = func() error {
+	return unix.Kill(os.Getpid(), unix.SIGTSTP)
+}
+
+// Suspend 像终端驱动处理Ctrl-Z一样向当前进程组发送SIGTSTP，进程继续运行后返回。
+// raw模式下终端不会把Ctrl-Z转换为信号，逐键读取的程序可以在读到Ctrl-Z时调用它
+func Suspend() error {
+	resumed := make(chan os.Signal, 1)
+	signal.Notify(resumed, unix.SIGCONT)
+	defer signal.Stop(resumed)
+	if err := unix.Kill(0, unix.SIGTSTP); err != nil {
+		return err
+	}
+	<-resumed
+	return nil
+}
+
+// handleSuspend HandleSuspend的实现，挂起时把fd恢复为cooked
+func handleSuspend(fd int, cooked *term.State) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, unix.SIGTSTP)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-signals:
+				suspend(fd, cooked, signals)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
+
+// suspend 恢复cooked后停止进程，收到SIGCONT后重新设置停止前的终端状态
+func suspend(fd int, cooked *term.State, signals chan os.Signal) {
+	current, err := term.GetState(fd)
+	if err == nil {
+		term.Restore(fd, cooked)
+	}
+
+	resumed := make(chan os.Signal, 1)
+	signal.Notify(resumed, unix.SIGCONT)
+	defer signal.Stop(resumed)
+	// 暂时取消对SIGTSTP的处理，使下面的SIGTSTP按默认动作停止进程
+	signal.Stop(signals)
+	if stopProcess() == nil {
+		<-resumed
+	}
+	signal.Notify(signals, unix.SIGTSTP)
+
+	if err == nil {
+		term.Restore(fd, current)
+	}
+}
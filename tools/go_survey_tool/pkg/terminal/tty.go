@@ -0,0 +1,24 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// OpenControllingTTY 打开进程的控制终端，用于标准输入被重定向时仍然向用户提问，
+// 与less和git的做法相同。没有控制终端（如在cron或容器中运行）时返回错误
+func OpenControllingTTY() (*os.File, error) {
+	if controllingTTY == "" {
+		return nil, errors.New("controlling terminal is not supported on this platform")
+	}
+	f, err := os.OpenFile(controllingTTY, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !isTTY(f) {
+		f.Close()
+		return nil, fmt.Errorf("%s is not a terminal", controllingTTY)
+	}
+	return f, nil
+}
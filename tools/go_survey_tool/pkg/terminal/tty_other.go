@@ -0,0 +1,6 @@
+//go:build !unix && !windows
+
+package terminal
+
+// controllingTTY 不支持的平台上为空，OpenControllingTTY总是返回错误
+var controllingTTY = ""
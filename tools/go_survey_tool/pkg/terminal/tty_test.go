@@ -0,0 +1,31 @@
+package terminal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenControllingTTYFailure(t *testing.T) {
+	old := controllingTTY
+	defer func() { controllingTTY = old }()
+
+	t.Run("Missing device", func(t *testing.T) {
+		controllingTTY = filepath.Join(t.TempDir(), "no-such-tty")
+		if f, err := OpenControllingTTY(); err == nil {
+			f.Close()
+			t.Error("expected an error when the device cannot be opened")
+		}
+	})
+
+	t.Run("Not a terminal", func(t *testing.T) {
+		controllingTTY = filepath.Join(t.TempDir(), "file")
+		if err := os.WriteFile(controllingTTY, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if f, err := OpenControllingTTY(); err == nil {
+			f.Close()
+			t.Error("expected an error for a regular file")
+		}
+	})
+}
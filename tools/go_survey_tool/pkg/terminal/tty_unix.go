@@ -0,0 +1,6 @@
+//go:build unix
+
+package terminal
+
+// controllingTTY 控制终端的设备路径
+var controllingTTY = "/dev/tty"
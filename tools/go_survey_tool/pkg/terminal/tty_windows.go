@@ -0,0 +1,6 @@
+//go:build windows
+
+package terminal
+
+// controllingTTY 控制台输入的设备名
+var controllingTTY = "CONIN$"
@@ -0,0 +1,37 @@
+package utils
+
+import "strings"
+
+// asciiBorder 当前符号集的Border不是6个字符时使用的边框
+const asciiBorder = "++++-|"
+
+// BoxText 用当前符号集的边框（见Symbols.Border）把s框起来，用于强调警告和确认。
+// 框的宽度按最长的一行（以DisplayWidth计算）自动确定；width > 0时整个框不超过width列，
+// 更长的行用WrapText折行。s中原有的换行保留，返回的多行之间用\n分隔，末尾没有换行
+func BoxText(s string, width int) string {
+	border := []rune(CurrentSymbols().Border)
+	if len(border) != 6 {
+		border = []rune(asciiBorder)
+	}
+	topLeft, topRight, bottomLeft, bottomRight := string(border[0]), string(border[1]), string(border[2]), string(border[3])
+	horizontal, vertical := string(border[4]), string(border[5])
+
+	s = strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n")
+	if width > 0 {
+		// 两侧各占一列边框和一列空白
+		s = WrapText(s, max(width-4, 1))
+	}
+	lines := strings.Split(s, "\n")
+	inner := 0
+	for _, line := range lines {
+		inner = max(inner, DisplayWidth(line))
+	}
+
+	var b strings.Builder
+	b.WriteString(topLeft + strings.Repeat(horizontal, inner+2) + topRight + "\n")
+	for _, line := range lines {
+		b.WriteString(vertical + " " + line + strings.Repeat(" ", inner-DisplayWidth(line)) + " " + vertical + "\n")
+	}
+	b.WriteString(bottomLeft + strings.Repeat(horizontal, inner+2) + bottomRight)
+	return b.String()
+}
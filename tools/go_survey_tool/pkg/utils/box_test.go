@@ -0,0 +1,69 @@
+package utils_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// checkGolden 比较got与testdata中的golden文件，-update时改写golden文件
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("读取golden文件失败: %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("output does not match %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestBoxTextGolden(t *testing.T) {
+	defer utils.SetSymbols(utils.CurrentSymbols())
+	text := "Overwrite answers.json?\n覆盖后无法恢复 - the previous answers will be lost for good"
+
+	tests := []struct {
+		golden  string
+		symbols utils.Symbols
+	}{
+		{"box_unicode_width40.golden", utils.UnicodeSymbols},
+		{"box_ascii_width40.golden", utils.ASCIISymbols},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			utils.SetSymbols(tt.symbols)
+			got := utils.BoxText(text, 40)
+			for _, line := range strings.Split(got, "\n") {
+				if w := utils.DisplayWidth(line); w > 40 {
+					t.Errorf("line %q is %d columns wide, want at most 40", line, w)
+				}
+			}
+			checkGolden(t, tt.golden, got+"\n")
+		})
+	}
+}
+
+func TestBoxTextAutoSize(t *testing.T) {
+	defer utils.SetSymbols(utils.CurrentSymbols())
+	utils.SetSymbols(utils.Symbols{})
+
+	want := "+-------+\n| hi    |\n| there |\n+-------+"
+	if got := utils.BoxText("hi\r\nthere", 0); got != want {
+		t.Errorf("BoxText() = %q, want %q", got, want)
+	}
+	if got := utils.BoxText("hi\r\nthere", 80); got != want {
+		t.Errorf("BoxText() with room to spare = %q, want the box sized to the text", got)
+	}
+}
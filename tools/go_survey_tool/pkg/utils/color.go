@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"golang.org/x/term"
+)
+
+// Color ANSI文字样式
+type Color int
+
+// 支持的样式
+const (
+	Red Color = iota
+	Green
+	Yellow
+	Blue
+	Bold
+	Dim
+)
+
+// colorCodes 各样式对应的SGR参数
+var colorCodes = map[Color]int{
+	Red:    31,
+	Green:  32,
+	Yellow: 33,
+	Blue:   34,
+	Bold:   1,
+	Dim:    2,
+}
+
+// ColorEnabled 判断是否输出颜色：设置了NO_COLOR（无论值是什么）、TERM为空或dumb时不输出
+// 每次调用都重新读取环境变量
+func ColorEnabled() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	term := os.Getenv("TERM")
+	return term != "" && term != "dumb"
+}
+
+// Colorize 用ANSI转义序列给s加上样式c并在末尾复位，颜色被禁用或c未知时原样返回s
+func Colorize(s string, c Color) string {
+	code, ok := colorCodes[c]
+	if !ok || s == "" || !ColorEnabled() {
+		return s
+	}
+	return "\x1b[" + strconv.Itoa(code) + "m" + s + "\x1b[0m"
+}
+
+// ColorEnabledFor 判断写到w的内容是否输出颜色：除ColorEnabled的条件外w还必须是终端，
+// 输出被重定向到管道、文件或缓冲区时不会混入转义序列
+func ColorEnabledFor(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd())) && ColorEnabled()
+}
+
+// ColorizeFor 与Colorize相同，但只在ColorEnabledFor(w)时着色，用于直接写到w的内容
+func ColorizeFor(w io.Writer, s string, c Color) string {
+	if !ColorEnabledFor(w) {
+		return s
+	}
+	return Colorize(s, c)
+}
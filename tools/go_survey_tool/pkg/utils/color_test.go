@@ -0,0 +1,93 @@
+package utils_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// unsetEnv 在测试期间删除环境变量，结束后恢复
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	os.Unsetenv(key)
+}
+
+func TestColorize(t *testing.T) {
+	t.Run("Enabled", func(t *testing.T) {
+		unsetEnv(t, "NO_COLOR")
+		t.Setenv("TERM", "xterm-256color")
+
+		tests := []struct {
+			color utils.Color
+			code  string
+		}{
+			{utils.Red, "\x1b[31m"},
+			{utils.Green, "\x1b[32m"},
+			{utils.Yellow, "\x1b[33m"},
+			{utils.Blue, "\x1b[34m"},
+			{utils.Bold, "\x1b[1m"},
+			{utils.Dim, "\x1b[2m"},
+		}
+		for _, tt := range tests {
+			got := utils.Colorize("ok", tt.color)
+			if got != tt.code+"ok\x1b[0m" {
+				t.Errorf("Colorize(ok, %d) = %q, want code %q and a reset", tt.color, got, tt.code)
+			}
+		}
+	})
+
+	t.Run("NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		t.Setenv("TERM", "xterm-256color")
+		if got := utils.Colorize("ok", utils.Green); got != "ok" {
+			t.Errorf("Colorize() = %q, want no escape codes", got)
+		}
+		if utils.ColorEnabled() {
+			t.Error("ColorEnabled() = true with NO_COLOR set")
+		}
+	})
+
+	t.Run("Dumb terminal", func(t *testing.T) {
+		unsetEnv(t, "NO_COLOR")
+		t.Setenv("TERM", "dumb")
+		if got := utils.Colorize("ok", utils.Red); strings.Contains(got, "\x1b") {
+			t.Errorf("Colorize() = %q, want no escape codes", got)
+		}
+	})
+}
+
+func TestColorizeFor(t *testing.T) {
+	unsetEnv(t, "NO_COLOR")
+	t.Setenv("TERM", "xterm")
+
+	var buf bytes.Buffer
+	if got := utils.ColorizeFor(&buf, "ok", utils.Red); got != "ok" {
+		t.Errorf("ColorizeFor(buffer) = %q, want no escape codes", got)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	if utils.ColorEnabledFor(w) {
+		t.Error("ColorEnabledFor(pipe) = true, want false")
+	}
+}
+
+func TestSymbolsColored(t *testing.T) {
+	unsetEnv(t, "NO_COLOR")
+	t.Setenv("TERM", "xterm")
+
+	colored := utils.UnicodeSymbols.Colored()
+	if colored.Success != "\x1b[32m✓\x1b[0m" || colored.Failure != "\x1b[31m✗\x1b[0m" || colored.Warning != "\x1b[33m⚠\x1b[0m" {
+		t.Errorf("Colored() = %+v", colored)
+	}
+	if colored.Question != "?" || colored.Selected != ">" {
+		t.Errorf("prompt symbols should not be colored: %+v", colored)
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/term"
+)
+
+// ErrFileExists 目标文件已存在且没有确认覆盖
+var ErrFileExists = errors.New("file already exists")
+
+// ConfirmOverwrite SafeWriteFile和CheckOverwrite在目标文件已存在时调用它询问是否覆盖，
+// 通常设置为survey.AskConfirmDangerous；为nil时不覆盖已有的文件
+var ConfirmOverwrite func(path string) (bool, error)
+
+// SafeWriteFile 把data写入path，不会悄悄覆盖已有的文件：先用CheckOverwrite确认，再用WriteFileAtomic写入
+func SafeWriteFile(path string, data []byte, force bool) error {
+	return safeWriteFile(path, data, force, term.IsTerminal(int(os.Stdin.Fd())), ConfirmOverwrite)
+}
+
+// CheckOverwrite 确认可以写入path：path已存在且force为false时，标准输入是终端就用ConfirmOverwrite询问，
+// 否则（或用户拒绝时）返回ErrFileExists。要在耗时的操作（比如整个调查）之后写入时，先调用它再用WriteFileAtomic写入
+func CheckOverwrite(path string, force bool) error {
+	return checkOverwrite(path, force, term.IsTerminal(int(os.Stdin.Fd())), ConfirmOverwrite)
+}
+
+// safeWriteFile SafeWriteFile的实现，tty表示标准输入是否为终端
+func safeWriteFile(path string, data []byte, force, tty bool, confirm func(path string) (bool, error)) error {
+	if err := checkOverwrite(path, force, tty, confirm); err != nil {
+		return err
+	}
+	if err := WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	return nil
+}
+
+// checkOverwrite CheckOverwrite的实现，tty表示标准输入是否为终端
+func checkOverwrite(path string, force, tty bool, confirm func(path string) (bool, error)) error {
+	if _, err := os.Stat(path); err != nil || force {
+		return nil
+	}
+	if !tty || confirm == nil {
+		return fmt.Errorf("%w: %s", ErrFileExists, path)
+	}
+	ok, err := confirm(path)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrFileExists, path)
+	}
+	return nil
+}
+
+// WriteFileAtomic 先写同目录下的临时文件（权限0600）再重命名为path，写入中断时不会留下半截文件或破坏原来的文件
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
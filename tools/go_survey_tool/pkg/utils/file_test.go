@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeWriteFile(t *testing.T) {
+	confirmed := func(answer bool) func(string) (bool, error) {
+		return func(string) (bool, error) { return answer, nil }
+	}
+	tests := []struct {
+		name    string
+		exists  bool
+		force   bool
+		tty     bool
+		confirm func(string) (bool, error)
+		wantErr error
+		want    string
+	}{
+		{"New file", false, false, false, nil, nil, "new"},
+		{"Exists, not a terminal", true, false, false, confirmed(true), ErrFileExists, "old"},
+		{"Exists, force", true, true, false, nil, nil, "new"},
+		{"Exists, confirmed", true, false, true, confirmed(true), nil, "new"},
+		{"Exists, declined", true, false, true, confirmed(false), ErrFileExists, "old"},
+		{"Exists, no prompt", true, false, true, nil, ErrFileExists, "old"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "answers.json")
+			if tt.exists {
+				if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := safeWriteFile(path, []byte("new"), tt.force, tt.tty, tt.confirm)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("safeWriteFile() error = %v, want %v", err, tt.wantErr)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("file contains %q, want %q", data, tt.want)
+			}
+			if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+				t.Errorf("temporary files left behind: %v", entries)
+			}
+		})
+	}
+}
+
+func TestSafeWriteFileConfirmError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	interrupted := errors.New("interrupted")
+	err := safeWriteFile(path, []byte("new"), false, true, func(string) (bool, error) { return false, interrupted })
+	if !errors.Is(err, interrupted) {
+		t.Errorf("safeWriteFile() error = %v, want the prompt error", err)
+	}
+}
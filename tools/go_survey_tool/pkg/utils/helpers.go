@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"unicode"
 )
 
 // PrintError 打印错误信息
@@ -13,9 +14,29 @@ func PrintError(err error) {
 	}
 }
 
-// IsEmpty 检查字符串是否为空或仅包含空格
+// IsEmpty 检查字符串是否为空或仅包含空白
+// 除unicode.IsSpace认定的空白外，还把zeroWidthChars中的字符视为空白，
+// 这样从网页或文档中粘贴来的“看起来为空”的输入也不能通过ValidateNotEmpty
 func IsEmpty(s string) bool {
-	return strings.TrimSpace(s) == ""
+	return strings.TrimFunc(s, isBlank) == ""
+}
+
+// zeroWidthChars 不显示任何内容的字符，unicode.IsSpace不把它们当作空白
+var zeroWidthChars = map[rune]bool{
+	'\u00ad': true, // 软连字符
+	'\u180e': true, // 蒙古文元音分隔符
+	'\u200b': true, // 零宽空格
+	'\u200c': true, // 零宽不连字
+	'\u200d': true, // 零宽连接符
+	'\u200e': true, // 从左到右标记
+	'\u200f': true, // 从右到左标记
+	'\u2060': true, // 词连接符
+	'\ufeff': true, // BOM，也用作零宽不换行空格
+}
+
+// isBlank 判断r是否为空白或零宽字符
+func isBlank(r rune) bool {
+	return unicode.IsSpace(r) || zeroWidthChars[r]
 }
 
 // ValidateNotEmpty 验证输入不为空
@@ -26,6 +47,50 @@ func ValidateNotEmpty(input string) error {
 	return nil
 }
 
+// CollapseSpace 去掉首尾空白，并把中间连续的空白（包括换行和制表符）合并为一个空格
+func CollapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// SplitList 把列表形式的文本按seps中的任一字符切分为各项，每项去掉首尾空白，空白的项（见IsEmpty）被丢弃。
+// 没有指定seps时按逗号和空白切分；s中没有任何项时返回空的切片而不是nil
+func SplitList(s string, seps ...rune) []string {
+	isSep := func(r rune) bool { return r == ',' || unicode.IsSpace(r) }
+	if len(seps) > 0 {
+		isSep = func(r rune) bool {
+			for _, sep := range seps {
+				if r == sep {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	items := []string{}
+	for _, item := range strings.FieldsFunc(s, isSep) {
+		if !IsEmpty(item) {
+			items = append(items, strings.TrimFunc(item, isBlank))
+		}
+	}
+	return items
+}
+
+// IndentMultiline 在s的第二行起每行前加上prefix，用于在按行排版的输出中写入多行文本，
+// 续行不会被误认为新的一项。\r\n和单独的\r都按换行处理，单行文本原样返回
+func IndentMultiline(s, prefix string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
+
+// BOM UTF-8字节顺序标记，Windows上的编辑器常在文件开头写入它
+const BOM = "\ufeff"
+
+// StripBOM 去掉s开头的一个UTF-8 BOM，字符串中间的BOM保持不变
+func StripBOM(s string) string {
+	return strings.TrimPrefix(s, BOM)
+}
+
 // FormatOptions 格式化选项列表用于显示
 func FormatOptions(options []string) string {
 	var builder strings.Builder
@@ -33,4 +98,83 @@ func FormatOptions(options []string) string {
 		builder.WriteString(fmt.Sprintf("%d. %s\n", i+1, option))
 	}
 	return builder.String()
-}
\ No newline at end of file
+}
+
+// OptionIndex 返回value在options中第一次出现的下标（从0开始），不存在时返回-1和false
+func OptionIndex(options []string, value string) (int, bool) {
+	for i, option := range options {
+		if option == value {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// OptionAt 返回下标为i（从0开始）的选项，i越界时返回空字符串和false
+func OptionAt(options []string, i int) (string, bool) {
+	if i < 0 || i >= len(options) {
+		return "", false
+	}
+	return options[i], true
+}
+
+// ParseBool 解析yes/no风格的布尔输入（y/yes/true/1 与 n/no/false/0，不区分大小写）
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "y", "yes", "true", "1":
+		return true, nil
+	case "n", "no", "false", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean value %q (expected yes or no)", s)
+}
+
+// BoolFormat 输出答案时布尔值的写法，零值BoolTrueFalse在JSON和TOML中是原生的布尔值，
+// 在CSV、环境变量等文本格式中是true/false
+type BoolFormat int
+
+const (
+	BoolTrueFalse BoolFormat = iota // true/false
+	BoolYesNo                       // 字符串yes/no
+	BoolOneZero                     // 数字1/0
+)
+
+// ParseBoolFormat 解析命令行中的布尔值写法：true/false、yes/no或1/0
+func ParseBoolFormat(s string) (BoolFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true/false", "":
+		return BoolTrueFalse, nil
+	case "yes/no":
+		return BoolYesNo, nil
+	case "1/0":
+		return BoolOneZero, nil
+	}
+	return BoolTrueFalse, fmt.Errorf("invalid boolean format %q (expected true/false, yes/no or 1/0)", s)
+}
+
+// Apply 按f转换答案中的布尔值，包括[]interface{}中的元素，其他值原样返回。
+// BoolYesNo得到字符串，BoolOneZero得到int，输出时再按各格式的规则序列化
+func (f BoolFormat) Apply(value interface{}) interface{} {
+	switch v := value.(type) {
+	case bool:
+		switch f {
+		case BoolYesNo:
+			if v {
+				return "yes"
+			}
+			return "no"
+		case BoolOneZero:
+			if v {
+				return 1
+			}
+			return 0
+		}
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = f.Apply(item)
+		}
+		return items
+	}
+	return value
+}
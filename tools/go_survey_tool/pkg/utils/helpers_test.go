@@ -1,6 +1,7 @@
 package utils_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
@@ -18,6 +19,12 @@ func TestIsEmpty(t *testing.T) {
 		{"Mixed whitespace", " \t \n ", true},
 		{"Non-empty", "hello", false},
 		{"Non-empty with spaces", " hello world ", false},
+		{"Only zero width space", "\u200b", true},
+		{"Zero width characters and spaces", " \u200b\u200d\ufeff\u2060 ", true},
+		{"BOM only", "\ufeff", true},
+		{"Non-breaking and ideographic spaces", "\u00a0\u3000", true},
+		{"Text between zero width spaces", "\u200bhi\u200b", false},
+		{"Combining mark is not blank", "\u0301", false},
 	}
 
 	for _, tt := range tests {
@@ -30,6 +37,56 @@ func TestIsEmpty(t *testing.T) {
 	}
 }
 
+func TestOptionIndex(t *testing.T) {
+	options := []string{"Red", "Blue", "Red"}
+	tests := []struct {
+		name  string
+		value string
+		index int
+		ok    bool
+	}{
+		{"First", "Red", 0, true},
+		{"Second", "Blue", 1, true},
+		{"Not found", "Green", -1, false},
+		{"Case sensitive", "blue", -1, false},
+		{"Empty", "", -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, ok := utils.OptionIndex(options, tt.value)
+			if index != tt.index || ok != tt.ok {
+				t.Errorf("OptionIndex(%q) = %d, %v, want %d, %v", tt.value, index, ok, tt.index, tt.ok)
+			}
+		})
+	}
+}
+
+func TestOptionAt(t *testing.T) {
+	options := []string{"Red", "Blue"}
+	tests := []struct {
+		name  string
+		i     int
+		value string
+		ok    bool
+	}{
+		{"First", 0, "Red", true},
+		{"Last", 1, "Blue", true},
+		{"Past the end", 2, "", false},
+		{"Negative", -1, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := utils.OptionAt(options, tt.i)
+			if value != tt.value || ok != tt.ok {
+				t.Errorf("OptionAt(%d) = %q, %v, want %q, %v", tt.i, value, ok, tt.value, tt.ok)
+			}
+		})
+	}
+	if _, ok := utils.OptionAt(nil, 0); ok {
+		t.Error("OptionAt(nil, 0) should report out of range")
+	}
+}
+
 func TestFormatOptions(t *testing.T) {
 	options := []string{"Option A", "Option B", "Option C"}
 	expected := "1. Option A\n2. Option B\n3. Option C\n"
@@ -61,4 +118,137 @@ func TestValidateNotEmpty(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestParseBool(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+		hasError bool
+	}{
+		{"y", true, false},
+		{"YES", true, false},
+		{" true ", true, false},
+		{"1", true, false},
+		{"n", false, false},
+		{"No", false, false},
+		{"false", false, false},
+		{"0", false, false},
+		{"", false, true},
+		{"maybe", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := utils.ParseBool(tt.input)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("ParseBool(%q) error = %v, want error = %v", tt.input, err, tt.hasError)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseBool(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBoolFormat(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []interface{}
+	}{
+		{"true/false", []interface{}{true, false, "x", []interface{}{true, "y"}}},
+		{"yes/no", []interface{}{"yes", "no", "x", []interface{}{"yes", "y"}}},
+		{"1/0", []interface{}{1, 0, "x", []interface{}{1, "y"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			f, err := utils.ParseBoolFormat(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseBoolFormat() error = %v", err)
+			}
+			got := []interface{}{f.Apply(true), f.Apply(false), f.Apply("x"), f.Apply([]interface{}{true, "y"})}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+	if _, err := utils.ParseBoolFormat("on/off"); err == nil {
+		t.Error("ParseBoolFormat(on/off) should fail")
+	}
+}
+
+func TestCollapseSpace(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"  Alice  ", "Alice"},
+		{"New\t\tYork", "New York"},
+		{"line one\n  line two\r\n", "line one line two"},
+	}
+	for _, tt := range tests {
+		if got := utils.CollapseSpace(tt.input); got != tt.expected {
+			t.Errorf("CollapseSpace(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		input    string
+		seps     []rune
+		expected []string
+	}{
+		{"", nil, []string{}},
+		{" ,\t, \u200b ", nil, []string{}},
+		{"go, rust  python\njava", nil, []string{"go", "rust", "python", "java"}},
+		{",,a,, b ,", nil, []string{"a", "b"}},
+		{"New York; San Jose ;;", []rune{';'}, []string{"New York", "San Jose"}},
+		{"a|b;c d", []rune{'|', ';'}, []string{"a", "b", "c d"}},
+	}
+	for _, tt := range tests {
+		got := utils.SplitList(tt.input, tt.seps...)
+		if got == nil || !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("SplitList(%q, %q) = %#v, want %#v", tt.input, tt.seps, got, tt.expected)
+		}
+	}
+}
+
+func TestIndentMultiline(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"Alice", "Alice"},
+		{"line one\nline two", "line one\n   line two"},
+		{"a\r\n\r\nc\rd", "a\n   \n   c\n   d"},
+		{"trailing\n", "trailing\n   "},
+	}
+	for _, tt := range tests {
+		if got := utils.IndentMultiline(tt.input, "   "); got != tt.expected {
+			t.Errorf("IndentMultiline(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestStripBOM(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Blue", "Blue"},
+		{"\ufeffBlue", "Blue"},
+		{"\ufeff\ufeffBlue", "\ufeffBlue"},
+		{"Bl\ufeffue", "Bl\ufeffue"},
+		{"\ufeff", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := utils.StripBOM(tt.input); got != tt.expected {
+			t.Errorf("StripBOM(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
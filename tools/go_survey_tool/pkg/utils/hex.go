@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// HexDump 以hexdump -C的格式输出b：偏移、每行16个字节的十六进制和可打印字符
+// b为空时返回空串
+func HexDump(b []byte) string {
+	return hex.Dump(b)
+}
+
+// HexInline 把b格式化为以空格分隔的单行十六进制，例如"1b 5b 41"
+func HexInline(b []byte) string {
+	parts := make([]string, len(b))
+	for i, c := range b {
+		parts[i] = fmt.Sprintf("%02x", c)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,54 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+func TestHexInline(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected string
+	}{
+		{"Empty", nil, ""},
+		{"Arrow key", []byte("\x1b[A"), "1b 5b 41"},
+		{"Non-printable", []byte{0x00, 0x7f, 0xff}, "00 7f ff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.HexInline(tt.input); got != tt.expected {
+				t.Errorf("HexInline(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHexDump(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected string
+	}{
+		{"Empty", []byte{}, ""},
+		{
+			"Non-printable shown as dots",
+			[]byte("\x1b[A\r\nok\x00"),
+			"00000000  1b 5b 41 0d 0a 6f 6b 00                           |.[A..ok.|\n",
+		},
+		{
+			"Second line",
+			[]byte("0123456789abcdefXY"),
+			"00000000  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  |0123456789abcdef|\n" +
+				"00000010  58 59                                             |XY|\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.HexDump(tt.input); got != tt.expected {
+				t.Errorf("HexDump(%q) =\n%q\nwant\n%q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
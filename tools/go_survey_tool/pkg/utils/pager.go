@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// DefaultPager PAGER未设置时使用的分页程序，-R让less保留颜色控制序列
+const DefaultPager = "less -R"
+
+// PageThrough 把content交给分页程序显示，见PageThroughTo
+func PageThrough(content string) error {
+	return PageThroughTo(os.Stdout, content)
+}
+
+// PageThroughTo f是终端时把content通过$PAGER（默认less -R）显示在f上，
+// 否则直接写入f，避免分页程序的输出混入管道。分页程序不存在或无法启动时同样直接写入
+func PageThroughTo(f *os.File, content string) error {
+	return pageTo(f, content, os.Getenv("PAGER"), term.IsTerminal(int(f.Fd())))
+}
+
+// pageTo PageThroughTo的实现，pager为PAGER的值，tty表示w是否为终端
+func pageTo(w io.Writer, content, pager string, tty bool) error {
+	if !tty {
+		return writeAll(w, content)
+	}
+	if strings.TrimSpace(pager) == "" {
+		pager = DefaultPager
+	}
+	// PAGER中可以带参数，例如"less -R"
+	args := strings.Fields(pager)
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return writeAll(w, content)
+	}
+
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = w
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return writeAll(w, content)
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("分页程序%s失败: %w", args[0], err)
+	}
+	return nil
+}
+
+// writeAll 把content原样写入w
+func writeAll(w io.Writer, content string) error {
+	_, err := io.WriteString(w, content)
+	return err
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestPageTo(t *testing.T) {
+	if _, err := exec.LookPath("sed"); err != nil {
+		t.Skip("sed not available")
+	}
+	tests := []struct {
+		name  string
+		pager string
+		tty   bool
+		want  string
+	}{
+		{"Not a terminal", "sed s/a/b/", false, "a\n"},
+		{"Pager with arguments", "sed s/a/b/", true, "b\n"},
+		{"Pager not installed", "no-such-pager-xyz -R", true, "a\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := pageTo(&out, "a\n", tt.pager, tt.tty); err != nil {
+				t.Fatalf("pageTo() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("pageTo() wrote %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPageToPagerFails(t *testing.T) {
+	if _, err := exec.LookPath("false"); err != nil {
+		t.Skip("false not available")
+	}
+	if err := pageTo(&bytes.Buffer{}, "a\n", "false", true); err == nil {
+		t.Error("pageTo() with a failing pager should return an error")
+	}
+}
+
+func TestPageThroughToPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	t.Setenv("PAGER", "false")
+
+	if err := PageThroughTo(w, "hello\n"); err != nil {
+		t.Fatalf("PageThroughTo() error = %v", err)
+	}
+	w.Close()
+	var got bytes.Buffer
+	got.ReadFrom(r)
+	if got.String() != "hello\n" {
+		t.Errorf("PageThroughTo() on a pipe wrote %q, want the content unchanged", got.String())
+	}
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StrengthOpts 密码强度要求
+type StrengthOpts struct {
+	MinLength     int      // 最小长度（按字符计算）
+	RequireUpper  bool     // 必须包含大写字母
+	RequireLower  bool     // 必须包含小写字母
+	RequireDigit  bool     // 必须包含数字
+	RequireSymbol bool     // 必须包含符号
+	Banned        []string // 禁止包含的子串（不区分大小写）
+}
+
+// ValidatePasswordStrength 根据opts生成密码强度验证函数
+// 返回的错误会列出所有未满足的要求，而不仅是第一个
+func ValidatePasswordStrength(opts StrengthOpts) func(string) error {
+	return func(password string) error {
+		var failed []string
+
+		if opts.MinLength > 0 && utf8.RuneCountInString(password) < opts.MinLength {
+			failed = append(failed, fmt.Sprintf("at least %d characters", opts.MinLength))
+		}
+
+		var hasUpper, hasLower, hasDigit, hasSymbol bool
+		for _, r := range password {
+			switch {
+			case unicode.IsUpper(r):
+				hasUpper = true
+			case unicode.IsLower(r):
+				hasLower = true
+			case unicode.IsDigit(r):
+				hasDigit = true
+			case unicode.IsPunct(r) || unicode.IsSymbol(r):
+				hasSymbol = true
+			}
+		}
+		if opts.RequireUpper && !hasUpper {
+			failed = append(failed, "an uppercase letter")
+		}
+		if opts.RequireLower && !hasLower {
+			failed = append(failed, "a lowercase letter")
+		}
+		if opts.RequireDigit && !hasDigit {
+			failed = append(failed, "a digit")
+		}
+		if opts.RequireSymbol && !hasSymbol {
+			failed = append(failed, "a symbol")
+		}
+
+		lower := strings.ToLower(password)
+		for _, banned := range opts.Banned {
+			if banned != "" && strings.Contains(lower, strings.ToLower(banned)) {
+				failed = append(failed, fmt.Sprintf("must not contain %q", banned))
+			}
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("password does not meet requirements: %s", strings.Join(failed, ", "))
+		}
+		return nil
+	}
+}
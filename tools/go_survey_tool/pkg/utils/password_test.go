@@ -0,0 +1,72 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+func TestValidatePasswordStrength(t *testing.T) {
+	validate := utils.ValidatePasswordStrength(utils.StrengthOpts{
+		MinLength:     8,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		Banned:        []string{"password", "omnish"},
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		wantErr  bool
+		contains string
+	}{
+		{"Too short", "Ab1!", true, "at least 8 characters"},
+		{"Missing uppercase", "abcdef1!", true, "an uppercase letter"},
+		{"Missing lowercase", "ABCDEF1!", true, "a lowercase letter"},
+		{"Missing digit", "Abcdefg!", true, "a digit"},
+		{"Missing symbol", "Abcdefg1", true, "a symbol"},
+		{"Banned substring", "MyPassword1!", true, `must not contain "password"`},
+		{"Banned substring case-insensitive", "x0OMNISH!y", true, `must not contain "omnish"`},
+		{"Strong password", "Tr0ub4dor&3x", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("validate(%q) error = %q, want it to contain %q", tt.input, err.Error(), tt.contains)
+			}
+		})
+	}
+}
+
+func TestValidatePasswordStrengthListsAllFailures(t *testing.T) {
+	validate := utils.ValidatePasswordStrength(utils.StrengthOpts{
+		MinLength:    10,
+		RequireUpper: true,
+		RequireDigit: true,
+	})
+
+	err := validate("short")
+	if err == nil {
+		t.Fatal("expected an error for a weak password")
+	}
+	for _, want := range []string{"at least 10 characters", "an uppercase letter", "a digit"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing requirement %q", err.Error(), want)
+		}
+	}
+}
+
+func TestValidatePasswordStrengthZeroOpts(t *testing.T) {
+	validate := utils.ValidatePasswordStrength(utils.StrengthOpts{})
+	if err := validate(""); err != nil {
+		t.Errorf("empty options should accept any password, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ShellQuote 把s转换为POSIX shell中安全的单引号字符串
+// 单引号内没有任何转义，内嵌的单引号先结束引号、输出转义的单引号再重新开始引号
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// EnvName 把key转换为可用作环境变量名的形式：ASCII字母转为大写，
+// 其他不是ASCII字母、数字或下划线的字符（包括非ASCII字符）替换为下划线；
+// 以数字开头或key为空时在前面加一个下划线，例如"db-host"为DB_HOST，"2fa"为_2FA
+func EnvName(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	name := b.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// EnvValue 把答案格式化为环境变量的值：bool为true/false，切片的元素用空格连接，其他同FormatValue
+func EnvValue(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case []string:
+		return strings.Join(v, " ")
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = EnvValue(item)
+		}
+		return strings.Join(parts, " ")
+	default:
+		return FormatValue(v)
+	}
+}
+
+// ExportLine 返回设置环境变量的shell语句，例如export COLOR='light blue'，
+// 变量名由EnvName转换，值由EnvValue格式化并用ShellQuote引用
+func ExportLine(key string, value interface{}) string {
+	return "export " + EnvName(key) + "=" + ShellQuote(EnvValue(value))
+}
@@ -0,0 +1,115 @@
+package utils_test
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+var shellQuoteTests = []struct {
+	name     string
+	input    string
+	expected string
+}{
+	{"Empty", "", "''"},
+	{"Plain", "blue", "'blue'"},
+	{"Spaces", "light blue", "'light blue'"},
+	{"Single quote", "it's", `'it'\''s'`},
+	{"Only quotes", "''", `''\'''\'''`},
+	{"Double quotes and dollar", `say "$HOME"`, `'say "$HOME"'`},
+	{"Newline", "a\nb", "'a\nb'"},
+	{"Backslash and backtick", "a\\b`c`", "'a\\b`c`'"},
+}
+
+func TestShellQuote(t *testing.T) {
+	for _, tt := range shellQuoteTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.ShellQuote(tt.input); got != tt.expected {
+				t.Errorf("ShellQuote(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestShellQuoteRoundTrip(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+	for _, tt := range shellQuoteTests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := exec.Command(sh, "-c", "printf %s "+utils.ShellQuote(tt.input)).Output()
+			if err != nil {
+				t.Fatalf("sh error = %v", err)
+			}
+			if string(out) != tt.input {
+				t.Errorf("sh printed %q, want %q", out, tt.input)
+			}
+		})
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"color", "COLOR"},
+		{"DB_HOST", "DB_HOST"},
+		{"db-host", "DB_HOST"},
+		{"first name", "FIRST_NAME"},
+		{"a.b/c", "A_B_C"},
+		{"2fa", "_2FA"},
+		{"名字", "__"},
+		{"café", "CAF_"},
+		{"", "_"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := utils.EnvName(tt.key); got != tt.expected {
+				t.Errorf("EnvName(%q) = %q, want %q", tt.key, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExportLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		value    interface{}
+		expected string
+	}{
+		{"String", "color", "light blue", "export COLOR='light blue'"},
+		{"Quote", "motto", "it's", `export MOTTO='it'\''s'`},
+		{"True", "like", true, "export LIKE='true'"},
+		{"False", "like", false, "export LIKE='false'"},
+		{"Slice", "colors", []string{"Red", "Light Blue"}, "export COLORS='Red Light Blue'"},
+		{"Decoded slice", "colors", []interface{}{"Red", true}, "export COLORS='Red true'"},
+		{"Nil", "empty", nil, "export EMPTY=''"},
+		{"Sanitized key", "db-host", "localhost", "export DB_HOST='localhost'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.ExportLine(tt.key, tt.value); got != tt.expected {
+				t.Errorf("ExportLine(%q, %v) = %q, want %q", tt.key, tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExportLineEval(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+	script := utils.ExportLine("motto", `it's "$HOME"`) + "; printf %s \"$MOTTO\""
+	out, err := exec.Command(sh, "-c", script).Output()
+	if err != nil {
+		t.Fatalf("sh error = %v", err)
+	}
+	if string(out) != `it's "$HOME"` {
+		t.Errorf("sh printed %q, want the value unchanged", out)
+	}
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// Symbols 状态标记和提示使用的符号
+type Symbols struct {
+	Success  string // 检查通过
+	Failure  string // 检查失败
+	Warning  string // 警告
+	Question string // 问题前缀
+	Selected string // 选择菜单中的当前项
+
+	Arrows    string // 操作提示中的上下方向键
+	Separator string // 操作提示中各项之间的分隔符
+	Border    string // BoxText的边框，依次为左上、右上、左下、右下角、横线和竖线
+}
+
+// 预置的符号集。Question和Selected与survey的默认图标一致
+var (
+	UnicodeSymbols = Symbols{Success: "✓", Failure: "✗", Warning: "⚠", Question: "?", Selected: ">", Arrows: "↑/↓", Separator: "·", Border: "┌┐└┘─│"}
+	ASCIISymbols   = Symbols{Success: "[OK]", Failure: "[FAIL]", Warning: "[WARN]", Question: "?", Selected: ">", Arrows: "up/down", Separator: "|", Border: "++++-|"}
+)
+
+var (
+	symbolsMu sync.RWMutex
+	current   = SymbolsForTerm(os.Getenv("TERM"))
+)
+
+// SymbolsForTerm 根据TERM选择符号集：dumb终端通常无法显示Unicode符号，使用ASCII
+func SymbolsForTerm(term string) Symbols {
+	if term == "dumb" {
+		return ASCIISymbols
+	}
+	return UnicodeSymbols
+}
+
+// SetSymbols 设置所有工具使用的符号集
+func SetSymbols(s Symbols) {
+	symbolsMu.Lock()
+	defer symbolsMu.Unlock()
+	current = s
+}
+
+// CurrentSymbols 返回当前的符号集，默认按TERM环境变量自动选择
+func CurrentSymbols() Symbols {
+	symbolsMu.RLock()
+	defer symbolsMu.RUnlock()
+	return current
+}
+
+// Colored 返回状态标记着色后的副本：通过为绿色、失败为红色、警告为黄色。
+// Question和Selected由survey负责着色，保持不变
+func (s Symbols) Colored() Symbols {
+	s.Success = Colorize(s.Success, Green)
+	s.Failure = Colorize(s.Failure, Red)
+	s.Warning = Colorize(s.Warning, Yellow)
+	return s
+}
+
+// FormatHints 把操作提示组合成一行，例如"[↑/↓ move · enter select]"，
+// 使用当前符号集的分隔符（未设置时为"|"），颜色启用时整行暗色显示。
+// width > 0时先截断到width列再着色，避免切掉复位序列；hints为空时返回""
+func FormatHints(hints []string, width int) string {
+	if len(hints) == 0 {
+		return ""
+	}
+	sep := CurrentSymbols().Separator
+	if sep == "" {
+		sep = "|"
+	}
+	line := "[" + strings.Join(hints, " "+sep+" ") + "]"
+	if width > 0 {
+		line = FitToWidth(line, width)
+	}
+	return Colorize(line, Dim)
+}
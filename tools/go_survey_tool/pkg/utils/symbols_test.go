@@ -0,0 +1,85 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+func TestSymbolsForTerm(t *testing.T) {
+	tests := []struct {
+		term     string
+		expected utils.Symbols
+	}{
+		{"dumb", utils.ASCIISymbols},
+		{"xterm-256color", utils.UnicodeSymbols},
+		{"", utils.UnicodeSymbols},
+	}
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			if got := utils.SymbolsForTerm(tt.term); got != tt.expected {
+				t.Errorf("SymbolsForTerm(%q) = %+v, want %+v", tt.term, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetSymbols(t *testing.T) {
+	old := utils.CurrentSymbols()
+	defer utils.SetSymbols(old)
+
+	custom := utils.Symbols{Success: "ok", Failure: "no", Warning: "!!", Question: "Q", Selected: "*"}
+	utils.SetSymbols(custom)
+	if got := utils.CurrentSymbols(); got != custom {
+		t.Errorf("CurrentSymbols() = %+v, want %+v", got, custom)
+	}
+}
+
+func TestASCIISymbolsArePlainASCII(t *testing.T) {
+	s := utils.ASCIISymbols
+	for _, sym := range []string{s.Success, s.Failure, s.Warning, s.Question, s.Selected, s.Arrows, s.Separator} {
+		for _, r := range sym {
+			if r > 0x7f {
+				t.Errorf("ASCII symbol %q contains non-ASCII rune %q", sym, r)
+			}
+		}
+	}
+}
+
+func TestFormatHints(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	old := utils.CurrentSymbols()
+	defer utils.SetSymbols(old)
+
+	tests := []struct {
+		name    string
+		symbols utils.Symbols
+		hints   []string
+		width   int
+		want    string
+	}{
+		{"Unicode", utils.UnicodeSymbols, []string{"a", "b"}, 0, "[a · b]"},
+		{"ASCII", utils.ASCIISymbols, []string{"a", "b"}, 0, "[a | b]"},
+		{"No separator", utils.Symbols{}, []string{"a", "b"}, 0, "[a | b]"},
+		{"Single hint", utils.UnicodeSymbols, []string{"q quit"}, 0, "[q quit]"},
+		{"Fits width", utils.UnicodeSymbols, []string{"a", "b"}, 7, "[a · b]"},
+		{"Truncated", utils.UnicodeSymbols, []string{"move", "select"}, 8, "[move ·…"},
+		{"No hints", utils.UnicodeSymbols, nil, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.SetSymbols(tt.symbols)
+			if got := utils.FormatHints(tt.hints, tt.width); got != tt.want {
+				t.Errorf("FormatHints(%q, %d) = %q, want %q", tt.hints, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatHintsColor(t *testing.T) {
+	t.Setenv("TERM", "xterm")
+	unsetEnv(t, "NO_COLOR")
+	if got := utils.FormatHints([]string{"a"}, 0); got != "\x1b[2m[a]\x1b[0m" {
+		t.Errorf("FormatHints() = %q, want the hint line dimmed", got)
+	}
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FormatKeyValues 把键值对格式化为两列对齐的表格，每行一个键，键按显示宽度右侧补齐
+// 键按order的顺序输出，order中不存在于kv的键被忽略，不在order中的键按字母顺序排在最后。
+// 值按FormatValue转换为文本
+func FormatKeyValues(kv map[string]interface{}, order []string) string {
+	keys := orderedKeys(order, kv)
+	keyWidth := 0
+	for _, key := range keys {
+		if w := DisplayWidth(key); w > keyWidth {
+			keyWidth = w
+		}
+	}
+
+	var b strings.Builder
+	for _, key := range keys {
+		b.WriteString(key)
+		b.WriteString(strings.Repeat(" ", keyWidth-DisplayWidth(key)+2))
+		b.WriteString(FormatValue(kv[key]))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// FormatDiff 列出old和new中值不同的键，每行一个"key: 旧值 → 新值"，旧值为红色、新值为绿色，
+// 用于编辑已有配置后确认改动。值按FormatValue的文本比较，相同的键不输出；
+// 只在一边存在的键，缺少的一边显示为"(none)"。键的顺序与FormatKeyValues相同，没有改动时返回空字符串
+func FormatDiff(old, new map[string]interface{}, order []string) string {
+	var b strings.Builder
+	for _, key := range orderedKeys(order, old, new) {
+		before, hadBefore := old[key]
+		after, hasAfter := new[key]
+		if hadBefore && hasAfter && FormatValue(before) == FormatValue(after) {
+			continue
+		}
+		fmt.Fprintf(&b, "%s: %s → %s\n", key, diffValue(before, hadBefore, Red), diffValue(after, hasAfter, Green))
+	}
+	return b.String()
+}
+
+// diffValue 返回FormatDiff中一边的值，不存在时为"(none)"
+func diffValue(value interface{}, ok bool, c Color) string {
+	if !ok {
+		return Colorize("(none)", Dim)
+	}
+	return Colorize(FormatValue(value), c)
+}
+
+// orderedKeys 返回maps中所有的键：先按order的顺序，其余的按字母顺序排在最后
+func orderedKeys(order []string, maps ...map[string]interface{}) []string {
+	var keys []string
+	listed := make(map[string]bool, len(order))
+	has := func(key string) bool {
+		for _, m := range maps {
+			if _, ok := m[key]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	for _, key := range order {
+		if has(key) && !listed[key] {
+			keys = append(keys, key)
+			listed[key] = true
+		}
+	}
+	var rest []string
+	for _, m := range maps {
+		for key := range m {
+			if !listed[key] {
+				rest = append(rest, key)
+				listed[key] = true
+			}
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// FormatValue 把答案值转换为显示用的文本：nil为空，切片的元素用", "连接
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ", ")
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = FormatValue(item)
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// FormatOptionsLimited 把选项用", "连接，最多显示前limit个，其余用"… (+N more)"表示
+// len(options)<=limit或limit<=0时显示全部选项
+func FormatOptionsLimited(options []string, limit int) string {
+	if limit <= 0 || len(options) <= limit {
+		return strings.Join(options, ", ")
+	}
+	return fmt.Sprintf("%s, … (+%d more)", strings.Join(options[:limit], ", "), len(options)-limit)
+}
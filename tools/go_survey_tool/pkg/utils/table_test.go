@@ -0,0 +1,110 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+func TestFormatKeyValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		kv       map[string]interface{}
+		order    []string
+		expected string
+	}{
+		{
+			name:     "Aligned in given order",
+			kv:       map[string]interface{}{"name": "Alice", "favorite color": "Blue", "age": 30},
+			order:    []string{"name", "favorite color", "age"},
+			expected: "name            Alice\nfavorite color  Blue\nage             30\n",
+		},
+		{
+			name:     "Unlisted keys sorted last, missing keys ignored",
+			kv:       map[string]interface{}{"b": 1, "a": 2, "c": 3},
+			order:    []string{"c", "missing"},
+			expected: "c  3\na  2\nb  1\n",
+		},
+		{
+			name:     "Nil and slice values",
+			kv:       map[string]interface{}{"tags": []string{"go", "cli"}, "mixed": []interface{}{"x", 1, nil}, "none": nil},
+			order:    []string{"tags", "mixed", "none"},
+			expected: "tags   go, cli\nmixed  x, 1, \nnone   \n",
+		},
+		{
+			name:     "Wide keys",
+			kv:       map[string]interface{}{"名字": "Alice", "id": true},
+			order:    []string{"名字", "id"},
+			expected: "名字  Alice\nid    true\n",
+		},
+		{
+			name:     "Empty",
+			kv:       nil,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.FormatKeyValues(tt.kv, tt.order); got != tt.expected {
+				t.Errorf("FormatKeyValues() =\n%q\nwant\n%q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatOptionsLimited(t *testing.T) {
+	options := []string{"Red", "Blue", "Green", "Yellow"}
+	tests := []struct {
+		name     string
+		options  []string
+		limit    int
+		expected string
+	}{
+		{"Below limit", options[:2], 3, "Red, Blue"},
+		{"At limit", options[:3], 3, "Red, Blue, Green"},
+		{"One over", options, 3, "Red, Blue, Green, … (+1 more)"},
+		{"Several over", options, 1, "Red, … (+3 more)"},
+		{"No limit", options, 0, "Red, Blue, Green, Yellow"},
+		{"Empty", nil, 3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.FormatOptionsLimited(tt.options, tt.limit); got != tt.expected {
+				t.Errorf("FormatOptionsLimited() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	old := map[string]interface{}{"name": "Alice", "color": "Blue", "port": 8080, "tags": []string{"a", "b"}}
+	new := map[string]interface{}{"name": "Alice", "color": "Red", "debug": true, "tags": []interface{}{"a", "b"}}
+
+	got := utils.FormatDiff(old, new, []string{"name", "color", "port"})
+	want := "color: Blue → Red\nport: 8080 → (none)\ndebug: (none) → true\n"
+	if got != want {
+		t.Errorf("FormatDiff() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatDiffNoChanges(t *testing.T) {
+	answers := map[string]interface{}{"name": "Alice"}
+	if got := utils.FormatDiff(answers, answers, nil); got != "" {
+		t.Errorf("FormatDiff() of equal maps = %q, want empty", got)
+	}
+	if got := utils.FormatDiff(nil, nil, []string{"name"}); got != "" {
+		t.Errorf("FormatDiff(nil, nil) = %q, want empty", got)
+	}
+}
+
+func TestFormatDiffColors(t *testing.T) {
+	unsetEnv(t, "NO_COLOR")
+	t.Setenv("TERM", "xterm-256color")
+	got := utils.FormatDiff(map[string]interface{}{"a": 1}, map[string]interface{}{"a": 2}, nil)
+	want := "a: \x1b[31m1\x1b[0m → \x1b[32m2\x1b[0m\n"
+	if got != want {
+		t.Errorf("FormatDiff() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// AllValidators 组合多个验证函数，依次执行并在第一个错误处停止
+// 没有传入任何验证函数时总是通过
+func AllValidators(vs ...func(string) error) func(string) error {
+	return func(input string) error {
+		for _, v := range vs {
+			if err := v(input); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// AnyValidator 组合多个验证函数，只要有一个通过即通过，在第一个通过处停止
+// 全部失败时返回合并了所有失败原因的错误；没有传入任何验证函数时总是通过
+func AnyValidator(vs ...func(string) error) func(string) error {
+	return func(input string) error {
+		if len(vs) == 0 {
+			return nil
+		}
+		var reasons []string
+		for _, v := range vs {
+			err := v(input)
+			if err == nil {
+				return nil
+			}
+			reasons = append(reasons, err.Error())
+		}
+		return fmt.Errorf("%s", strings.Join(reasons, " or "))
+	}
+}
+
+// ValidateLength 验证输入的字符数在[min, max]范围内，max <= 0表示不限制最大长度
+func ValidateLength(min, max int) func(string) error {
+	return func(input string) error {
+		n := utf8.RuneCountInString(input)
+		if n < min {
+			return fmt.Errorf("value must be at least %d characters", min)
+		}
+		if max > 0 && n > max {
+			return fmt.Errorf("value must be at most %d characters", max)
+		}
+		return nil
+	}
+}
+
+// ValidateRegex 验证输入完整匹配正则表达式pattern
+// pattern无效属于编程错误，会直接panic
+func ValidateRegex(pattern string) func(string) error {
+	re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+	return func(input string) error {
+		if !re.MatchString(input) {
+			return fmt.Errorf("value must match pattern %s", pattern)
+		}
+		return nil
+	}
+}
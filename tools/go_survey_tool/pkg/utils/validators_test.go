@@ -0,0 +1,126 @@
+package utils_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+// countingValidator 返回记录调用次数的验证函数
+func countingValidator(err error, calls *int) func(string) error {
+	return func(string) error {
+		*calls++
+		return err
+	}
+}
+
+func TestAllValidators(t *testing.T) {
+	validate := utils.AllValidators(utils.ValidateNotEmpty, utils.ValidateLength(3, 5), utils.ValidateRegex(`[a-z]+`))
+
+	tests := []struct {
+		name     string
+		input    string
+		hasError bool
+	}{
+		{"Empty", "", true},
+		{"Too short", "ab", true},
+		{"Too long", "abcdef", true},
+		{"Pattern mismatch", "ab1", true},
+		{"Valid", "abcd", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validate(tt.input); (err != nil) != tt.hasError {
+				t.Errorf("validate(%q) error = %v, want error = %v", tt.input, err, tt.hasError)
+			}
+		})
+	}
+}
+
+func TestAllValidatorsShortCircuits(t *testing.T) {
+	var first, second int
+	validate := utils.AllValidators(
+		countingValidator(errors.New("first failed"), &first),
+		countingValidator(nil, &second),
+	)
+	if err := validate("x"); err == nil || err.Error() != "first failed" {
+		t.Errorf("validate() error = %v, want the first error", err)
+	}
+	if first != 1 || second != 0 {
+		t.Errorf("calls = (%d, %d), want (1, 0)", first, second)
+	}
+}
+
+func TestAnyValidator(t *testing.T) {
+	validate := utils.AnyValidator(utils.ValidateRegex(`\d+`), utils.ValidateRegex(`[a-z]+`))
+
+	if err := validate("123"); err != nil {
+		t.Errorf("digits should pass: %v", err)
+	}
+	if err := validate("abc"); err != nil {
+		t.Errorf("letters should pass: %v", err)
+	}
+	err := validate("a1")
+	if err == nil {
+		t.Fatal("mixed input should fail")
+	}
+	if !strings.Contains(err.Error(), `\d+`) || !strings.Contains(err.Error(), "[a-z]+") {
+		t.Errorf("error should list every reason, got %q", err.Error())
+	}
+}
+
+func TestAnyValidatorShortCircuits(t *testing.T) {
+	var first, second int
+	validate := utils.AnyValidator(
+		countingValidator(nil, &first),
+		countingValidator(errors.New("unused"), &second),
+	)
+	if err := validate("x"); err != nil {
+		t.Errorf("validate() error = %v", err)
+	}
+	if first != 1 || second != 0 {
+		t.Errorf("calls = (%d, %d), want (1, 0)", first, second)
+	}
+}
+
+func TestValidatorsEmptyVariadic(t *testing.T) {
+	if err := utils.AllValidators()("anything"); err != nil {
+		t.Errorf("AllValidators() should pass, got %v", err)
+	}
+	if err := utils.AnyValidator()("anything"); err != nil {
+		t.Errorf("AnyValidator() should pass, got %v", err)
+	}
+}
+
+func TestValidateLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max int
+		input    string
+		hasError bool
+	}{
+		{"Within range", 2, 4, "abc", false},
+		{"Below min", 2, 4, "a", true},
+		{"Above max", 2, 4, "abcde", true},
+		{"No max", 1, 0, strings.Repeat("a", 100), false},
+		{"Counts runes not bytes", 1, 2, "你好", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := utils.ValidateLength(tt.min, tt.max)(tt.input); (err != nil) != tt.hasError {
+				t.Errorf("ValidateLength(%d, %d)(%q) error = %v, want error = %v", tt.min, tt.max, tt.input, err, tt.hasError)
+			}
+		})
+	}
+}
+
+func TestValidateRegexMatchesWholeInput(t *testing.T) {
+	validate := utils.ValidateRegex(`\d{3}|x`)
+	for input, hasError := range map[string]bool{"123": false, "x": false, "1234": true, "a123": true} {
+		if err := validate(input); (err != nil) != hasError {
+			t.Errorf("validate(%q) error = %v, want error = %v", input, err, hasError)
+		}
+	}
+}
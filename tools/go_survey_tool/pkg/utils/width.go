@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// 零宽连接符，用于把多个emoji组合成一个字形（如家庭、职业emoji）
+const zeroWidthJoiner = '\u200d'
+
+// emojiPresentation 变体选择符VS16，要求前一个字符按emoji显示，终端中占2列（如❤️、1️⃣）
+const emojiPresentation = '\ufe0f'
+
+// DisplayWidth 计算字符串在终端中占用的列数
+// 按Unicode东亚宽度规则：宽字符和全角字符占2列，组合字符、格式字符、控制字符和ANSI颜色序列占0列；
+// 通过零宽连接符组合的emoji序列和肤色修饰符视为一个字形，不额外占列，
+// 带VS16的字符按emoji显示占2列。字形的划分见nextCluster
+func DisplayWidth(s string) int {
+	total := 0
+	for s != "" {
+		size, w := nextCluster(s)
+		total += w
+		s = s[size:]
+	}
+	return total
+}
+
+// nextCluster 返回s开头的字形的字节数和显示列数
+// 字形为一个字符加上其后的零宽字符（组合字符、变体选择符等）和肤色修饰符，
+// 零宽连接符后的字符也并入同一个字形。截断和折行只在字形边界处进行，
+// 避免把emoji序列切成两半，或让残留的零宽连接符与后面的省略号连在一起
+func nextCluster(s string) (size, width int) {
+	if n := csiLength(s); n > 0 {
+		return n, 0
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	width = runeWidth(r)
+	for size < len(s) {
+		next, n := utf8.DecodeRuneInString(s[size:])
+		switch {
+		case next == zeroWidthJoiner:
+			size += n
+			if size < len(s) {
+				_, joined := utf8.DecodeRuneInString(s[size:])
+				size += joined
+			}
+		case next == emojiPresentation:
+			size += n
+			width = max(width, 2)
+		case isEmojiModifier(next), isZeroWidth(next):
+			size += n
+		default:
+			return size, width
+		}
+	}
+	return size, width
+}
+
+// csiLength s以ANSI控制序列（如颜色"\x1b[2m"）开头时返回序列的字节数，否则返回0
+// 控制序列不占列，整体作为一个字形，截断时不会被切开
+func csiLength(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// runeWidth 返回单个字符占用的列数
+func runeWidth(r rune) int {
+	if r < 0x20 || (r >= 0x7f && r < 0xa0) || isZeroWidth(r) {
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// isZeroWidth 判断是否为附着在前一个字符上的零宽字符：组合字符和格式字符
+func isZeroWidth(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf)
+}
+
+// isEmojiModifier 判断是否为emoji肤色修饰符（U+1F3FB到U+1F3FF）
+func isEmojiModifier(r rune) bool {
+	return r >= 0x1f3fb && r <= 0x1f3ff
+}
+
+// WrapText 按显示宽度把文本折行到width列以内
+// 优先在空格处断行；单个词（包括不含空格的中文句子）超过width时按字符强制断开，
+// 原有的换行符会被保留。width <= 0时原样返回
+func WrapText(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		lines = append(lines, wrapLine(paragraph, width)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapLine 折行单个段落
+func wrapLine(s string, width int) []string {
+	var lines []string
+	var current strings.Builder
+	currentWidth := 0
+
+	for _, word := range strings.Fields(s) {
+		wordWidth := DisplayWidth(word)
+		if currentWidth > 0 && currentWidth+1+wordWidth <= width {
+			current.WriteByte(' ')
+			current.WriteString(word)
+			currentWidth += 1 + wordWidth
+			continue
+		}
+
+		if currentWidth > 0 {
+			lines = append(lines, current.String())
+			current.Reset()
+			currentWidth = 0
+		}
+		for wordWidth > width {
+			head, tail := splitAtWidth(word, width)
+			lines = append(lines, head)
+			word = tail
+			wordWidth = DisplayWidth(word)
+		}
+		current.WriteString(word)
+		currentWidth = wordWidth
+	}
+
+	if currentWidth > 0 || len(lines) == 0 {
+		lines = append(lines, current.String())
+	}
+	return lines
+}
+
+// splitAtWidth 在不超过width列的最后一个字形边界处把s分成两段
+// 第一个字形就比width宽时也至少切出一个字形，避免死循环
+func splitAtWidth(s string, width int) (string, string) {
+	cut, used := 0, 0
+	for cut < len(s) {
+		size, w := nextCluster(s[cut:])
+		if cut > 0 && used+w > width {
+			break
+		}
+		cut += size
+		used += w
+	}
+	return s[:cut], s[cut:]
+}
+
+// ellipsis FitToWidth截断时追加的省略号，占1列
+const ellipsis = "…"
+
+// FitToWidth 把s截断到不超过width列（按DisplayWidth计算），被截断时末尾用省略号代替
+// 不会把宽字符或emoji序列切成两半；width<=1时只返回省略号
+func FitToWidth(s string, width int) string {
+	if width <= 1 {
+		return ellipsis
+	}
+	if DisplayWidth(s) <= width {
+		return s
+	}
+
+	limit := width - DisplayWidth(ellipsis)
+	cut, used := 0, 0
+	for cut < len(s) {
+		size, w := nextCluster(s[cut:])
+		if used+w > limit {
+			break
+		}
+		cut += size
+		used += w
+	}
+	return s[:cut] + ellipsis
+}
+
+// FormatOptionsAligned 格式化带编号的选项列表，编号右对齐使得序号后的点对齐
+// descriptions不为空时，选项后用点引线补齐到最宽选项（按显示宽度），再输出描述列
+func FormatOptionsAligned(options []string, descriptions []string) string {
+	numberWidth := len(strconv.Itoa(len(options)))
+	labelWidth := 0
+	for _, option := range options {
+		if w := DisplayWidth(option); w > labelWidth {
+			labelWidth = w
+		}
+	}
+
+	var builder strings.Builder
+	for i, option := range options {
+		builder.WriteString(fmt.Sprintf("%*d. %s", numberWidth, i+1, option))
+		if i < len(descriptions) && descriptions[i] != "" {
+			dots := strings.Repeat(".", labelWidth-DisplayWidth(option)+2)
+			builder.WriteString(" " + dots + " " + descriptions[i])
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
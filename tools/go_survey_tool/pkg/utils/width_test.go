@@ -0,0 +1,152 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yrlihuan/omnish/tools/go_survey_tool/pkg/utils"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{"Empty string", "", 0},
+		{"ASCII", "hello", 5},
+		{"CJK", "你好", 4},
+		{"Mixed CJK and ASCII", "选项 1: 红色", 12},
+		{"Fullwidth letters", "ＡＢ", 4},
+		{"Emoji", "👍", 2},
+		{"Emoji with skin tone", "👍🏽", 2},
+		{"ZWJ family sequence", "👨‍👩‍👧", 2},
+		{"Combining accent", "é", 1},
+		{"Zero width space", "a​b", 2},
+		{"Flag", "🇨🇳", 2},
+		{"Emoji presentation selector", "❤️", 2},
+		{"Text heart", "❤", 1},
+		{"Keycap", "1️⃣", 2},
+		{"ZWJ with selector", "🏳️‍🌈", 2},
+		{"ZWJ family then text", "👨‍👩‍👧ok", 4},
+		{"Control characters", "a\tb\x1b", 2},
+		{"Color sequence", "\x1b[2m[ab]\x1b[0m", 4},
+		{"Unterminated sequence", "\x1b[2", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.DisplayWidth(tt.input); got != tt.expected {
+				t.Errorf("DisplayWidth(%q) = %d, want %d", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		expected string
+	}{
+		{"Fits", "hello world", 20, "hello world"},
+		{"Breaks at spaces", "the quick brown fox", 10, "the quick\nbrown fox"},
+		{"Keeps newlines", "a\nb c", 10, "a\nb c"},
+		{"Long word hard break", "abcdefghij", 4, "abcd\nefgh\nij"},
+		{"CJK breaks by display width", "你好世界再见", 5, "你好\n世界\n再见"},
+		{"Emoji kept whole", "👍🏽👍🏽👍🏽", 4, "👍🏽👍🏽\n👍🏽"},
+		{"ZWJ sequence kept whole", "👨‍👩‍👧👨‍👩‍👧👨‍👩‍👧", 4, "👨‍👩‍👧👨‍👩‍👧\n👨‍👩‍👧"},
+		{"Wide rune wider than width", "你好", 1, "你\n好"},
+		{"Zero width disables wrapping", "a b c", 0, "a b c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.WrapText(tt.input, tt.width); got != tt.expected {
+				t.Errorf("WrapText(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFitToWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		expected string
+	}{
+		{"Fits", "hello", 5, "hello"},
+		{"Truncated", "hello world", 8, "hello w…"},
+		{"CJK not split", "你好世界", 6, "你好…"},
+		{"CJK odd width", "你好世界", 5, "你好…"},
+		{"CJK fits exactly", "你好世界", 8, "你好世界"},
+		{"Emoji with modifier kept whole", "👍🏽👍🏽", 3, "👍🏽…"},
+		{"ZWJ sequence kept whole", "👨‍👩‍👧👨‍👩‍👧", 3, "👨‍👩‍👧…"},
+		{"ZWJ sequence dropped whole", "a👨‍👩‍👧", 2, "a…"},
+		{"Combining accent kept", "éé", 2, "éé"},
+		{"Combining accent not orphaned", "éééé", 3, "éé…"},
+		{"Mixed", "ab你好cd", 5, "ab你…"},
+		{"Width one", "hello", 1, "…"},
+		{"Width zero", "hello", 0, "…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := utils.FitToWidth(tt.input, tt.width)
+			if got != tt.expected {
+				t.Errorf("FitToWidth(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.expected)
+			}
+			if tt.width > 1 && utils.DisplayWidth(got) > tt.width {
+				t.Errorf("FitToWidth(%q, %d) is %d columns wide", tt.input, tt.width, utils.DisplayWidth(got))
+			}
+		})
+	}
+}
+
+func TestFormatOptionsAligned(t *testing.T) {
+	t.Run("Right-aligned numbers", func(t *testing.T) {
+		options := make([]string, 10)
+		for i := range options {
+			options[i] = "x"
+		}
+		lines := strings.Split(strings.TrimSuffix(utils.FormatOptionsAligned(options, nil), "\n"), "\n")
+		if lines[0] != " 1. x" || lines[9] != "10. x" {
+			t.Errorf("unexpected numbering: %q ... %q", lines[0], lines[9])
+		}
+	})
+
+	t.Run("Dots end at the same column", func(t *testing.T) {
+		// ASCII、中文、带零宽连接符和VS16的emoji混排
+		options := []string{"Deploy", "部署到生产", "👨‍👩‍👧 Family", "❤️ Favorites", "🏳️‍🌈"}
+		descriptions := []string{"ship it", "上线", "shared", "starred", "pride"}
+		lines := strings.Split(strings.TrimSuffix(utils.FormatOptionsAligned(options, descriptions), "\n"), "\n")
+
+		column := -1
+		for i, line := range lines {
+			dotsEnd := strings.LastIndex(line, ".") + 1
+			if w := utils.DisplayWidth(line[:dotsEnd]); column == -1 {
+				column = w
+			} else if w != column {
+				t.Errorf("line %d dots end at column %d, want %d: %q", i, w, column, line)
+			}
+		}
+	})
+
+	t.Run("Descriptions aligned by display width", func(t *testing.T) {
+		options := []string{"Red", "红色", "👍🏽"}
+		descriptions := []string{"warm", "暖色", "ok"}
+		lines := strings.Split(strings.TrimSuffix(utils.FormatOptionsAligned(options, descriptions), "\n"), "\n")
+
+		column := -1
+		for i, line := range lines {
+			prefix := line[:strings.LastIndex(line, " ")]
+			if w := utils.DisplayWidth(prefix); column == -1 {
+				column = w
+			} else if w != column {
+				t.Errorf("line %d description starts at column %d, want %d: %q", i, w, column, line)
+			}
+		}
+	})
+}